@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/compact"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+const (
+	autoCompactLastRunKey = "auto_compact_last_run" // Metadata key recording when the daemon last compacted
+	autoCompactStateKey   = "auto_compact_state"    // Metadata key of the autoCompactState
+
+	// autoCompactRetryAfter is how long the daemon leaves an issue alone after
+	// failing to compact it, so issues that keep failing don't hold up the rest
+	autoCompactRetryAfter = 7 * 24 * time.Hour
+)
+
+// autoCompactState is what the daemon remembers between runs
+type autoCompactState struct {
+	Runs   []autoCompactRun     `json:"runs"`   // Runs of the last 24h, for the daily limit
+	Failed map[string]time.Time `json:"failed"` // When compacting each issue last failed
+}
+
+type autoCompactRun struct {
+	At       time.Time `json:"at"`
+	Attempts int       `json:"attempts"` // Issues sent to the summarizer, compacted or not
+}
+
+func loadAutoCompactState(ctx context.Context, s *sqlite.SQLiteStorage, now time.Time) (*autoCompactState, error) {
+	state := &autoCompactState{}
+	value, err := s.GetMetadata(ctx, autoCompactStateKey)
+	if err != nil {
+		return nil, err
+	}
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), state); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", autoCompactStateKey, err)
+		}
+	}
+	if state.Failed == nil {
+		state.Failed = make(map[string]time.Time)
+	}
+
+	// Forget what no longer matters
+	runs := state.Runs[:0]
+	for _, run := range state.Runs {
+		if now.Sub(run.At) < 24*time.Hour {
+			runs = append(runs, run)
+		}
+	}
+	state.Runs = runs
+	for id, at := range state.Failed {
+		if now.Sub(at) >= autoCompactRetryAfter {
+			delete(state.Failed, id)
+		}
+	}
+	return state, nil
+}
+
+func (st *autoCompactState) save(ctx context.Context, s *sqlite.SQLiteStorage) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", autoCompactStateKey, err)
+	}
+	return s.SetMetadata(ctx, autoCompactStateKey, string(data))
+}
+
+// attempts returns the issues sent to the summarizer in the last 24h
+func (st *autoCompactState) attempts() int {
+	total := 0
+	for _, run := range st.Runs {
+		total += run.Attempts
+	}
+	return total
+}
+
+// autoCompactSettings controls compaction in the daemon, read from config
+type autoCompactSettings struct {
+	Enabled    bool          // auto_compact_enabled
+	Interval   time.Duration // auto_compact_interval: minimum time between runs
+	BatchSize  int           // compact_batch_size: most issues compacted per run
+	Workers    int           // compact_parallel_workers: concurrent summarizer calls
+	DailyLimit int           // auto_compact_daily_limit: most compaction attempts per 24h, 0 for no limit
+}
+
+func loadAutoCompactSettings(ctx context.Context, s *sqlite.SQLiteStorage) (*autoCompactSettings, error) {
+	get := func(key, fallback string) (string, error) {
+		value, err := s.GetConfig(ctx, key)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		if value == "" {
+			value = fallback
+		}
+		return value, nil
+	}
+	getInt := func(key, fallback string) (int, error) {
+		value, err := get(key, fallback)
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid %s %q: must be a non-negative number", key, value)
+		}
+		return n, nil
+	}
+
+	settings := &autoCompactSettings{}
+	enabled, err := get("auto_compact_enabled", "false")
+	if err != nil {
+		return nil, err
+	}
+	if settings.Enabled, err = strconv.ParseBool(enabled); err != nil {
+		return nil, fmt.Errorf("invalid auto_compact_enabled %q", enabled)
+	}
+	interval, err := get("auto_compact_interval", "24h")
+	if err != nil {
+		return nil, err
+	}
+	if settings.Interval, err = parseRelativeDuration(interval); err != nil {
+		return nil, fmt.Errorf("invalid auto_compact_interval: %w", err)
+	}
+	if settings.BatchSize, err = getInt("compact_batch_size", "50"); err != nil {
+		return nil, err
+	}
+	if settings.Workers, err = getInt("compact_parallel_workers", "5"); err != nil {
+		return nil, err
+	}
+	if settings.DailyLimit, err = getInt("auto_compact_daily_limit", "200"); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// runAutoCompaction compacts eligible issues, Tier 1 before Tier 2, when
+// auto_compact_enabled is set and auto_compact_interval has passed since the last
+// run. Each run handles at most compact_batch_size issues and stays within the
+// daily limit, which counts every attempt, failed or not. Issues restored with
+// bd restore and issues that failed within autoCompactRetryAfter are left alone.
+// Returns the number of issues compacted.
+func runAutoCompaction(ctx context.Context, s *sqlite.SQLiteStorage, now time.Time, log func(string, ...interface{})) int {
+	settings, err := loadAutoCompactSettings(ctx, s)
+	if err != nil {
+		log("Auto-compaction skipped: %v", err)
+		return 0
+	}
+	if !settings.Enabled {
+		return 0
+	}
+
+	lastRun, err := s.GetMetadata(ctx, autoCompactLastRunKey)
+	if err != nil {
+		log("Auto-compaction skipped: %v", err)
+		return 0
+	}
+	if last, err := time.Parse(time.RFC3339, lastRun); err == nil && now.Sub(last) < settings.Interval {
+		return 0
+	}
+
+	state, err := loadAutoCompactState(ctx, s, now)
+	if err != nil {
+		log("Auto-compaction skipped: %v", err)
+		return 0
+	}
+	limit := settings.BatchSize
+	if settings.DailyLimit > 0 {
+		if remaining := settings.DailyLimit - state.attempts(); remaining < limit {
+			limit = remaining
+		}
+		if limit <= 0 {
+			log("Auto-compaction skipped: daily limit of %d issues reached", settings.DailyLimit)
+			return 0
+		}
+	}
+	restored, err := s.GetRestoredIssueIDs(ctx)
+	if err != nil {
+		log("Auto-compaction skipped: %v", err)
+		return 0
+	}
+
+	config, err := newCompactConfig(ctx, nil, s, false)
+	if err != nil {
+		log("Auto-compaction skipped: %v", err)
+		return 0
+	}
+	config.Concurrency = settings.Workers
+	compactor, err := compact.New(s, config.APIKey, config)
+	if err != nil {
+		log("Auto-compaction skipped: %v", err)
+		return 0
+	}
+
+	// Record the run up front so a failing provider isn't retried every sync cycle
+	if err := s.SetMetadata(ctx, autoCompactLastRunKey, now.UTC().Format(time.RFC3339)); err != nil {
+		log("Auto-compaction skipped: failed to record run: %v", err)
+		return 0
+	}
+
+	run := autoCompactRun{At: now}
+	total := 0
+	for _, tier := range []int{1, 2} {
+		if limit <= 0 || ctx.Err() != nil {
+			break
+		}
+
+		var candidates []*sqlite.CompactionCandidate
+		if tier == 1 {
+			candidates, err = s.GetTier1Candidates(ctx)
+		} else {
+			candidates, err = s.GetTier2Candidates(ctx)
+		}
+		if err != nil {
+			log("Auto-compaction: failed to get Tier %d candidates: %v", tier, err)
+			break
+		}
+		var ids []string
+		for _, c := range candidates {
+			if len(ids) == limit {
+				break
+			}
+			if _, failed := state.Failed[c.IssueID]; failed || restored[c.IssueID] {
+				continue
+			}
+			ids = append(ids, c.IssueID)
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		var results []*compact.CompactResult
+		if tier == 1 {
+			results, err = compactor.CompactTier1Batch(ctx, ids)
+		} else {
+			results, err = compactor.CompactTier2Batch(ctx, ids)
+		}
+		if err != nil {
+			log("Auto-compaction: Tier %d batch failed: %v", tier, err)
+			break
+		}
+		limit -= len(ids)
+		run.Attempts += len(ids)
+
+		succeeded, saved := 0, 0
+		var firstErr error
+		for _, r := range results {
+			if r.Err != nil {
+				state.Failed[r.IssueID] = now
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s: %w", r.IssueID, r.Err)
+				}
+				continue
+			}
+			delete(state.Failed, r.IssueID)
+			succeeded++
+			saved += r.OriginalSize - r.CompactedSize
+		}
+		total += succeeded
+		log("Auto-compaction: Tier %d compacted %d of %d issues (saved %d bytes)", tier, succeeded, len(results), saved)
+		if firstErr != nil {
+			log("Auto-compaction: %d failed, first error: %v", len(results)-succeeded, firstErr)
+		}
+		if succeeded == 0 {
+			// Every call failed (rate limited, server down): back off until the next run
+			break
+		}
+	}
+
+	if run.Attempts > 0 {
+		state.Runs = append(state.Runs, run)
+	}
+	if err := state.save(ctx, s); err != nil {
+		log("Auto-compaction: failed to record run: %v", err)
+	}
+	return total
+}
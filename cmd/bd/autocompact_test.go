@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRunAutoCompaction(t *testing.T) {
+	testStore, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer testStore.Close()
+
+	ctx := context.Background()
+	var logged []string
+	logf := func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+
+	closedAt := time.Now().Add(-48 * time.Hour)
+	for i := 1; i <= 5; i++ {
+		issue := &types.Issue{
+			ID:          fmt.Sprintf("test-%d", i),
+			Title:       "Old closed issue",
+			Description: strings.Repeat("A long description of what went wrong and how it was fixed. ", 10),
+			Notes:       strings.Repeat("Notes from debugging. ", 20),
+			Status:      types.StatusClosed,
+			Priority:    2,
+			IssueType:   types.TypeTask,
+			CreatedAt:   closedAt.Add(-time.Hour),
+			UpdatedAt:   closedAt,
+			ClosedAt:    &closedAt,
+		}
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	now := time.Now()
+	if n := runAutoCompaction(ctx, testStore, now, logf); n != 0 {
+		t.Fatalf("Expected nothing compacted while disabled, got %d", n)
+	}
+
+	for key, value := range map[string]string{
+		"auto_compact_enabled":     "true",
+		"auto_compact_interval":    "1h",
+		"auto_compact_daily_limit": "3",
+		"compact_provider":         "offline",
+		"compact_tier1_days":       "0",
+		"compact_batch_size":       "2",
+	} {
+		if err := testStore.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+
+	if n := runAutoCompaction(ctx, testStore, now, logf); n != 2 {
+		t.Fatalf("Expected a batch of 2 compacted, got %d (log: %v)", n, logged)
+	}
+
+	// Within the interval nothing runs
+	if n := runAutoCompaction(ctx, testStore, now.Add(30*time.Minute), logf); n != 0 {
+		t.Errorf("Expected no run within the interval, got %d", n)
+	}
+
+	// The daily limit of 3 leaves room for one more
+	if n := runAutoCompaction(ctx, testStore, now.Add(2*time.Hour), logf); n != 1 {
+		t.Errorf("Expected 1 compacted under the daily limit, got %d (log: %v)", n, logged)
+	}
+	if n := runAutoCompaction(ctx, testStore, now.Add(4*time.Hour), logf); n != 0 {
+		t.Errorf("Expected the daily limit to stop compaction, got %d", n)
+	}
+	if last := logged[len(logged)-1]; !strings.Contains(last, "daily limit of 3 issues reached") {
+		t.Errorf("Expected the exhausted budget to be logged, got %q", last)
+	}
+
+	compacted := 0
+	for i := 1; i <= 5; i++ {
+		issue, _ := testStore.GetIssue(ctx, fmt.Sprintf("test-%d", i))
+		if issue.CompactionLevel == 1 {
+			compacted++
+		}
+	}
+	if compacted != 3 {
+		t.Errorf("Expected 3 issues at Tier 1, got %d", compacted)
+	}
+}
+
+func TestRunAutoCompactionSkipsFailedAndRestored(t *testing.T) {
+	testStore, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer testStore.Close()
+
+	ctx := context.Background()
+	var logged []string
+	logf := func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+
+	// test-1 closed first, so it heads the candidates; its summary can't be shorter
+	// than its description, so compacting it always fails
+	for i := 1; i <= 4; i++ {
+		closedAt := time.Now().Add(-time.Duration(100-i) * time.Hour)
+		description := strings.Repeat("A long description of what went wrong and how it was fixed. ", 10)
+		if i == 1 {
+			description = "x"
+		}
+		issue := &types.Issue{
+			ID:          fmt.Sprintf("test-%d", i),
+			Title:       "Old closed issue",
+			Description: description,
+			Status:      types.StatusClosed,
+			Priority:    2,
+			IssueType:   types.TypeTask,
+			CreatedAt:   closedAt.Add(-time.Hour),
+			UpdatedAt:   closedAt,
+			ClosedAt:    &closedAt,
+		}
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for key, value := range map[string]string{
+		"auto_compact_enabled":     "true",
+		"auto_compact_interval":    "1h",
+		"auto_compact_daily_limit": "3",
+		"compact_provider":         "offline",
+		"compact_tier1_days":       "0",
+		"compact_batch_size":       "2",
+	} {
+		if err := testStore.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+
+	// Restoring test-2 exempts it from automatic compaction
+	original, _ := testStore.GetIssue(ctx, "test-2")
+	if err := testStore.RestoreCompaction(ctx, original, nil, "test"); err != nil {
+		t.Fatalf("RestoreCompaction failed: %v", err)
+	}
+
+	now := time.Now()
+	if n := runAutoCompaction(ctx, testStore, now, logf); n != 1 {
+		t.Fatalf("Expected test-3 compacted beside the failing test-1, got %d (log: %v)", n, logged)
+	}
+
+	// The failed issue isn't retried, and the failed attempt counts toward the daily limit
+	if n := runAutoCompaction(ctx, testStore, now.Add(2*time.Hour), logf); n != 1 {
+		t.Errorf("Expected test-4 compacted, got %d (log: %v)", n, logged)
+	}
+	if n := runAutoCompaction(ctx, testStore, now.Add(4*time.Hour), logf); n != 0 {
+		t.Errorf("Expected the daily limit to stop compaction, got %d", n)
+	}
+	if last := logged[len(logged)-1]; !strings.Contains(last, "daily limit of 3 issues reached") {
+		t.Errorf("Expected the exhausted budget to be logged, got %q", last)
+	}
+
+	for id, level := range map[string]int{"test-1": 0, "test-2": 0, "test-3": 1, "test-4": 1} {
+		issue, _ := testStore.GetIssue(ctx, id)
+		if issue.CompactionLevel != level {
+			t.Errorf("Expected %s at level %d, got %d", id, level, issue.CompactionLevel)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Apply operations to many issues at once",
+}
+
+var bulkUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update every issue matching a filter in one transaction",
+	Long: `Update every issue matching a filter in one transaction.
+
+The --where filter is a space-separated list of key:value terms. All terms
+must match (label may be repeated):
+
+  status:open  priority:2  type:bug  assignee:alice  label:legacy  title:text
+
+Examples:
+  bd bulk update --where 'label:legacy status:open' --set priority=3 --add-label cleanup --assignee bob
+  bd bulk update --where 'assignee:agent-7 status:in_progress' --set status=open --dry-run`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		where, _ := cmd.Flags().GetString("where")
+		sets, _ := cmd.Flags().GetStringArray("set")
+		addLabels, _ := cmd.Flags().GetStringSlice("add-label")
+		removeLabels, _ := cmd.Flags().GetStringSlice("remove-label")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if strings.TrimSpace(where) == "" {
+			fmt.Fprintf(os.Stderr, "Error: --where is required (use 'status:open' etc. to select issues)\n")
+			os.Exit(1)
+		}
+
+		filter, err := parseWhereClause(where)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		updates, err := parseSetAssignments(sets)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if cmd.Flags().Changed("assignee") {
+			assignee, _ := cmd.Flags().GetString("assignee")
+			updates["assignee"] = assignee
+		}
+
+		if len(updates) == 0 && len(addLabels) == 0 && len(removeLabels) == 0 {
+			fmt.Println("No updates specified")
+			return
+		}
+
+		ctx := context.Background()
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(issues) == 0 {
+			if jsonOutput {
+				outputJSON([]*types.Issue{})
+			} else {
+				fmt.Println("No issues match the filter")
+			}
+			return
+		}
+
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+
+		if dryRun {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{
+					"dry_run":       true,
+					"matched":       ids,
+					"updates":       updates,
+					"add_labels":    addLabels,
+					"remove_labels": removeLabels,
+				})
+				return
+			}
+			yellow := color.New(color.FgYellow).SprintFunc()
+			fmt.Printf("\n%s Dry run: would update %d issue(s)\n\n", yellow("⚠"), len(issues))
+			for _, issue := range issues {
+				fmt.Printf("%s: %s\n", issue.ID, issue.Title)
+				for _, change := range describeBulkChanges(issue, updates, addLabels, removeLabels) {
+					fmt.Printf("  %s\n", change)
+				}
+			}
+			fmt.Println()
+			return
+		}
+
+		if err := store.UpdateIssues(ctx, ids, updates, addLabels, removeLabels, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Closing recurring issues spawns their next occurrences
+		var spawned []*types.Issue
+		if updates["status"] == string(types.StatusClosed) {
+			for _, id := range ids {
+				if next := spawnNextOccurrence(ctx, id); next != nil {
+					spawned = append(spawned, next)
+				}
+			}
+		}
+
+		// Schedule auto-flush once for the whole batch
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			updated := make([]*types.Issue, 0, len(ids))
+			for _, id := range ids {
+				if issue, _ := store.GetIssue(ctx, id); issue != nil {
+					updated = append(updated, issue)
+				}
+			}
+			outputJSON(updated)
+			return
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Updated %d issue(s): %s\n", green("✓"), len(ids), strings.Join(ids, ", "))
+		for _, issue := range spawned {
+			printSpawnedOccurrence(issue)
+		}
+	},
+}
+
+// parseWhereClause parses a space-separated list of key:value terms into an IssueFilter
+func parseWhereClause(where string) (types.IssueFilter, error) {
+	var filter types.IssueFilter
+	for _, term := range strings.Fields(where) {
+		parts := strings.SplitN(term, ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return filter, fmt.Errorf("invalid filter term '%s' (expected key:value)", term)
+		}
+		key, value := strings.ToLower(parts[0]), parts[1]
+
+		switch key {
+		case "status":
+			status := types.Status(value)
+			if !status.IsValid() {
+				return filter, fmt.Errorf("invalid status in filter: %s", value)
+			}
+			filter.Status = &status
+		case "priority":
+			priority, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(value), "P"))
+			if err != nil || priority < 0 || priority > 4 {
+				return filter, fmt.Errorf("invalid priority in filter: %s (expected 0-4)", value)
+			}
+			filter.Priority = &priority
+		case "type":
+			issueType := types.IssueType(value)
+			if !issueType.IsValid() {
+				return filter, fmt.Errorf("invalid type in filter: %s", value)
+			}
+			filter.IssueType = &issueType
+		case "assignee":
+			assignee := value
+			filter.Assignee = &assignee
+		case "label":
+			filter.Labels = append(filter.Labels, value)
+		case "title":
+			filter.TitleSearch = value
+		default:
+			return filter, fmt.Errorf("unknown filter key '%s' (valid: status, priority, type, assignee, label, title)", key)
+		}
+	}
+	return filter, nil
+}
+
+// bulkSetFields maps --set keys to issue columns accepted by UpdateIssue
+var bulkSetFields = map[string]string{
+	"status":              "status",
+	"priority":            "priority",
+	"title":               "title",
+	"assignee":            "assignee",
+	"type":                "issue_type",
+	"issue_type":          "issue_type",
+	"design":              "design",
+	"notes":               "notes",
+	"acceptance-criteria": "acceptance_criteria",
+	"acceptance_criteria": "acceptance_criteria",
+	"estimate":            "estimated_minutes",
+	"estimated_minutes":   "estimated_minutes",
+	"external-ref":        "external_ref",
+	"external_ref":        "external_ref",
+	"due":                 "due_at",
+	"due_at":              "due_at",
+	"defer":               "defer_until",
+	"defer_until":         "defer_until",
+	"recur":               "recurrence",
+	"recurrence":          "recurrence",
+}
+
+// parseSetAssignments parses key=value assignments into an update map
+func parseSetAssignments(sets []string) (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+	for _, assignment := range sets {
+		parts := strings.SplitN(assignment, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid --set value '%s' (expected field=value)", assignment)
+		}
+		field, ok := bulkSetFields[strings.ToLower(strings.TrimSpace(parts[0]))]
+		if !ok {
+			return nil, fmt.Errorf("unsupported field for --set: %s", parts[0])
+		}
+		value := strings.TrimSpace(parts[1])
+
+		switch field {
+		case "priority", "estimated_minutes":
+			if field == "priority" {
+				value = strings.TrimPrefix(strings.ToUpper(value), "P")
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value: %s", field, value)
+			}
+			updates[field] = n
+		case "due_at", "defer_until":
+			t, err := parseDateFlag(value, field == "due_at")
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value: %v", field, err)
+			}
+			updates[field] = t
+		default:
+			updates[field] = value
+		}
+	}
+	return updates, nil
+}
+
+// describeBulkChanges renders the changes a bulk update would make to an issue (for --dry-run)
+func describeBulkChanges(issue *types.Issue, updates map[string]interface{}, addLabels, removeLabels []string) []string {
+	current := map[string]interface{}{
+		"status":              string(issue.Status),
+		"priority":            issue.Priority,
+		"title":               issue.Title,
+		"assignee":            issue.Assignee,
+		"issue_type":          string(issue.IssueType),
+		"design":              issue.Design,
+		"notes":               issue.Notes,
+		"acceptance_criteria": issue.AcceptanceCriteria,
+		"estimated_minutes":   "",
+		"external_ref":        "",
+		"due_at":              "",
+		"defer_until":         "",
+		"recurrence":          issue.Recurrence,
+	}
+	if issue.EstimatedMinutes != nil {
+		current["estimated_minutes"] = *issue.EstimatedMinutes
+	}
+	if issue.ExternalRef != nil {
+		current["external_ref"] = *issue.ExternalRef
+	}
+	if issue.DueAt != nil {
+		current["due_at"] = formatDate(*issue.DueAt)
+	}
+	if issue.DeferUntil != nil {
+		current["defer_until"] = formatDate(*issue.DeferUntil)
+	}
+
+	fields := make([]string, 0, len(updates))
+	for field := range updates {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var changes []string
+	for _, field := range fields {
+		oldValue := fmt.Sprint(current[field])
+		newValue := fmt.Sprint(updates[field])
+		if t, ok := updates[field].(*time.Time); ok {
+			newValue = ""
+			if t != nil {
+				newValue = formatDate(*t)
+			}
+		}
+		if oldValue == newValue {
+			changes = append(changes, fmt.Sprintf("%s: %s (unchanged)", field, newValue))
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%s: %s → %s", field, oldValue, newValue))
+	}
+	for _, label := range addLabels {
+		changes = append(changes, fmt.Sprintf("+label %s", label))
+	}
+	for _, label := range removeLabels {
+		changes = append(changes, fmt.Sprintf("-label %s", label))
+	}
+	return changes
+}
+
+func init() {
+	bulkUpdateCmd.Flags().String("where", "", "Filter selecting issues (e.g., 'label:legacy status:open')")
+	bulkUpdateCmd.Flags().StringArray("set", []string{}, "Field assignment field=value (repeatable, e.g., --set priority=3)")
+	bulkUpdateCmd.Flags().StringSlice("add-label", []string{}, "Labels to add (comma-separated)")
+	bulkUpdateCmd.Flags().StringSlice("remove-label", []string{}, "Labels to remove (comma-separated)")
+	bulkUpdateCmd.Flags().StringP("assignee", "a", "", "New assignee")
+	bulkUpdateCmd.Flags().Bool("dry-run", false, "Preview changes without applying them")
+	bulkCmd.AddCommand(bulkUpdateCmd)
+	rootCmd.AddCommand(bulkCmd)
+}
@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/compact"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
@@ -20,26 +25,68 @@ var (
 	compactBatch   int
 	compactWorkers int
 	compactStats   bool
+	compactOffline bool
+	compactVerify  bool
+	compactPreview string
 )
 
+// promptsDirName is the directory under .beads holding compaction prompt overrides
+const promptsDirName = "prompts"
+
 var compactCmd = &cobra.Command{
 	Use:   "compact",
 	Short: "Compact old closed issues to save space",
 	Long: `Compact old closed issues using semantic summarization.
 
 Compaction reduces database size by summarizing closed issues that are no longer
-actively referenced. This is permanent graceful decay - original content is discarded.
+actively referenced. Originals stay recoverable with 'bd restore'.
 
 Tiers:
   - Tier 1: Semantic compression (30 days closed, 70% reduction)
   - Tier 2: Ultra compression (90 days closed, 95% reduction)
 
+Each pass saves a snapshot of the issue first. Tier 2 also moves the issue's
+events into that snapshot, keeping only the compaction record in the audit trail.
+
 Examples:
   bd compact --dry-run                  # Preview candidates
   bd compact --all                      # Compact all eligible issues
+  bd compact --tier 2 --all             # Ultra-compress Tier 1 issues
   bd compact --id bd-42                 # Compact specific issue
   bd compact --id bd-42 --force         # Force compact (bypass checks)
-  bd compact --stats                    # Show statistics
+  bd compact --stats                    # Show statistics and API usage
+  bd compact --verify                   # Audit compacted issues against snapshots
+  bd compact --preview bd-42 --tier 2   # Show the prompt that would be sent
+
+Summaries come from Claude Haiku by default (ANTHROPIC_API_KEY). To use any
+OpenAI-compatible server instead, such as a local llama.cpp or Ollama:
+  bd compact --all --provider openai --base-url http://localhost:11434/v1 --model llama3.1
+
+--offline needs no model at all: it keeps headings, the first and last paragraphs,
+acceptance criteria bullets, the start of the notes and every referenced issue ID
+and URL. Its output is reproducible, which suits CI jobs without network access.
+
+Prompts can be customized per workspace by adding Go templates to .beads/prompts/:
+tier1.tmpl and tier2.tmpl replace the built-in prompts, and tier1.bug.tmpl (or any
+tier<N>.<type>.tmpl) applies to just that issue type. Tier 1 templates can use
+{{.ID}}, {{.Type}}, {{.Title}}, {{.Description}}, {{.Design}},
+{{.AcceptanceCriteria}} and {{.Notes}}; Tier 2 templates {{.ID}}, {{.Type}},
+{{.Title}} and {{.CurrentDescription}}. Templates are checked when compaction
+starts and must include the text to summarize.
+
+--dry-run estimates the tokens each candidate needs from its content and the
+prompt, and prices them for known models. Actual token usage reported by the API
+is recorded per model; --stats shows it with the cost and bytes saved per token.
+
+Every summary is checked before it is applied: it must be shorter than the
+original, keep every issue ID and URL the original mentions, and be non-empty
+markdown (Tier 1 summaries need labels, headings or bullets). Model providers get
+two more tries, told what was wrong; issues whose summary still fails are skipped
+with a comment explaining why. --verify re-runs these checks on compacted issues
+against the original content in their snapshots.
+
+--provider, --model and --base-url are remembered in the compact_provider,
+compact_model and compact_base_url config values. OPENAI_API_KEY is sent if set.
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
@@ -55,6 +102,21 @@ Examples:
 			return
 		}
 
+		if compactVerify {
+			runCompactVerify(ctx, sqliteStore)
+			return
+		}
+
+		if compactTier != 1 && compactTier != 2 {
+			fmt.Fprintf(os.Stderr, "Error: --tier must be 1 or 2\n")
+			os.Exit(1)
+		}
+
+		if compactPreview != "" {
+			runCompactPreview(ctx, cmd, sqliteStore, compactPreview)
+			return
+		}
+
 		if compactID != "" && compactAll {
 			fmt.Fprintf(os.Stderr, "Error: cannot use --id and --all together\n")
 			os.Exit(1)
@@ -70,19 +132,15 @@ Examples:
 			os.Exit(1)
 		}
 
-		apiKey := os.Getenv("ANTHROPIC_API_KEY")
-		if apiKey == "" && !compactDryRun {
-			fmt.Fprintf(os.Stderr, "Error: ANTHROPIC_API_KEY environment variable not set\n")
+		config, err := newCompactConfig(ctx, cmd, sqliteStore, compactDryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		config := &compact.CompactConfig{
-			APIKey:      apiKey,
-			Concurrency: compactWorkers,
-			DryRun:      compactDryRun,
-		}
+		config.Concurrency = compactWorkers
 
-		compactor, err := compact.New(sqliteStore, apiKey, config)
+		compactor, err := compact.New(sqliteStore, config.APIKey, config)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create compactor: %v\n", err)
 			os.Exit(1)
@@ -97,6 +155,75 @@ Examples:
 	},
 }
 
+// compactSettings maps the summarizer flags to the config keys that remember them
+var compactSettings = []struct{ flag, key string }{
+	{"provider", "compact_provider"},
+	{"model", "compact_model"},
+	{"base-url", "compact_base_url"},
+}
+
+// newCompactConfig builds the compactor config from flags, falling back to the
+// configured provider, model and server. Flags that were given are saved for next time.
+// With a nil cmd (as in the daemon) only the configured values are used.
+func newCompactConfig(ctx context.Context, cmd *cobra.Command, store *sqlite.SQLiteStorage, dryRun bool) (*compact.CompactConfig, error) {
+	changed := func(flag string) bool { return cmd != nil && cmd.Flags().Changed(flag) }
+
+	values := make(map[string]string, len(compactSettings))
+	for _, setting := range compactSettings {
+		var value string
+		if changed(setting.flag) {
+			value, _ = cmd.Flags().GetString(setting.flag)
+		} else {
+			configured, err := store.GetConfig(ctx, setting.key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", setting.key, err)
+			}
+			value = configured
+		}
+		values[setting.flag] = value
+	}
+
+	if cmd != nil && compactOffline {
+		if changed("provider") {
+			return nil, fmt.Errorf("cannot use --offline with --provider")
+		}
+		// A one-off choice, so the configured provider is left alone
+		values["provider"] = compact.ProviderOffline
+	}
+
+	config := &compact.CompactConfig{
+		DryRun:    dryRun,
+		Provider:  values["provider"],
+		Model:     values["model"],
+		BaseURL:   values["base-url"],
+		PromptDir: filepath.Join(filepath.Dir(dbPath), promptsDirName),
+	}
+
+	switch config.Provider {
+	case "", compact.ProviderAnthropic:
+		config.APIKey = os.Getenv("ANTHROPIC_API_KEY")
+		if config.APIKey == "" && !dryRun {
+			return nil, fmt.Errorf("ANTHROPIC_API_KEY environment variable not set")
+		}
+	case compact.ProviderOpenAI:
+		if config.Model == "" || strings.HasPrefix(config.Model, "claude-") {
+			return nil, fmt.Errorf("--model is required for the %s provider (compact_model is %q)", compact.ProviderOpenAI, config.Model)
+		}
+	case compact.ProviderOffline:
+	default:
+		return nil, fmt.Errorf("unknown provider %q (want %s, %s or %s)", config.Provider, compact.ProviderAnthropic, compact.ProviderOpenAI, compact.ProviderOffline)
+	}
+
+	for _, setting := range compactSettings {
+		if changed(setting.flag) {
+			if err := store.SetConfig(ctx, setting.key, values[setting.flag]); err != nil {
+				return nil, fmt.Errorf("failed to save %s: %w", setting.key, err)
+			}
+		}
+	}
+	return config, nil
+}
+
 func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *sqlite.SQLiteStorage, issueID string) {
 	start := time.Now()
 
@@ -121,13 +248,20 @@ func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *
 	originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
 
 	if compactDryRun {
+		usage, err := compactor.EstimateUsage(issue, compactTier)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to estimate usage: %v\n", err)
+			os.Exit(1)
+		}
+
 		if jsonOutput {
 			output := map[string]interface{}{
-				"dry_run":       true,
-				"tier":          compactTier,
-				"issue_id":      issueID,
-				"original_size": originalSize,
-				"estimated_reduction": "70-80%",
+				"dry_run":             true,
+				"tier":                compactTier,
+				"issue_id":            issueID,
+				"original_size":       originalSize,
+				"estimated_reduction": estimatedReduction(compactTier),
+				"estimate":            usageEstimateJSON(compactor.Model(), usage),
 			}
 			outputJSON(output)
 			return
@@ -136,7 +270,8 @@ func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *
 		fmt.Printf("DRY RUN - Tier %d compaction\n\n", compactTier)
 		fmt.Printf("Issue: %s\n", issueID)
 		fmt.Printf("Original size: %d bytes\n", originalSize)
-		fmt.Printf("Estimated reduction: 70-80%%\n")
+		fmt.Printf("Estimated reduction: %s\n", estimatedReduction(compactTier))
+		printUsageEstimate(compactor.Model(), usage)
 		return
 	}
 
@@ -144,8 +279,7 @@ func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *
 	if compactTier == 1 {
 		compactErr = compactor.CompactTier1(ctx, issueID)
 	} else {
-		fmt.Fprintf(os.Stderr, "Error: Tier 2 compaction not yet implemented\n")
-		os.Exit(1)
+		compactErr = compactor.CompactTier2(ctx, issueID)
 	}
 
 	if compactErr != nil {
@@ -224,12 +358,28 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 
 	if compactDryRun {
 		totalSize := 0
+		var total compact.Usage
+		perIssue := make([]map[string]interface{}, 0, len(candidates))
 		for _, id := range candidates {
 			issue, err := store.GetIssue(ctx, id)
-			if err != nil {
+			if err != nil || issue == nil {
 				continue
 			}
-			totalSize += len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
+			size := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
+			totalSize += size
+
+			usage, err := compactor.EstimateUsage(issue, compactTier)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to estimate usage for %s: %v\n", id, err)
+				os.Exit(1)
+			}
+			total.Add(usage)
+			perIssue = append(perIssue, map[string]interface{}{
+				"issue_id":      id,
+				"original_size": size,
+				"input_tokens":  usage.InputTokens,
+				"output_tokens": usage.OutputTokens,
+			})
 		}
 
 		if jsonOutput {
@@ -238,7 +388,9 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 				"tier":                compactTier,
 				"candidate_count":     len(candidates),
 				"total_size_bytes":    totalSize,
-				"estimated_reduction": "70-80%",
+				"estimated_reduction": estimatedReduction(compactTier),
+				"estimate":            usageEstimateJSON(compactor.Model(), total),
+				"candidates":          perIssue,
 			}
 			outputJSON(output)
 			return
@@ -247,7 +399,8 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 		fmt.Printf("DRY RUN - Tier %d compaction\n\n", compactTier)
 		fmt.Printf("Candidates: %d issues\n", len(candidates))
 		fmt.Printf("Total size: %d bytes\n", totalSize)
-		fmt.Printf("Estimated reduction: 70-80%%\n")
+		fmt.Printf("Estimated reduction: %s\n", estimatedReduction(compactTier))
+		printUsageEstimate(compactor.Model(), total)
 		return
 	}
 
@@ -255,7 +408,13 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 		fmt.Printf("Compacting %d issues (Tier %d)...\n\n", len(candidates), compactTier)
 	}
 
-	results, err := compactor.CompactTier1Batch(ctx, candidates)
+	var results []*compact.CompactResult
+	var err error
+	if compactTier == 1 {
+		results, err = compactor.CompactTier1Batch(ctx, candidates)
+	} else {
+		results, err = compactor.CompactTier2Batch(ctx, candidates)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: batch compaction failed: %v\n", err)
 		os.Exit(1)
@@ -329,16 +488,57 @@ func runCompactStats(ctx context.Context, store *sqlite.SQLiteStorage) {
 		tier2Size += c.OriginalSize
 	}
 
+	compacted, err := store.GetCompactionStats(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	for _, level := range []int{1, 2} {
+		if compacted[level] == nil {
+			compacted[level] = &sqlite.CompactionTierStats{}
+		}
+	}
+
+	usage, err := store.GetCompactionUsage(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	models := make([]string, 0, len(usage))
+	for model := range usage {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+
 	if jsonOutput {
+		usageOutput := make(map[string]interface{}, len(usage))
+		for _, model := range models {
+			u := usage[model]
+			entry := map[string]interface{}{
+				"calls":           u.Calls,
+				"input_tokens":    u.InputTokens,
+				"output_tokens":   u.OutputTokens,
+				"issues":          u.Issues,
+				"bytes_saved":     u.BytesSaved,
+				"bytes_per_token": bytesSavedPerToken(u),
+			}
+			if cost, ok := compactionCost(model, u); ok {
+				entry["cost_usd"] = cost
+			}
+			usageOutput[model] = entry
+		}
 		output := map[string]interface{}{
 			"tier1": map[string]interface{}{
 				"candidates": len(tier1),
 				"total_size": tier1Size,
+				"compacted":  compacted[1],
 			},
 			"tier2": map[string]interface{}{
 				"candidates": len(tier2),
 				"total_size": tier2Size,
+				"compacted":  compacted[2],
 			},
+			"usage": usageOutput,
 		}
 		outputJSON(output)
 		return
@@ -358,6 +558,228 @@ func runCompactStats(ctx context.Context, store *sqlite.SQLiteStorage) {
 	if tier2Size > 0 {
 		fmt.Printf("  Estimated savings: %d bytes (95%%)\n", tier2Size*95/100)
 	}
+
+	if compacted[1].Issues+compacted[2].Issues > 0 {
+		fmt.Printf("\nAlready compacted:\n")
+		for _, level := range []int{1, 2} {
+			tier := compacted[level]
+			if tier.Issues == 0 {
+				continue
+			}
+			fmt.Printf("  Tier %d: %d issues, %d → %d bytes (saved %d)", level, tier.Issues,
+				tier.OriginalSize, tier.CompressedSize, tier.OriginalSize-tier.CompressedSize)
+			if tier.ArchivedEvents > 0 {
+				fmt.Printf(", %d events archived", tier.ArchivedEvents)
+			}
+			fmt.Println()
+		}
+	}
+
+	if len(models) > 0 {
+		fmt.Printf("\nAPI usage:\n")
+		totalCost, priced := 0.0, true
+		for _, model := range models {
+			u := usage[model]
+			fmt.Printf("  %s: %d calls, %d input + %d output tokens", model, u.Calls, u.InputTokens, u.OutputTokens)
+			if cost, ok := compactionCost(model, u); ok {
+				totalCost += cost
+				fmt.Printf(", %s", formatDollars(cost))
+			} else {
+				priced = false
+				fmt.Printf(", price unknown")
+			}
+			fmt.Printf("\n    %d issues compacted, %d bytes saved (%.1f bytes per token)\n", u.Issues, u.BytesSaved, bytesSavedPerToken(u))
+		}
+		if len(models) > 1 && priced {
+			fmt.Printf("  Total cost: %s\n", formatDollars(totalCost))
+		}
+	}
+}
+
+// formatDollars shows cents, or fractions of a cent for the small amounts single issues cost
+func formatDollars(amount float64) string {
+	if amount < 1 {
+		return fmt.Sprintf("$%.4f", amount)
+	}
+	return fmt.Sprintf("$%.2f", amount)
+}
+
+// compactionCost prices recorded usage, returning false for models without a known price
+func compactionCost(model string, u *sqlite.CompactionUsage) (float64, bool) {
+	return compact.Cost(model, compact.Usage{Calls: u.Calls, InputTokens: u.InputTokens, OutputTokens: u.OutputTokens})
+}
+
+// bytesSavedPerToken is how many bytes compaction saved for each token spent
+func bytesSavedPerToken(u *sqlite.CompactionUsage) float64 {
+	tokens := u.InputTokens + u.OutputTokens
+	if tokens == 0 {
+		return 0
+	}
+	return float64(u.BytesSaved) / float64(tokens)
+}
+
+// runCompactPreview prints the prompt --tier compaction would send for an issue
+func runCompactPreview(ctx context.Context, cmd *cobra.Command, store *sqlite.SQLiteStorage, issueID string) {
+	config, err := newCompactConfig(ctx, cmd, store, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	compactor, err := compact.New(store, "", config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	issue, err := store.GetIssue(ctx, issueID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to get issue: %v\n", err)
+		os.Exit(1)
+	}
+	if issue == nil {
+		fmt.Fprintf(os.Stderr, "Error: issue %s not found\n", issueID)
+		os.Exit(1)
+	}
+
+	prompt, source, err := compactor.RenderPrompt(issue, compactTier)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to render prompt: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"issue_id":     issueID,
+			"tier":         compactTier,
+			"source":       source,
+			"model":        compactor.Model(),
+			"prompt":       prompt,
+			"input_tokens": compact.EstimateTokens(prompt),
+		})
+		return
+	}
+
+	fmt.Printf("Tier %d prompt for %s (%s) from %s\n", compactTier, issueID, issue.IssueType, source)
+	if compactor.Model() == "" {
+		fmt.Printf("Note: offline compaction extracts text and doesn't send this prompt\n")
+	} else {
+		fmt.Printf("Model: %s, about %d input tokens\n", compactor.Model(), compact.EstimateTokens(prompt))
+	}
+	fmt.Printf("%s\n%s\n", strings.Repeat("─", 60), prompt)
+}
+
+// compactVerifyResult is the --verify outcome for one compacted issue
+type compactVerifyResult struct {
+	IssueID  string   `json:"issue_id"`
+	Tier     int      `json:"tier"`
+	OK       bool     `json:"ok"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+func runCompactVerify(ctx context.Context, store *sqlite.SQLiteStorage) {
+	ids, err := store.GetCompactedIssueIDs(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	results := make([]*compactVerifyResult, 0, len(ids))
+	failed := 0
+	for _, id := range ids {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to get %s: %v\n", id, err)
+			os.Exit(1)
+		}
+		snapshots, err := store.GetSnapshots(ctx, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		result := &compactVerifyResult{IssueID: id, Tier: issue.CompactionLevel, OK: true}
+		if len(snapshots) == 0 {
+			result.Problems = []string{"no snapshot of the original to verify against"}
+		} else if err := compact.ValidateSummary(snapshots[0].Issue, issue.Description, issue.CompactionLevel); err != nil {
+			var invalid *compact.ValidationError
+			if !errors.As(err, &invalid) {
+				fmt.Fprintf(os.Stderr, "Error: failed to verify %s: %v\n", id, err)
+				os.Exit(1)
+			}
+			result.OK = false
+			result.Problems = invalid.Problems
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"verified": len(results),
+			"failed":   failed,
+			"issues":   results,
+		})
+	} else {
+		for _, r := range results {
+			if r.OK && len(r.Problems) == 0 {
+				continue
+			}
+			mark := color.New(color.FgRed).Sprint("✗")
+			if r.OK {
+				mark = color.New(color.FgYellow).Sprint("?")
+			}
+			fmt.Printf("%s %s (Tier %d)\n", mark, r.IssueID, r.Tier)
+			for _, problem := range r.Problems {
+				fmt.Printf("    %s\n", problem)
+			}
+		}
+		if failed == 0 {
+			fmt.Printf("%s Verified %d compacted issues\n", color.New(color.FgGreen).Sprint("✓"), len(results))
+		} else {
+			fmt.Printf("\n%d of %d compacted issues failed verification\n", failed, len(results))
+			fmt.Printf("Restore them with 'bd restore <id> --apply' and compact again\n")
+		}
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// usageEstimateJSON describes estimated usage and its cost for --json output
+func usageEstimateJSON(model string, usage compact.Usage) map[string]interface{} {
+	estimate := map[string]interface{}{
+		"model":         model,
+		"calls":         usage.Calls,
+		"input_tokens":  usage.InputTokens,
+		"output_tokens": usage.OutputTokens,
+	}
+	if cost, ok := compact.Cost(model, usage); ok {
+		estimate["cost_usd"] = cost
+	}
+	return estimate
+}
+
+func printUsageEstimate(model string, usage compact.Usage) {
+	if model == "" {
+		fmt.Printf("Estimated cost: none (offline, no API calls)\n")
+		return
+	}
+	fmt.Printf("Estimated tokens: %d input + %d output (%s)\n", usage.InputTokens, usage.OutputTokens, model)
+	if cost, ok := compact.Cost(model, usage); ok {
+		fmt.Printf("Estimated cost: %s\n", formatDollars(cost))
+	} else {
+		fmt.Printf("Estimated cost: unknown (no price for %s)\n", model)
+	}
+	fmt.Printf("Summaries that fail validation are retried, which can add up to two more calls each\n")
+}
+
+// estimatedReduction is the typical size reduction of a compaction tier
+func estimatedReduction(tier int) string {
+	if tier == 2 {
+		return "90-95%"
+	}
+	return "70-80%"
 }
 
 func progressBar(current, total int) string {
@@ -386,6 +808,12 @@ func init() {
 	compactCmd.Flags().IntVar(&compactBatch, "batch-size", 10, "Issues per batch")
 	compactCmd.Flags().IntVar(&compactWorkers, "workers", 5, "Parallel workers")
 	compactCmd.Flags().BoolVar(&compactStats, "stats", false, "Show compaction statistics")
+	compactCmd.Flags().BoolVar(&compactVerify, "verify", false, "Check compacted issues against their snapshots")
+	compactCmd.Flags().StringVar(&compactPreview, "preview", "", "Show the prompt --tier compaction would send for an issue")
+	compactCmd.Flags().BoolVar(&compactOffline, "offline", false, "Compact by extracting key content, without a model or network access")
+	compactCmd.Flags().String("provider", "", "Summarization provider: anthropic, openai or offline (default from compact_provider)")
+	compactCmd.Flags().String("model", "", "Model name (default from compact_model)")
+	compactCmd.Flags().String("base-url", "", "OpenAI-compatible server URL (default from compact_base_url, else "+compact.DefaultOpenAIBaseURL+")")
 
 	rootCmd.AddCommand(compactCmd)
 }
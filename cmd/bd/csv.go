@@ -0,0 +1,456 @@
+// This file implements CSV export and import for editing issues in a spreadsheet.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// defaultCSVColumns is the column set written when --columns isn't given. updated_at
+// lets import tell spreadsheet edits apart from concurrent changes.
+const defaultCSVColumns = "id,title,description,status,priority,issue_type,assignee,estimated_minutes,labels,dependencies,due_at,updated_at"
+
+// csvColumn maps a CSV column to an issue field
+type csvColumn struct {
+	get func(issue *types.Issue) string
+	// set parses a cell into the issue; nil for columns that are export-only
+	set func(issue *types.Issue, value string) error
+}
+
+// csvColumns lists every supported column by name
+var csvColumns = map[string]csvColumn{
+	"id": {
+		get: func(i *types.Issue) string { return i.ID },
+		set: func(i *types.Issue, v string) error { i.ID = v; return nil },
+	},
+	"title": {
+		get: func(i *types.Issue) string { return i.Title },
+		set: func(i *types.Issue, v string) error { i.Title = v; return nil },
+	},
+	"description": {
+		get: func(i *types.Issue) string { return i.Description },
+		set: func(i *types.Issue, v string) error { i.Description = v; return nil },
+	},
+	"design": {
+		get: func(i *types.Issue) string { return i.Design },
+		set: func(i *types.Issue, v string) error { i.Design = v; return nil },
+	},
+	"acceptance_criteria": {
+		get: func(i *types.Issue) string { return i.AcceptanceCriteria },
+		set: func(i *types.Issue, v string) error { i.AcceptanceCriteria = v; return nil },
+	},
+	"notes": {
+		get: func(i *types.Issue) string { return i.Notes },
+		set: func(i *types.Issue, v string) error { i.Notes = v; return nil },
+	},
+	"status": {
+		get: func(i *types.Issue) string { return string(i.Status) },
+		set: func(i *types.Issue, v string) error { i.Status = types.Status(v); return nil },
+	},
+	"priority": {
+		get: func(i *types.Issue) string { return strconv.Itoa(i.Priority) },
+		set: func(i *types.Issue, v string) error {
+			p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(v), "P"))
+			if err != nil {
+				return fmt.Errorf("invalid priority %q", v)
+			}
+			i.Priority = p
+			return nil
+		},
+	},
+	"issue_type": {
+		get: func(i *types.Issue) string { return string(i.IssueType) },
+		set: func(i *types.Issue, v string) error { i.IssueType = types.IssueType(v); return nil },
+	},
+	"assignee": {
+		get: func(i *types.Issue) string { return i.Assignee },
+		set: func(i *types.Issue, v string) error { i.Assignee = v; return nil },
+	},
+	"estimated_minutes": {
+		get: func(i *types.Issue) string {
+			if i.EstimatedMinutes == nil {
+				return ""
+			}
+			return strconv.Itoa(*i.EstimatedMinutes)
+		},
+		set: func(i *types.Issue, v string) error {
+			if v == "" {
+				i.EstimatedMinutes = nil
+				return nil
+			}
+			m, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("invalid estimated_minutes %q", v)
+			}
+			i.EstimatedMinutes = &m
+			return nil
+		},
+	},
+	"external_ref": {
+		get: func(i *types.Issue) string {
+			if i.ExternalRef == nil {
+				return ""
+			}
+			return *i.ExternalRef
+		},
+		set: func(i *types.Issue, v string) error {
+			if v == "" {
+				i.ExternalRef = nil
+			} else {
+				i.ExternalRef = &v
+			}
+			return nil
+		},
+	},
+	"labels": {
+		get: func(i *types.Issue) string { return strings.Join(i.Labels, ", ") },
+		set: func(i *types.Issue, v string) error {
+			// Non-nil so import syncs (and can clear) labels
+			i.Labels = append([]string{}, parseLabels(v)...)
+			return nil
+		},
+	},
+	"dependencies": {
+		get: func(i *types.Issue) string { return strings.Join(formatMarkdownDependencies(i.Dependencies), ", ") },
+		set: func(i *types.Issue, v string) error {
+			i.Dependencies = nil
+			for _, spec := range parseDependencies(v) {
+				depType := types.DepBlocks
+				dependsOnID := spec
+				if t, id, ok := strings.Cut(spec, ":"); ok {
+					depType = types.DependencyType(t)
+					dependsOnID = id
+				}
+				if !depType.IsValid() {
+					return fmt.Errorf("invalid dependency type %q", depType)
+				}
+				i.Dependencies = append(i.Dependencies, &types.Dependency{
+					IssueID:     i.ID,
+					DependsOnID: dependsOnID,
+					Type:        depType,
+				})
+			}
+			return nil
+		},
+	},
+	"due_at":      csvDateColumn(func(i *types.Issue) **time.Time { return &i.DueAt }, true),
+	"defer_until": csvDateColumn(func(i *types.Issue) **time.Time { return &i.DeferUntil }, false),
+	"recurrence": {
+		get: func(i *types.Issue) string { return i.Recurrence },
+		set: func(i *types.Issue, v string) error { i.Recurrence = v; return nil },
+	},
+	"created_at": {
+		get: func(i *types.Issue) string { return i.CreatedAt.UTC().Format(time.RFC3339) },
+	},
+	"updated_at": {
+		// Full precision, so import can tell whether the issue changed since export
+		get: func(i *types.Issue) string { return i.UpdatedAt.UTC().Format(time.RFC3339Nano) },
+		set: func(i *types.Issue, v string) error {
+			if v == "" {
+				i.UpdatedAt = time.Time{}
+				return nil
+			}
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return fmt.Errorf("invalid updated_at %q", v)
+			}
+			i.UpdatedAt = t
+			return nil
+		},
+	},
+	"closed_at": {
+		get: func(i *types.Issue) string {
+			if i.ClosedAt == nil {
+				return ""
+			}
+			return i.ClosedAt.UTC().Format(time.RFC3339)
+		},
+	},
+}
+
+// csvColumnAliases maps friendlier header names to column names
+var csvColumnAliases = map[string]string{
+	"type":       "issue_type",
+	"estimate":   "estimated_minutes",
+	"deps":       "dependencies",
+	"acceptance": "acceptance_criteria",
+	"due":        "due_at",
+	"defer":      "defer_until",
+	"recur":      "recurrence",
+}
+
+// csvDateColumn builds a column for an optional date. Import accepts anything
+// parseDateFlag does, so spreadsheet users can type 2025-11-01.
+func csvDateColumn(field func(i *types.Issue) **time.Time, endOfDay bool) csvColumn {
+	return csvColumn{
+		get: func(i *types.Issue) string {
+			if t := *field(i); t != nil {
+				return t.UTC().Format(time.RFC3339)
+			}
+			return ""
+		},
+		set: func(i *types.Issue, v string) error {
+			t, err := parseDateFlag(v, endOfDay)
+			if err != nil {
+				return err
+			}
+			*field(i) = t
+			return nil
+		},
+	}
+}
+
+// parseCSVColumns resolves a comma-separated column list
+func parseCSVColumns(spec string) ([]string, error) {
+	var columns []string
+	for _, name := range strings.Split(spec, ",") {
+		name = normalizeCSVColumn(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := csvColumns[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(csvColumnNames(), ", "))
+		}
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	return columns, nil
+}
+
+// normalizeCSVColumn maps a header to its column name
+func normalizeCSVColumn(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, " ", "_")
+	if alias, ok := csvColumnAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// csvColumnNames returns all column names, sorted
+func csvColumnNames() []string {
+	names := make([]string, 0, len(csvColumns))
+	for name := range csvColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeCSV writes issues as CSV with a header row
+func writeCSV(w io.Writer, issues []*types.Issue, columns []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	row := make([]string, len(columns))
+	for _, issue := range issues {
+		for i, name := range columns {
+			row[i] = csvColumns[name].get(issue)
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write %s: %w", issue.ID, err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportCSV writes issues to stdout or a file
+func exportCSV(issues []*types.Issue, output string, columns []string) error {
+	if output == "" {
+		return writeCSV(os.Stdout, issues, columns)
+	}
+	if err := validateExportPath(output); err != nil {
+		return err
+	}
+	return writeFileAtomic(output, func(w io.Writer) error {
+		return writeCSV(w, issues, columns)
+	})
+}
+
+// readCSVIssues parses CSV rows into issues for import. A row whose ID exists starts
+// from the stored issue, so columns left out of the file keep their values; other
+// rows start from the defaults of bd create. Every row is checked with Validate and
+// all problems are reported together.
+func readCSVIssues(ctx context.Context, r io.Reader) ([]*types.Issue, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("empty CSV input")
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make([]string, len(header))
+	hasColumn := make(map[string]bool, len(header))
+	for i, h := range header {
+		name := normalizeCSVColumn(strings.TrimPrefix(h, "\uFEFF")) // Spreadsheets may add a BOM
+		col, ok := csvColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", h, strings.Join(csvColumnNames(), ", "))
+		}
+		if col.set == nil {
+			name = "" // Export-only, ignored on import
+		}
+		columns[i] = name
+		hasColumn[name] = true
+	}
+	if !hasColumn["title"] && !hasColumn["id"] {
+		return nil, fmt.Errorf("CSV needs an id or title column")
+	}
+
+	var issues []*types.Issue
+	var problems []string
+	line := 1
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		issue, err := csvRowIssue(ctx, columns, hasColumn, record)
+		if err == nil {
+			err = issue.Validate()
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		issues = append(issues, issue)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid CSV rows:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return issues, nil
+}
+
+// csvRowIssue builds the issue for one CSV row
+func csvRowIssue(ctx context.Context, columns []string, hasColumn map[string]bool, record []string) (*types.Issue, error) {
+	issue := &types.Issue{
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+	}
+
+	// Look up the stored issue first, so the file overrides only the columns it has
+	for i, name := range columns {
+		if name == "id" && i < len(record) && strings.TrimSpace(record[i]) != "" {
+			existing, err := store.GetIssue(ctx, strings.TrimSpace(record[i]))
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				copied := *existing
+				issue = &copied
+				// Only a matching updated_at column marks a row as an edit
+				issue.UpdatedAt = time.Time{}
+			}
+		}
+	}
+
+	// The id is set first so dependencies record their issue
+	order := make([]int, 0, len(columns))
+	for i, name := range columns {
+		if name == "id" {
+			order = append([]int{i}, order...)
+		} else if name != "" {
+			order = append(order, i)
+		}
+	}
+	for _, i := range order {
+		value := ""
+		if i < len(record) {
+			value = strings.TrimSpace(record[i])
+		}
+		if err := csvColumns[columns[i]].set(issue, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", columns[i], err)
+		}
+	}
+
+	// Keep closed_at consistent with the (possibly edited) status
+	if issue.Status == types.StatusClosed {
+		if issue.ClosedAt == nil {
+			now := time.Now()
+			issue.ClosedAt = &now
+		}
+	} else {
+		issue.ClosedAt = nil
+	}
+	return issue, nil
+}
+
+// splitCSVEdits separates collisions that are spreadsheet edits from real conflicts.
+// A row is an edit when its updated_at matches the stored issue: nobody changed the
+// issue since it was exported, so the differences are the editor's.
+func splitCSVEdits(collisions []*sqlite.CollisionDetail) (edits, conflicts []*sqlite.CollisionDetail) {
+	for _, c := range collisions {
+		incoming := c.IncomingIssue.UpdatedAt
+		if !incoming.IsZero() && !c.ExistingIssue.UpdatedAt.After(incoming) {
+			edits = append(edits, c)
+		} else {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return edits, conflicts
+}
+
+// printCSVDiff prints the changes CSV rows would make to existing issues: field
+// edits, and label changes (labels never count as collisions)
+func printCSVDiff(ctx context.Context, edits []*sqlite.CollisionDetail, issues []*types.Issue) {
+	editByID := make(map[string]*sqlite.CollisionDetail, len(edits))
+	for _, edit := range edits {
+		editByID[edit.ID] = edit
+	}
+
+	var lines []string
+	for _, issue := range issues {
+		var changes []string
+		if edit := editByID[issue.ID]; edit != nil {
+			for _, field := range edit.ConflictingFields {
+				if col, ok := csvColumns[field]; ok {
+					changes = append(changes, fmt.Sprintf("    %s: %q → %q", field, col.get(edit.ExistingIssue), col.get(edit.IncomingIssue)))
+				}
+			}
+		}
+		if issue.Labels != nil && issue.ID != "" {
+			current, err := store.GetLabels(ctx, issue.ID)
+			if err == nil {
+				sorted := append([]string{}, issue.Labels...)
+				sort.Strings(sorted)
+				if strings.Join(current, ", ") != strings.Join(sorted, ", ") {
+					changes = append(changes, fmt.Sprintf("    labels: %q → %q", strings.Join(current, ", "), strings.Join(sorted, ", ")))
+				}
+			}
+		}
+		if len(changes) > 0 {
+			lines = append(lines, fmt.Sprintf("  %s: %s", issue.ID, issue.Title))
+			lines = append(lines, changes...)
+		}
+	}
+
+	if len(lines) == 0 {
+		fmt.Fprintf(os.Stderr, "\nNo edits to existing issues.\n")
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\nEdits to existing issues:\n%s\n", strings.Join(lines, "\n"))
+}
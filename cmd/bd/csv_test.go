@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	testStore, err := sqlite.New(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer testStore.Close()
+
+	oldStore := store
+	store = testStore
+	defer func() { store = oldStore }()
+
+	ctx := context.Background()
+	estimate := 30
+	issues := []*types.Issue{
+		{ID: "test-1", Title: "First, with comma", Description: "Line one\nLine two", Status: types.StatusOpen,
+			Priority: 1, IssueType: types.TypeBug, EstimatedMinutes: &estimate},
+		{ID: "test-2", Title: "Second", Description: "Keep me", Status: types.StatusOpen, Priority: 2,
+			IssueType: types.TypeTask},
+	}
+	for _, issue := range issues {
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := testStore.AddLabel(ctx, "test-1", "backend", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	dep := &types.Dependency{IssueID: "test-2", DependsOnID: "test-1", Type: types.DepBlocks}
+	if err := testStore.AddDependency(ctx, dep, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	// Export the way bd export does
+	for _, issue := range issues {
+		issue.Labels, _ = testStore.GetLabels(ctx, issue.ID)
+		issue.Dependencies, _ = testStore.GetDependencyRecords(ctx, issue.ID)
+	}
+	columns, err := parseCSVColumns(defaultCSVColumns)
+	if err != nil {
+		t.Fatalf("parseCSVColumns failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, issues, columns); err != nil {
+		t.Fatalf("writeCSV failed: %v", err)
+	}
+
+	// Unedited rows read back as exact matches
+	parsed, err := readCSVIssues(ctx, strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("readCSVIssues failed: %v", err)
+	}
+	if len(parsed) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(parsed))
+	}
+	if parsed[0].Title != "First, with comma" || parsed[0].Description != "Line one\nLine two" {
+		t.Errorf("Text not preserved: %+v", parsed[0])
+	}
+	if len(parsed[0].Labels) != 1 || parsed[0].Labels[0] != "backend" {
+		t.Errorf("Labels not preserved: %v", parsed[0].Labels)
+	}
+	if len(parsed[1].Dependencies) != 1 || parsed[1].Dependencies[0].DependsOnID != "test-1" ||
+		parsed[1].Dependencies[0].IssueID != "test-2" {
+		t.Errorf("Dependencies not preserved: %+v", parsed[1].Dependencies)
+	}
+	result, err := sqlite.DetectCollisions(ctx, testStore, parsed)
+	if err != nil {
+		t.Fatalf("DetectCollisions failed: %v", err)
+	}
+	if len(result.ExactMatches) != 2 || len(result.Collisions) != 0 {
+		t.Errorf("Expected 2 exact matches, got %+v", result)
+	}
+
+	// An edited row is an edit, not a collision, while updated_at still matches
+	edited := strings.Replace(buf.String(), "Second,Keep me,open,2", "Second,Keep me,in_progress,0", 1)
+	parsed, err = readCSVIssues(ctx, strings.NewReader(edited))
+	if err != nil {
+		t.Fatalf("readCSVIssues failed: %v", err)
+	}
+	result, err = sqlite.DetectCollisions(ctx, testStore, parsed)
+	if err != nil {
+		t.Fatalf("DetectCollisions failed: %v", err)
+	}
+	edits, conflicts := splitCSVEdits(result.Collisions)
+	if len(edits) != 1 || len(conflicts) != 0 {
+		t.Fatalf("Expected 1 edit and no conflicts, got %d and %d", len(edits), len(conflicts))
+	}
+	if got := strings.Join(edits[0].ConflictingFields, ","); got != "status,priority" {
+		t.Errorf("Expected status and priority to change, got %s", got)
+	}
+
+	// Once the issue changes in the database, the same row is a collision
+	if err := testStore.UpdateIssue(ctx, "test-2", map[string]interface{}{"assignee": "bob"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	result, err = sqlite.DetectCollisions(ctx, testStore, parsed)
+	if err != nil {
+		t.Fatalf("DetectCollisions failed: %v", err)
+	}
+	edits, conflicts = splitCSVEdits(result.Collisions)
+	if len(edits) != 0 || len(conflicts) != 1 {
+		t.Errorf("Expected a conflict after a concurrent change, got %d edits and %d conflicts", len(edits), len(conflicts))
+	}
+}
+
+func TestReadCSVIssuesValidation(t *testing.T) {
+	tmpDir := t.TempDir()
+	testStore, err := sqlite.New(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer testStore.Close()
+
+	oldStore := store
+	store = testStore
+	defer func() { store = oldStore }()
+
+	input := "Title,Priority,Type,Labels\n" +
+		"Good row,P1,bug,\"ui, urgent\"\n" +
+		",2,task,\n" +
+		"Bad priority,9,task,\n" +
+		"Bad type,2,story,\n"
+	_, err = readCSVIssues(context.Background(), strings.NewReader(input))
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+	for _, want := range []string{"line 3: title is required", "line 4: priority", "line 5: invalid issue type"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in error, got: %v", want, err)
+		}
+	}
+
+	issues, err := readCSVIssues(context.Background(), strings.NewReader("Title,Priority,Type,Labels\nGood row,P1,bug,\"ui, urgent\"\n"))
+	if err != nil {
+		t.Fatalf("readCSVIssues failed: %v", err)
+	}
+	if issues[0].Priority != 1 || issues[0].IssueType != types.TypeBug || len(issues[0].Labels) != 2 {
+		t.Errorf("Unexpected row: %+v", issues[0])
+	}
+
+	if _, err := readCSVIssues(context.Background(), strings.NewReader("title,color\nx,red\n")); err == nil {
+		t.Error("Expected error for unknown column")
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 )
 
 var daemonCmd = &cobra.Command{
@@ -29,6 +30,14 @@ The daemon will:
 - Auto-push commits if --auto-push flag set
 - Pull remote changes periodically
 - Auto-import when remote changes detected
+- Compact old closed issues if --auto-compact was set (see bd compact)
+
+Auto-compaction runs Tier 1 then Tier 2 on eligible issues at most once per
+--compact-interval, up to compact_batch_size issues per run and
+--compact-daily-limit attempts per day, using the provider configured for bd
+compact. Issues that failed to compact are retried after a week, and issues
+restored with bd restore are left alone. Compacted issues go out with the next
+auto-commit.
 
 Use --stop to stop a running daemon.
 Use --status to check if daemon is running.`,
@@ -82,6 +91,12 @@ Use --status to check if daemon is running.`,
 			os.Exit(1)
 		}
 
+		// Compaction settings live in config so the daemon picks them up on every run
+		if err := saveAutoCompactFlags(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 		// Start daemon
 		fmt.Printf("Starting bd daemon (interval: %v, auto-commit: %v, auto-push: %v)\n",
 			interval, autoCommit, autoPush)
@@ -100,9 +115,42 @@ func init() {
 	daemonCmd.Flags().Bool("stop", false, "Stop running daemon")
 	daemonCmd.Flags().Bool("status", false, "Show daemon status")
 	daemonCmd.Flags().String("log", "", "Log file path (default: .beads/daemon.log)")
+	daemonCmd.Flags().Bool("auto-compact", false, "Compact old closed issues in the background (remembered; --auto-compact=false to disable)")
+	daemonCmd.Flags().String("compact-interval", "", "Minimum time between compaction runs, e.g. 12h or 7d (remembered, default 24h)")
+	daemonCmd.Flags().Int("compact-daily-limit", 0, "Most compaction attempts per 24 hours, 0 for no limit (remembered, default 200)")
 	rootCmd.AddCommand(daemonCmd)
 }
 
+// saveAutoCompactFlags stores the compaction flags that were given in config
+func saveAutoCompactFlags(cmd *cobra.Command) error {
+	ctx := context.Background()
+	if cmd.Flags().Changed("auto-compact") {
+		enabled, _ := cmd.Flags().GetBool("auto-compact")
+		if err := store.SetConfig(ctx, "auto_compact_enabled", strconv.FormatBool(enabled)); err != nil {
+			return fmt.Errorf("failed to save auto_compact_enabled: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("compact-interval") {
+		interval, _ := cmd.Flags().GetString("compact-interval")
+		if d, err := parseRelativeDuration(interval); err != nil || d <= 0 {
+			return fmt.Errorf("invalid --compact-interval %q: use a duration like 12h or 7d", interval)
+		}
+		if err := store.SetConfig(ctx, "auto_compact_interval", interval); err != nil {
+			return fmt.Errorf("failed to save auto_compact_interval: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("compact-daily-limit") {
+		limit, _ := cmd.Flags().GetInt("compact-daily-limit")
+		if limit < 0 {
+			return fmt.Errorf("--compact-daily-limit must not be negative (got %d)", limit)
+		}
+		if err := store.SetConfig(ctx, "auto_compact_daily_limit", strconv.Itoa(limit)); err != nil {
+			return fmt.Errorf("failed to save auto_compact_daily_limit: %w", err)
+		}
+	}
+	return nil
+}
+
 func ensureBeadsDir() (string, error) {
 	var beadsDir string
 	if dbPath != "" {
@@ -349,9 +397,6 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 	defer ticker.Stop()
 
 	doSync := func() {
-		syncCtx, syncCancel := context.WithTimeout(ctx, 2*time.Minute)
-		defer syncCancel()
-		
 		log("Starting sync cycle...")
 		
 		jsonlPath := findJSONLPath()
@@ -360,6 +405,27 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 			return
 		}
 
+		// Compact old closed issues when auto_compact_enabled is set. Summarizer calls
+		// can be slow, so compaction gets its own timeout and runs before the sync's starts.
+		compacted := 0
+		if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+			compactCtx, compactCancel := context.WithTimeout(ctx, 30*time.Minute)
+			compacted = runAutoCompaction(compactCtx, sqliteStore, time.Now(), log)
+			compactCancel()
+		}
+
+		syncCtx, syncCancel := context.WithTimeout(ctx, 2*time.Minute)
+		defer syncCancel()
+
+		// Spawn recurring issues that came due (or were closed) before exporting
+		spawned, err := store.SpawnDueOccurrences(syncCtx, time.Now(), "daemon")
+		if err != nil {
+			log("Spawning recurring issues failed: %v", err)
+		}
+		if len(spawned) > 0 {
+			log("Spawned %d recurring issues", len(spawned))
+		}
+
 		if err := exportToJSONL(syncCtx, jsonlPath); err != nil {
 			log("Export failed: %v", err)
 			return
@@ -375,6 +441,9 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 
 			if hasChanges {
 				message := fmt.Sprintf("bd daemon sync: %s", time.Now().Format("2006-01-02 15:04:05"))
+				if compacted > 0 {
+					message += fmt.Sprintf(" (compacted %d issues)", compacted)
+				}
 				if err := gitCommit(syncCtx, jsonlPath, message); err != nil {
 					log("Commit failed: %v", err)
 					return
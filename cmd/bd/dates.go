@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// parseDateFlag parses a date given on the command line. Accepted forms:
+//
+//	2025-11-01, 2025-11-01 17:00, 2025-11-01T17:00:00Z (RFC3339)
+//	today, tomorrow
+//	3d, +2w, 12h (relative to now)
+//
+// A bare date means the end of that day when endOfDay is set (due dates: "due on the
+// 1st" is not overdue until the 1st is over) and the start of the day otherwise
+// (defer dates: "defer until the 1st" surfaces the issue on the morning of the 1st).
+// An empty string returns nil, which clears the date.
+func parseDateFlag(s string, endOfDay bool) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	now := time.Now()
+	dayBoundary := func(t time.Time) *time.Time {
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+		if endOfDay {
+			day = day.Add(24*time.Hour - time.Second)
+		}
+		return &day
+	}
+
+	switch strings.ToLower(s) {
+	case "today":
+		return dayBoundary(now), nil
+	case "tomorrow":
+		return dayBoundary(now.AddDate(0, 0, 1)), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return dayBoundary(t), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	if d, err := parseRelativeDuration(strings.TrimPrefix(s, "+")); err == nil {
+		t := now.Add(d)
+		return &t, nil
+	}
+
+	return nil, fmt.Errorf("invalid date %q (examples: 2025-11-01, \"2025-11-01 17:00\", tomorrow, 3d)", s)
+}
+
+// formatDate renders a due/defer date in local time, omitting the time of day when it
+// is a day boundary set by parseDateFlag
+func formatDate(t time.Time) string {
+	t = t.Local()
+	if (t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0) || (t.Hour() == 23 && t.Minute() == 59 && t.Second() == 59) {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04")
+}
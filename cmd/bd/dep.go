@@ -120,6 +120,14 @@ var depTreeCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if formatStr, _ := cmd.Flags().GetString("format"); formatStr != "" {
+			if err := outputTreeGraph(ctx, tree, formatStr); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		if jsonOutput {
 			// Always output array, even if empty
 			if tree == nil {
@@ -159,6 +167,30 @@ var depTreeCmd = &cobra.Command{
 	},
 }
 
+// outputTreeGraph draws a dependency tree as a graph, in the same formats as 'bd list --format'
+func outputTreeGraph(ctx context.Context, tree []*types.TreeNode, formatStr string) error {
+	// A node reachable along several paths appears once per path
+	seen := make(map[string]bool)
+	var issues []*types.Issue
+	for _, node := range tree {
+		if !seen[node.ID] {
+			seen[node.ID] = true
+			issue := node.Issue
+			issues = append(issues, &issue)
+		}
+	}
+
+	switch formatStr {
+	case "dot":
+		return outputDotFormat(ctx, store, issues)
+	case "mermaid":
+		return outputMermaidFormat(ctx, store, issues)
+	case "plantuml":
+		return outputPlantUMLFormat(ctx, store, issues)
+	}
+	return fmt.Errorf("unsupported format '%s' (use 'dot', 'mermaid' or 'plantuml')", formatStr)
+}
+
 var depCyclesCmd = &cobra.Command{
 	Use:   "cycles",
 	Short: "Detect dependency cycles",
@@ -199,6 +231,7 @@ var depCyclesCmd = &cobra.Command{
 
 func init() {
 	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|related|parent-child|discovered-from)")
+	depTreeCmd.Flags().String("format", "", "Output format: 'dot' (Graphviz), 'mermaid' or 'plantuml'")
 	depCmd.AddCommand(depAddCmd)
 	depCmd.AddCommand(depRemoveCmd)
 	depCmd.AddCommand(depTreeCmd)
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var epicCmd = &cobra.Command{
+	Use:   "epic",
+	Short: "Track progress of epics",
+}
+
+var epicStatusCmd = &cobra.Command{
+	Use:   "status [epic-id]",
+	Short: "Show progress rollup for an epic (or all open epics)",
+	Long: `Show how far along an epic is.
+
+Walks all parent-child descendants of the epic and reports counts by status,
+percent complete by issue count and by estimated minutes, which unclosed
+children are ready or blocked, and the newest activity.
+
+Without an ID, shows a rollup for every epic that is not closed.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+
+		var epics []*types.Issue
+		if len(args) == 1 {
+			epic, err := store.GetIssue(ctx, args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if epic == nil {
+				fmt.Fprintf(os.Stderr, "Issue %s not found\n", args[0])
+				os.Exit(1)
+			}
+			epics = append(epics, epic)
+		} else {
+			epicType := types.TypeEpic
+			all, err := store.SearchIssues(ctx, "", types.IssueFilter{IssueType: &epicType})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			for _, epic := range all {
+				if epic.Status != types.StatusClosed {
+					epics = append(epics, epic)
+				}
+			}
+		}
+
+		statuses := make([]*types.EpicStatus, 0, len(epics))
+		for _, epic := range epics {
+			status, err := store.GetEpicStatus(ctx, epic.ID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			statuses = append(statuses, status)
+		}
+
+		if jsonOutput {
+			if len(args) == 1 {
+				outputJSON(statuses[0])
+			} else {
+				outputJSON(statuses)
+			}
+			return
+		}
+
+		if len(epics) == 0 {
+			fmt.Println("No open epics")
+			return
+		}
+		for i, epic := range epics {
+			cyan := color.New(color.FgCyan).SprintFunc()
+			fmt.Printf("\n%s: %s (%s)\n", cyan(epic.ID), epic.Title, epic.Status)
+			printEpicStatus(statuses[i])
+		}
+		fmt.Println()
+	},
+}
+
+// printEpicStatus prints the progress rollup of an epic (shared with bd show)
+func printEpicStatus(status *types.EpicStatus) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	if status.TotalChildren == 0 {
+		fmt.Printf("  No child issues (add them with: bd dep add <child> %s --type parent-child)\n", status.EpicID)
+		return
+	}
+
+	closed := status.StatusCounts[types.StatusClosed]
+	fmt.Printf("  Progress:    %s %.0f%% (%d/%d closed)\n",
+		progressBar(closed, status.TotalChildren), status.PercentComplete, closed, status.TotalChildren)
+	if status.EstimatedMinutes > 0 {
+		line := fmt.Sprintf("  By estimate: %s %.0f%% (%s/%s)",
+			progressBar(status.ClosedEstimatedMinutes, status.EstimatedMinutes), status.PercentCompleteByEstimate,
+			formatMinutes(status.ClosedEstimatedMinutes), formatMinutes(status.EstimatedMinutes))
+		if status.UnestimatedChildren > 0 {
+			line += fmt.Sprintf(", %d unestimated", status.UnestimatedChildren)
+		}
+		fmt.Println(line)
+	}
+
+	var counts []string
+	for _, s := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusClosed} {
+		if n := status.StatusCounts[s]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, s))
+		}
+	}
+	fmt.Printf("  Status:      %s\n", strings.Join(counts, ", "))
+
+	if len(status.Ready) > 0 {
+		fmt.Printf("  %s       %s\n", green("Ready:"), strings.Join(status.Ready, ", "))
+	}
+	if len(status.Blocked) > 0 {
+		fmt.Printf("  %s     %s\n", yellow("Blocked:"), strings.Join(status.Blocked, ", "))
+	}
+	if e := status.LastActivity; e != nil {
+		fmt.Printf("  Last activity: %s %s on %s by %s\n",
+			e.CreatedAt.Format("2006-01-02 15:04"), e.EventType, e.IssueID, e.Actor)
+	}
+}
+
+func init() {
+	epicCmd.AddCommand(epicStatusCmd)
+	rootCmd.AddCommand(epicCmd)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -45,20 +46,62 @@ func validateExportPath(path string) error {
 	return nil
 }
 
+// writeFileAtomic writes a file through a temporary file in the same directory and
+// renames it into place, so readers never see a partial export
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp.*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath) // No-op after a successful rename
+
+	if err := write(tempFile); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	// Set appropriate file permissions (0644: rw-r--r--)
+	if err := os.Chmod(path, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to set file permissions: %v\n", err)
+	}
+	return nil
+}
+
 var exportCmd = &cobra.Command{
 	Use:   "export",
-	Short: "Export issues to JSONL format",
+	Short: "Export issues to JSONL or markdown format",
 	Long: `Export all issues to JSON Lines format (one JSON object per line).
 Issues are sorted by ID for consistent diffs.
 
-Output to stdout by default, or use -o flag for file output.`,
+Output to stdout by default, or use -o flag for file output.
+
+With --format markdown, issues are written in the structure read by
+'bd create -f', including IDs, status and dependencies, so the file can be
+edited and re-imported as updates. If -o names a directory (an existing one,
+or a path ending in /), each issue is written to its own <id>.md file.
+
+With --format csv, --columns picks the columns (labels and dependencies are
+comma-separated lists in one cell). Edit the file in a spreadsheet and bring
+it back with 'bd import --format csv'.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		format, _ := cmd.Flags().GetString("format")
 		output, _ := cmd.Flags().GetString("output")
 		statusFilter, _ := cmd.Flags().GetString("status")
 
-		if format != "jsonl" {
-			fmt.Fprintf(os.Stderr, "Error: only 'jsonl' format is currently supported\n")
+		if format != "jsonl" && format != "markdown" && format != "csv" {
+			fmt.Fprintf(os.Stderr, "Error: unsupported format '%s' (use 'jsonl', 'markdown' or 'csv')\n", format)
+			os.Exit(1)
+		}
+		columnSpec, _ := cmd.Flags().GetString("columns")
+		columns, err := parseCSVColumns(columnSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --columns: %v\n", err)
 			os.Exit(1)
 		}
 
@@ -102,6 +145,31 @@ Output to stdout by default, or use -o flag for file output.`,
 			issue.Labels = labels
 		}
 
+		// Populate work logs for all issues
+		allWorkLogs, err := store.GetAllWorkLogs(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting work logs: %v\n", err)
+			os.Exit(1)
+		}
+		for _, issue := range issues {
+			issue.WorkLogs = allWorkLogs[issue.ID]
+		}
+
+		// Markdown and CSV are for editing, not sync: leave dirty tracking alone
+		if format == "markdown" || format == "csv" {
+			var err error
+			if format == "markdown" {
+				err = exportMarkdown(issues, output)
+			} else {
+				err = exportCSV(issues, output, columns)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		// Open output
 		out := os.Stdout
 		var tempFile *os.File
@@ -185,7 +253,8 @@ Output to stdout by default, or use -o flag for file output.`,
 }
 
 func init() {
-	exportCmd.Flags().StringP("format", "f", "jsonl", "Export format (jsonl)")
+	exportCmd.Flags().StringP("format", "f", "jsonl", "Export format (jsonl, markdown, csv)")
+	exportCmd.Flags().String("columns", defaultCSVColumns, "CSV columns to export")
 	exportCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
 	exportCmd.Flags().StringP("status", "s", "", "Filter by status")
 	rootCmd.AddCommand(exportCmd)
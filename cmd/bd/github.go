@@ -0,0 +1,760 @@
+// This file implements 'bd github', a two-way bridge between bd issues and the
+// issues of a GitHub repository.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/github"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const (
+	githubRefPrefix     = "gh-"
+	githubRepoConfigKey = "github.repo"
+	githubLastPullKey   = "github.last_pull"
+	githubSyncKeyPrefix = "github.sync."
+)
+
+// githubSyncState records both sides' updated_at as of the last sync of a linked
+// issue, so a later pull or push can tell which side changed since
+type githubSyncState struct {
+	GitHubUpdatedAt time.Time `json:"github_updated_at"`
+	BDUpdatedAt     time.Time `json:"bd_updated_at"`
+}
+
+// githubSyncOptions controls a pull or push
+type githubSyncOptions struct {
+	Force  bool // Overwrite the other side on conflict
+	DryRun bool
+	Full   bool // Pull: ignore the last pull time and list every issue
+	Create bool // Push: open GitHub issues for unlinked bd issues
+}
+
+// githubConflict is an issue changed on both sides since the last sync
+type githubConflict struct {
+	ID          string `json:"id"`
+	ExternalRef string `json:"external_ref"`
+}
+
+// githubSyncResult summarizes a pull or push
+type githubSyncResult struct {
+	Repo         string           `json:"repo"`
+	Created      []string         `json:"created"`
+	Updated      []string         `json:"updated"`
+	Unchanged    int              `json:"unchanged"`
+	Conflicts    []githubConflict `json:"conflicts"`
+	Dependencies int              `json:"dependencies_added"`
+}
+
+func newGitHubSyncResult(repo string) *githubSyncResult {
+	return &githubSyncResult{Repo: repo, Created: []string{}, Updated: []string{}, Conflicts: []githubConflict{}}
+}
+
+// githubRef returns the external_ref linking an issue to a GitHub issue number
+func githubRef(number int) string {
+	return githubRefPrefix + strconv.Itoa(number)
+}
+
+// parseGitHubRef returns the GitHub issue number an external_ref links to
+func parseGitHubRef(ref *string) (int, bool) {
+	if ref == nil || !strings.HasPrefix(*ref, githubRefPrefix) {
+		return 0, false
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(*ref, githubRefPrefix))
+	if err != nil || number <= 0 {
+		return 0, false
+	}
+	return number, true
+}
+
+// loadGitHubSyncState returns the sync state of an issue, or nil if it was never synced
+func loadGitHubSyncState(ctx context.Context, issueID string) (*githubSyncState, error) {
+	value, err := store.GetMetadata(ctx, githubSyncKeyPrefix+issueID)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	var state githubSyncState
+	if err := json.Unmarshal([]byte(value), &state); err != nil {
+		return nil, fmt.Errorf("invalid sync state for %s: %w", issueID, err)
+	}
+	return &state, nil
+}
+
+// saveGitHubSyncState records that issueID and its GitHub issue are in sync as of
+// the bd issue's current updated_at and ghUpdatedAt
+func saveGitHubSyncState(ctx context.Context, issueID string, ghUpdatedAt time.Time) error {
+	issue, err := store.GetIssue(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	data, err := json.Marshal(githubSyncState{GitHubUpdatedAt: ghUpdatedAt, BDUpdatedAt: issue.UpdatedAt})
+	if err != nil {
+		return err
+	}
+	return store.SetMetadata(ctx, githubSyncKeyPrefix+issueID, string(data))
+}
+
+// githubLinkedIssues indexes bd issues by the GitHub issue number they link to
+func githubLinkedIssues(ctx context.Context) (map[int]*types.Issue, error) {
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, err
+	}
+	linked := make(map[int]*types.Issue)
+	for _, issue := range issues {
+		if number, ok := parseGitHubRef(issue.ExternalRef); ok {
+			linked[number] = issue
+		}
+	}
+	return linked, nil
+}
+
+// githubToIssue converts a GitHub issue not yet known to bd
+func githubToIssue(gh *github.Issue) *types.Issue {
+	ref := githubRef(gh.Number)
+	issue := &types.Issue{
+		Title:       gh.Title,
+		Description: gh.Body,
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeTask,
+		Assignee:    gh.AssigneeLogin(),
+		ExternalRef: &ref,
+	}
+	if gh.State == "closed" {
+		issue.Status = types.StatusClosed
+		closedAt := time.Now()
+		if gh.ClosedAt != nil {
+			closedAt = *gh.ClosedAt
+		}
+		issue.ClosedAt = &closedAt
+	}
+	return issue
+}
+
+// githubPullUpdates returns the bd updates that bring issue in line with gh. A
+// closed GitHub issue closes the bd issue and an open one reopens it, but open
+// GitHub issues leave bd's finer-grained statuses (in_progress, blocked) alone.
+func githubPullUpdates(issue *types.Issue, gh *github.Issue) map[string]interface{} {
+	updates := make(map[string]interface{})
+	if issue.Title != gh.Title {
+		updates["title"] = gh.Title
+	}
+	if issue.Description != gh.Body {
+		updates["description"] = gh.Body
+	}
+	if issue.Assignee != gh.AssigneeLogin() {
+		updates["assignee"] = gh.AssigneeLogin()
+	}
+	switch {
+	case gh.State == "closed" && issue.Status != types.StatusClosed:
+		updates["status"] = string(types.StatusClosed)
+	case gh.State == "open" && issue.Status == types.StatusClosed:
+		updates["status"] = string(types.StatusOpen)
+	}
+	return updates
+}
+
+// githubIssueRequest returns the GitHub edit that brings gh in line with issue, and
+// whether anything differs. A nil gh requests every field, for creating an issue.
+func githubIssueRequest(issue *types.Issue, labels []string, gh *github.Issue) (*github.IssueRequest, bool) {
+	req := &github.IssueRequest{}
+	changed := false
+
+	state := "open"
+	if issue.Status == types.StatusClosed {
+		state = "closed"
+	}
+	if gh == nil || gh.Title != issue.Title {
+		req.Title = &issue.Title
+		changed = true
+	}
+	if gh == nil || gh.Body != issue.Description {
+		req.Body = &issue.Description
+		changed = true
+	}
+	if gh == nil || gh.State != state {
+		req.State = &state
+		changed = true
+	}
+	if gh == nil || !sameLabels(gh.LabelNames(), labels) {
+		labels := append([]string{}, labels...)
+		req.Labels = &labels
+		changed = true
+	}
+	if gh == nil || gh.AssigneeLogin() != issue.Assignee {
+		assignees := []string{}
+		if issue.Assignee != "" {
+			assignees = append(assignees, issue.Assignee)
+		}
+		req.Assignees = &assignees
+		changed = true
+	}
+	return req, changed
+}
+
+// sameLabels reports whether two label lists hold the same set
+func sameLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ensureDependency adds a dependency unless it already exists. Returns whether it
+// was added; failures (such as cycles) are warnings so one bad link doesn't stop a sync.
+func ensureDependency(ctx context.Context, issueID, dependsOnID string, depType types.DependencyType) bool {
+	existing, err := store.GetDependencyRecords(ctx, issueID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to get dependencies of %s: %v\n", issueID, err)
+		return false
+	}
+	for _, dep := range existing {
+		if dep.DependsOnID == dependsOnID {
+			return false
+		}
+	}
+	dep := &types.Dependency{IssueID: issueID, DependsOnID: dependsOnID, Type: depType}
+	if err := store.AddDependency(ctx, dep, actor); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to add dependency %s -> %s: %v\n", issueID, dependsOnID, err)
+		return false
+	}
+	return true
+}
+
+// githubPull imports GitHub issues updated since the last pull. New GitHub issues
+// become bd issues with external_ref gh-<number>; linked issues take GitHub's
+// title, body, state, labels and assignee unless the bd issue also changed since
+// the last sync, which is reported as a conflict. Sub-issues become parent-child
+// dependencies and "blocked by" links become blocks dependencies.
+func githubPull(ctx context.Context, client *github.Client, opts githubSyncOptions) (*githubSyncResult, error) {
+	result := newGitHubSyncResult(client.Repo())
+
+	var since time.Time
+	if !opts.Full {
+		value, err := store.GetMetadata(ctx, githubLastPullKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read last pull time: %w", err)
+		}
+		if value != "" {
+			since, _ = time.Parse(time.RFC3339Nano, value)
+		}
+	}
+
+	ghIssues, err := client.ListIssues(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GitHub issues: %w", err)
+	}
+	linked, err := githubLinkedIssues(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+
+	// synced holds the GitHub issues whose bd issue now matches them
+	synced := make(map[int]*github.Issue)
+	latest := since
+	var resumeAt time.Time
+	for _, gh := range ghIssues {
+		if gh.UpdatedAt.After(latest) {
+			latest = gh.UpdatedAt
+		}
+
+		issue := linked[gh.Number]
+		if issue == nil {
+			issue = githubToIssue(gh)
+			if opts.DryRun {
+				result.Created = append(result.Created, githubRef(gh.Number))
+				continue
+			}
+			if err := store.CreateIssue(ctx, issue, actor); err != nil {
+				return nil, fmt.Errorf("failed to create issue for %s: %w", githubRef(gh.Number), err)
+			}
+			for _, label := range gh.LabelNames() {
+				if err := store.AddLabel(ctx, issue.ID, label, actor); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to add label %s to %s: %v\n", label, issue.ID, err)
+				}
+			}
+			linked[gh.Number] = issue
+			synced[gh.Number] = gh
+			result.Created = append(result.Created, issue.ID)
+			continue
+		}
+
+		state, err := loadGitHubSyncState(ctx, issue.ID)
+		if err != nil {
+			return nil, err
+		}
+		ghChanged := state == nil || gh.UpdatedAt.After(state.GitHubUpdatedAt)
+		bdChanged := state != nil && issue.UpdatedAt.After(state.BDUpdatedAt)
+		if !ghChanged {
+			result.Unchanged++
+			continue
+		}
+		if bdChanged && !opts.Force {
+			result.Conflicts = append(result.Conflicts, githubConflict{ID: issue.ID, ExternalRef: githubRef(gh.Number)})
+			// Pull from here again next time so the conflict isn't forgotten
+			if resumeAt.IsZero() || gh.UpdatedAt.Before(resumeAt) {
+				resumeAt = gh.UpdatedAt
+			}
+			continue
+		}
+
+		updates := githubPullUpdates(issue, gh)
+		labels, err := store.GetLabels(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for %s: %w", issue.ID, err)
+		}
+		labelsChanged := !sameLabels(labels, gh.LabelNames())
+		if len(updates) == 0 && !labelsChanged {
+			result.Unchanged++
+			if !opts.DryRun {
+				synced[gh.Number] = gh
+			}
+			continue
+		}
+		result.Updated = append(result.Updated, issue.ID)
+		if opts.DryRun {
+			continue
+		}
+		if len(updates) > 0 {
+			if err := store.UpdateIssue(ctx, issue.ID, updates, actor); err != nil {
+				return nil, fmt.Errorf("failed to update %s: %w", issue.ID, err)
+			}
+		}
+		if labelsChanged {
+			syncIssueLabels(ctx, issue.ID, gh.LabelNames())
+		}
+		synced[gh.Number] = gh
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	for _, gh := range ghIssues {
+		issue := linked[gh.Number]
+		if synced[gh.Number] == nil || issue == nil {
+			continue
+		}
+		if gh.SubIssuesSummary != nil && gh.SubIssuesSummary.Total > 0 {
+			subs, err := client.ListSubIssues(ctx, gh.Number)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list sub-issues of %s: %w", githubRef(gh.Number), err)
+			}
+			for _, sub := range subs {
+				if child := linked[sub.Number]; child != nil && ensureDependency(ctx, child.ID, issue.ID, types.DepParentChild) {
+					result.Dependencies++
+				}
+			}
+		}
+		if gh.DependenciesSummary != nil && gh.DependenciesSummary.BlockedBy > 0 {
+			blockers, err := client.ListBlockedBy(ctx, gh.Number)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list blockers of %s: %w", githubRef(gh.Number), err)
+			}
+			for _, blocker := range blockers {
+				if dep := linked[blocker.Number]; dep != nil && ensureDependency(ctx, issue.ID, dep.ID, types.DepBlocks) {
+					result.Dependencies++
+				}
+			}
+		}
+	}
+
+	for number, gh := range synced {
+		if err := saveGitHubSyncState(ctx, linked[number].ID, gh.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to save sync state: %w", err)
+		}
+	}
+	if !resumeAt.IsZero() && resumeAt.Before(latest) {
+		latest = resumeAt
+	}
+	if !latest.IsZero() {
+		if err := store.SetMetadata(ctx, githubLastPullKey, latest.UTC().Format(time.RFC3339Nano)); err != nil {
+			return nil, fmt.Errorf("failed to save last pull time: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// githubPush sends bd changes to the linked GitHub issues: title, description,
+// status (open or closed), labels and assignee. A GitHub issue that also changed
+// since the last sync is reported as a conflict. With Create, open bd issues that
+// aren't linked yet become new GitHub issues. parent-child and blocks dependencies
+// between linked issues are added as sub-issues and "blocked by" links.
+func githubPush(ctx context.Context, client *github.Client, opts githubSyncOptions) (*githubSyncResult, error) {
+	result := newGitHubSyncResult(client.Repo())
+
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+
+	// numbers maps bd IDs to linked GitHub issue numbers; pushed holds the GitHub
+	// issues written by this push, by number
+	numbers := make(map[string]int)
+	for _, issue := range issues {
+		if number, ok := parseGitHubRef(issue.ExternalRef); ok {
+			numbers[issue.ID] = number
+		}
+	}
+	pushed := make(map[int]*github.Issue)
+	pushedIDs := make(map[int]string)
+
+	for _, issue := range issues {
+		labels, err := store.GetLabels(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for %s: %w", issue.ID, err)
+		}
+
+		number, ok := numbers[issue.ID]
+		if !ok {
+			if !opts.Create || issue.Status == types.StatusClosed {
+				continue
+			}
+			result.Created = append(result.Created, issue.ID)
+			if opts.DryRun {
+				continue
+			}
+			req, _ := githubIssueRequest(issue, labels, nil)
+			req.State = nil // New issues are always open
+			gh, err := client.CreateIssue(ctx, req)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create GitHub issue for %s: %w", issue.ID, err)
+			}
+			if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"external_ref": githubRef(gh.Number)}, actor); err != nil {
+				return nil, fmt.Errorf("failed to link %s to %s: %w", issue.ID, githubRef(gh.Number), err)
+			}
+			numbers[issue.ID] = gh.Number
+			pushed[gh.Number] = gh
+			pushedIDs[gh.Number] = issue.ID
+			continue
+		}
+
+		state, err := loadGitHubSyncState(ctx, issue.ID)
+		if err != nil {
+			return nil, err
+		}
+		if state != nil && !issue.UpdatedAt.After(state.BDUpdatedAt) {
+			result.Unchanged++
+			continue
+		}
+
+		gh, err := client.GetIssue(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", githubRef(number), err)
+		}
+		if state != nil && gh.UpdatedAt.After(state.GitHubUpdatedAt) && !opts.Force {
+			result.Conflicts = append(result.Conflicts, githubConflict{ID: issue.ID, ExternalRef: githubRef(number)})
+			continue
+		}
+
+		req, changed := githubIssueRequest(issue, labels, gh)
+		if !changed {
+			result.Unchanged++
+			if !opts.DryRun {
+				pushed[number] = gh
+				pushedIDs[number] = issue.ID
+			}
+			continue
+		}
+		result.Updated = append(result.Updated, issue.ID)
+		if opts.DryRun {
+			continue
+		}
+		updated, err := client.UpdateIssue(ctx, number, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", githubRef(number), err)
+		}
+		pushed[number] = updated
+		pushedIDs[number] = issue.ID
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	// ghIssue fetches a linked GitHub issue, for the IDs the relationship APIs need
+	ghIssue := func(number int) (*github.Issue, error) {
+		if gh := pushed[number]; gh != nil {
+			return gh, nil
+		}
+		gh, err := client.GetIssue(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", githubRef(number), err)
+		}
+		pushed[number] = gh
+		return gh, nil
+	}
+	subIssues := make(map[int]map[int]bool)
+	touched := make(map[int]bool)
+
+	pushedNumbers := make([]int, 0, len(pushedIDs))
+	for number := range pushedIDs {
+		pushedNumbers = append(pushedNumbers, number)
+	}
+	sort.Ints(pushedNumbers)
+	for _, number := range pushedNumbers {
+		deps, err := store.GetDependencyRecords(ctx, pushedIDs[number])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies of %s: %w", pushedIDs[number], err)
+		}
+		var blockedBy map[int]bool
+		for _, dep := range deps {
+			target, ok := numbers[dep.DependsOnID]
+			if !ok {
+				continue
+			}
+			switch dep.Type {
+			case types.DepParentChild:
+				if subIssues[target] == nil {
+					subs, err := client.ListSubIssues(ctx, target)
+					if err != nil {
+						return nil, fmt.Errorf("failed to list sub-issues of %s: %w", githubRef(target), err)
+					}
+					subIssues[target] = make(map[int]bool)
+					for _, sub := range subs {
+						subIssues[target][sub.Number] = true
+					}
+				}
+				if subIssues[target][number] {
+					continue
+				}
+				child, err := ghIssue(number)
+				if err != nil {
+					return nil, err
+				}
+				if err := client.AddSubIssue(ctx, target, child.ID); err != nil {
+					return nil, fmt.Errorf("failed to add %s as a sub-issue of %s: %w", githubRef(number), githubRef(target), err)
+				}
+				subIssues[target][number] = true
+				touched[target] = true
+				touched[number] = true
+				result.Dependencies++
+			case types.DepBlocks:
+				if blockedBy == nil {
+					blockers, err := client.ListBlockedBy(ctx, number)
+					if err != nil {
+						return nil, fmt.Errorf("failed to list blockers of %s: %w", githubRef(number), err)
+					}
+					blockedBy = make(map[int]bool)
+					for _, blocker := range blockers {
+						blockedBy[blocker.Number] = true
+					}
+				}
+				if blockedBy[target] {
+					continue
+				}
+				blocker, err := ghIssue(target)
+				if err != nil {
+					return nil, err
+				}
+				if err := client.AddBlockedBy(ctx, number, blocker.ID); err != nil {
+					return nil, fmt.Errorf("failed to mark %s blocked by %s: %w", githubRef(number), githubRef(target), err)
+				}
+				blockedBy[target] = true
+				touched[target] = true
+				touched[number] = true
+				result.Dependencies++
+			}
+		}
+	}
+
+	// Adding relationships bumps the GitHub issues' updated_at, so re-read them
+	// before recording the sync state
+	for number := range touched {
+		delete(pushed, number)
+		if _, err := ghIssue(number); err != nil {
+			return nil, err
+		}
+	}
+	for number, id := range pushedIDs {
+		if err := saveGitHubSyncState(ctx, id, pushed[number].UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to save sync state: %w", err)
+		}
+	}
+	// Issues that only gained a relationship stay in sync if they were before
+	for id, number := range numbers {
+		if !touched[number] || pushedIDs[number] != "" {
+			continue
+		}
+		state, err := loadGitHubSyncState(ctx, id)
+		if err != nil || state == nil {
+			continue
+		}
+		if err := saveGitHubSyncState(ctx, id, pushed[number].UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to save sync state: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// newGitHubClient builds a client from --repo (remembered in config for next time)
+// or the configured repository, --api-url or GITHUB_API_URL, and GITHUB_TOKEN or GH_TOKEN
+func newGitHubClient(ctx context.Context, cmd *cobra.Command) (*github.Client, error) {
+	repo, _ := cmd.Flags().GetString("repo")
+	if repo == "" {
+		configured, err := store.GetConfig(ctx, githubRepoConfigKey)
+		if err != nil {
+			return nil, err
+		}
+		repo = configured
+	}
+	if repo == "" {
+		return nil, fmt.Errorf("no GitHub repository configured (use --repo owner/name)")
+	}
+
+	apiURL, _ := cmd.Flags().GetString("api-url")
+	if apiURL == "" {
+		apiURL = os.Getenv("GITHUB_API_URL")
+	}
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+
+	client, err := github.NewClient(apiURL, repo, token)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Flags().Changed("repo") {
+		if err := store.SetConfig(ctx, githubRepoConfigKey, repo); err != nil {
+			return nil, fmt.Errorf("failed to save repository: %w", err)
+		}
+	}
+	return client, nil
+}
+
+// printGitHubSyncResult reports a pull or push
+func printGitHubSyncResult(verb string, result *githubSyncResult, dryRun bool, forceHint string) {
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	for _, c := range result.Conflicts {
+		fmt.Printf("%s %s (%s) changed in both bd and GitHub since the last sync; skipped (use --force to %s)\n",
+			yellow("⚠"), c.ID, c.ExternalRef, forceHint)
+	}
+	prefix := green("✓")
+	if dryRun {
+		prefix = "Dry run:"
+	}
+	fmt.Printf("%s %s %s: %d created, %d updated, %d unchanged", prefix, verb, result.Repo,
+		len(result.Created), len(result.Updated), result.Unchanged)
+	if result.Dependencies > 0 {
+		fmt.Printf(", %d dependencies added", result.Dependencies)
+	}
+	fmt.Println()
+	for _, id := range result.Created {
+		fmt.Printf("  + %s\n", id)
+	}
+	for _, id := range result.Updated {
+		fmt.Printf("  ~ %s\n", id)
+	}
+}
+
+var githubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Sync issues with a GitHub repository",
+	Long: `Sync issues with a GitHub repository.
+
+'bd github pull' imports GitHub issues and 'bd github push' sends bd changes
+back. Linked issues carry external_ref gh-<number>. Title, body, state, labels
+and assignee are synced; GitHub sub-issues map to parent-child dependencies and
+"blocked by" links to blocks dependencies.
+
+Each sync records both sides' updated_at. An issue changed on both sides since
+the last sync is a conflict and is skipped unless --force is given.
+
+The repository given with --repo is remembered. The token comes from
+GITHUB_TOKEN or GH_TOKEN, and GITHUB_API_URL (or --api-url) selects a GitHub
+Enterprise server.
+
+Examples:
+  bd github pull --repo acme/widgets
+  bd github push --create
+  bd github pull --dry-run`,
+}
+
+var githubPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Import GitHub issues changed since the last pull",
+	Run: func(cmd *cobra.Command, args []string) {
+		runGitHubSync(cmd, "Pulled", "take GitHub's version", githubPull)
+	},
+}
+
+var githubPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Send bd changes to linked GitHub issues",
+	Run: func(cmd *cobra.Command, args []string) {
+		runGitHubSync(cmd, "Pushed", "overwrite GitHub", githubPush)
+	},
+}
+
+func runGitHubSync(cmd *cobra.Command, verb, forceHint string,
+	sync func(context.Context, *github.Client, githubSyncOptions) (*githubSyncResult, error)) {
+	var opts githubSyncOptions
+	opts.Force, _ = cmd.Flags().GetBool("force")
+	opts.DryRun, _ = cmd.Flags().GetBool("dry-run")
+	if cmd.Flags().Lookup("full") != nil {
+		opts.Full, _ = cmd.Flags().GetBool("full")
+	}
+	if cmd.Flags().Lookup("create") != nil {
+		opts.Create, _ = cmd.Flags().GetBool("create")
+	}
+
+	ctx := context.Background()
+	client, err := newGitHubClient(ctx, cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	result, err := sync(ctx, client, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !opts.DryRun && (len(result.Created) > 0 || len(result.Updated) > 0 || result.Dependencies > 0) {
+		markDirtyAndScheduleFlush()
+	}
+	printGitHubSyncResult(verb, result, opts.DryRun, forceHint)
+}
+
+func init() {
+	githubCmd.PersistentFlags().String("repo", "", "GitHub repository (owner/name)")
+	githubCmd.PersistentFlags().String("api-url", "", "GitHub API URL (default: GITHUB_API_URL or https://api.github.com)")
+	githubCmd.PersistentFlags().Bool("force", false, "Overwrite the other side on conflict")
+	githubCmd.PersistentFlags().Bool("dry-run", false, "Show what would change without changing anything")
+	githubPullCmd.Flags().Bool("full", false, "List every GitHub issue, not just those updated since the last pull")
+	githubPushCmd.Flags().Bool("create", false, "Open GitHub issues for unlinked, unclosed bd issues")
+
+	githubCmd.AddCommand(githubPullCmd)
+	githubCmd.AddCommand(githubPushCmd)
+	rootCmd.AddCommand(githubCmd)
+}
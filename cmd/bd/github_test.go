@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/github"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// fakeGitHub is an in-memory stand-in for the parts of the GitHub Issues API the
+// bridge uses
+type fakeGitHub struct {
+	mu        sync.Mutex
+	clock     time.Time
+	issues    map[int]*github.Issue
+	subIssues map[int][]int
+	blockedBy map[int][]int
+}
+
+func newFakeGitHub() *fakeGitHub {
+	return &fakeGitHub{
+		clock:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		issues:    make(map[int]*github.Issue),
+		subIssues: make(map[int][]int),
+		blockedBy: make(map[int][]int),
+	}
+}
+
+// tick advances the fake clock; every write gets a distinct updated_at
+func (f *fakeGitHub) tick() time.Time {
+	f.clock = f.clock.Add(time.Second)
+	return f.clock
+}
+
+func (f *fakeGitHub) add(title, state string, labels ...string) *github.Issue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	number := len(f.issues) + 1
+	issue := &github.Issue{ID: int64(1000 + number), Number: number, Title: title, State: state, UpdatedAt: f.tick()}
+	for _, l := range labels {
+		issue.Labels = append(issue.Labels, github.Label{Name: l})
+	}
+	f.issues[number] = issue
+	return issue
+}
+
+func (f *fakeGitHub) edit(number int, fn func(*github.Issue)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fn(f.issues[number])
+	f.issues[number].UpdatedAt = f.tick()
+}
+
+func (f *fakeGitHub) byID(id int64) int {
+	for number, issue := range f.issues {
+		if issue.ID == id {
+			return number
+		}
+	}
+	return 0
+}
+
+// view returns an issue as the API would, with relationship summaries filled in
+func (f *fakeGitHub) view(number int) *github.Issue {
+	issue := *f.issues[number]
+	issue.SubIssuesSummary = &github.SubIssuesSummary{Total: len(f.subIssues[number])}
+	issue.DependenciesSummary = &github.DependenciesSummary{BlockedBy: len(f.blockedBy[number])}
+	return &issue
+}
+
+func (f *fakeGitHub) views(numbers []int) []*github.Issue {
+	list := []*github.Issue{}
+	for _, n := range numbers {
+		list = append(list, f.view(n))
+	}
+	return list
+}
+
+func (f *fakeGitHub) apply(issue *github.Issue, req *github.IssueRequest) {
+	if req.Title != nil {
+		issue.Title = *req.Title
+	}
+	if req.Body != nil {
+		issue.Body = *req.Body
+	}
+	if req.State != nil {
+		issue.State = *req.State
+	}
+	if req.Labels != nil {
+		issue.Labels = nil
+		for _, l := range *req.Labels {
+			issue.Labels = append(issue.Labels, github.Label{Name: l})
+		}
+	}
+	if req.Assignees != nil {
+		issue.Assignee = nil
+		if len(*req.Assignees) > 0 {
+			issue.Assignee = &github.User{Login: (*req.Assignees)[0]}
+		}
+	}
+	issue.UpdatedAt = f.tick()
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path, ok := strings.CutPrefix(r.URL.Path, "/repos/acme/widgets/issues")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	reply := func(v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	if parts[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			var since time.Time
+			if s := r.URL.Query().Get("since"); s != "" {
+				since, _ = time.Parse(time.RFC3339, s)
+			}
+			var numbers []int
+			for n, issue := range f.issues {
+				if !issue.UpdatedAt.Before(since) {
+					numbers = append(numbers, n)
+				}
+			}
+			sort.Ints(numbers)
+			reply(f.views(numbers))
+		case http.MethodPost:
+			var req github.IssueRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			number := len(f.issues) + 1
+			issue := &github.Issue{ID: int64(1000 + number), Number: number, State: "open"}
+			f.apply(issue, &req)
+			f.issues[number] = issue
+			w.WriteHeader(http.StatusCreated)
+			reply(f.view(number))
+		}
+		return
+	}
+
+	number, err := strconv.Atoi(parts[0])
+	if err != nil || f.issues[number] == nil {
+		http.NotFound(w, r)
+		return
+	}
+	var body struct {
+		SubIssueID int64 `json:"sub_issue_id"`
+		IssueID    int64 `json:"issue_id"`
+	}
+	switch rest := strings.Join(parts[1:], "/"); {
+	case rest == "" && r.Method == http.MethodGet:
+		reply(f.view(number))
+	case rest == "" && r.Method == http.MethodPatch:
+		var req github.IssueRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.apply(f.issues[number], &req)
+		reply(f.view(number))
+	case rest == "sub_issues" && r.Method == http.MethodGet:
+		reply(f.views(f.subIssues[number]))
+	case rest == "sub_issues" && r.Method == http.MethodPost:
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.subIssues[number] = append(f.subIssues[number], f.byID(body.SubIssueID))
+		f.issues[number].UpdatedAt = f.tick()
+		reply(f.view(number))
+	case rest == "dependencies/blocked_by" && r.Method == http.MethodGet:
+		reply(f.views(f.blockedBy[number]))
+	case rest == "dependencies/blocked_by" && r.Method == http.MethodPost:
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.blockedBy[number] = append(f.blockedBy[number], f.byID(body.IssueID))
+		f.issues[number].UpdatedAt = f.tick()
+		reply(f.view(number))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestGitHubPullAndPush(t *testing.T) {
+	tmpDir := t.TempDir()
+	testStore, err := sqlite.New(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer testStore.Close()
+
+	oldStore := store
+	store = testStore
+	defer func() { store = oldStore }()
+
+	fake := newFakeGitHub()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client, err := github.NewClient(server.URL, "acme/widgets", "token")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	fake.add("Epic", "open", "epic")
+	fake.add("Child", "open")
+	fake.add("Blocker", "closed")
+	fake.edit(2, func(i *github.Issue) { i.Assignee = &github.User{Login: "alice"} })
+	fake.subIssues[1] = []int{2}
+	fake.blockedBy[2] = []int{3}
+
+	// First pull creates every issue and maps the relationships
+	result, err := githubPull(ctx, client, githubSyncOptions{})
+	if err != nil {
+		t.Fatalf("githubPull failed: %v", err)
+	}
+	if len(result.Created) != 3 || result.Dependencies != 2 {
+		t.Fatalf("Expected 3 issues and 2 dependencies created, got %+v", result)
+	}
+	linked, _ := githubLinkedIssues(ctx)
+	epic, child, blocker := linked[1], linked[2], linked[3]
+	if epic == nil || child == nil || blocker == nil {
+		t.Fatalf("Expected gh-1..3 to be linked, got %v", linked)
+	}
+	if blocker.Status != types.StatusClosed || child.Assignee != "alice" {
+		t.Errorf("State or assignee not imported: %+v %+v", blocker, child)
+	}
+	if labels, _ := testStore.GetLabels(ctx, epic.ID); len(labels) != 1 || labels[0] != "epic" {
+		t.Errorf("Labels not imported: %v", labels)
+	}
+	deps, _ := testStore.GetDependencyRecords(ctx, child.ID)
+	got := map[string]types.DependencyType{}
+	for _, dep := range deps {
+		got[dep.DependsOnID] = dep.Type
+	}
+	if got[epic.ID] != types.DepParentChild || got[blocker.ID] != types.DepBlocks {
+		t.Errorf("Expected parent-child on epic and blocks on blocker, got %v", got)
+	}
+
+	// Pulling again changes nothing
+	result, err = githubPull(ctx, client, githubSyncOptions{})
+	if err != nil {
+		t.Fatalf("githubPull failed: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Updated) != 0 {
+		t.Errorf("Expected no changes on a second pull, got %+v", result)
+	}
+
+	// A GitHub edit is pulled
+	fake.edit(1, func(i *github.Issue) { i.Title = "Epic (renamed)" })
+	result, err = githubPull(ctx, client, githubSyncOptions{})
+	if err != nil {
+		t.Fatalf("githubPull failed: %v", err)
+	}
+	if len(result.Updated) != 1 || result.Updated[0] != epic.ID {
+		t.Fatalf("Expected %s to be updated, got %+v", epic.ID, result)
+	}
+	if issue, _ := testStore.GetIssue(ctx, epic.ID); issue.Title != "Epic (renamed)" {
+		t.Errorf("Title not pulled: %q", issue.Title)
+	}
+
+	// A bd edit is pushed, and only to the changed issue
+	if err := testStore.UpdateIssue(ctx, child.ID, map[string]interface{}{"title": "Child (edited)"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	result, err = githubPush(ctx, client, githubSyncOptions{})
+	if err != nil {
+		t.Fatalf("githubPush failed: %v", err)
+	}
+	if len(result.Updated) != 1 || result.Updated[0] != child.ID {
+		t.Fatalf("Expected only %s to be pushed, got %+v", child.ID, result)
+	}
+	if fake.issues[2].Title != "Child (edited)" || fake.issues[2].AssigneeLogin() != "alice" {
+		t.Errorf("Push did not update GitHub: %+v", fake.issues[2])
+	}
+
+	// Changes on both sides conflict in either direction until forced
+	fake.edit(2, func(i *github.Issue) { i.Body = "from GitHub" })
+	if err := testStore.UpdateIssue(ctx, child.ID, map[string]interface{}{"description": "from bd"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	for name, sync := range map[string]func(context.Context, *github.Client, githubSyncOptions) (*githubSyncResult, error){
+		"pull": githubPull, "push": githubPush,
+	} {
+		result, err = sync(ctx, client, githubSyncOptions{})
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if len(result.Conflicts) != 1 || result.Conflicts[0].ID != child.ID || len(result.Updated) != 0 {
+			t.Errorf("Expected %s to report a conflict on %s, got %+v", name, child.ID, result)
+		}
+	}
+	result, err = githubPull(ctx, client, githubSyncOptions{Force: true})
+	if err != nil {
+		t.Fatalf("githubPull failed: %v", err)
+	}
+	if issue, _ := testStore.GetIssue(ctx, child.ID); issue.Description != "from GitHub" || len(result.Conflicts) != 0 {
+		t.Errorf("Forced pull did not take GitHub's version: %q, %+v", issue.Description, result)
+	}
+
+	// --create opens GitHub issues for new bd issues and links their dependencies
+	local := &types.Issue{Title: "Local", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := testStore.CreateIssue(ctx, local, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	dep := &types.Dependency{IssueID: local.ID, DependsOnID: child.ID, Type: types.DepBlocks}
+	if err := testStore.AddDependency(ctx, dep, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	result, err = githubPush(ctx, client, githubSyncOptions{Create: true})
+	if err != nil {
+		t.Fatalf("githubPush failed: %v", err)
+	}
+	if len(result.Created) != 1 || result.Dependencies != 1 {
+		t.Fatalf("Expected 1 issue and 1 dependency pushed, got %+v", result)
+	}
+	created, _ := testStore.GetIssue(ctx, local.ID)
+	number, ok := parseGitHubRef(created.ExternalRef)
+	if !ok || fake.issues[number].Title != "Local" {
+		t.Fatalf("Expected %s to be linked to a new GitHub issue, got ref %v", local.ID, created.ExternalRef)
+	}
+	if blockers := fake.blockedBy[number]; len(blockers) != 1 || blockers[0] != 2 {
+		t.Errorf("Expected gh-%d to be blocked by gh-2, got %v", number, blockers)
+	}
+
+	// Everything is in sync afterwards
+	result, err = githubPush(ctx, client, githubSyncOptions{Create: true})
+	if err != nil {
+		t.Fatalf("githubPush failed: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Updated) != 0 || result.Dependencies != 0 {
+		t.Errorf("Expected nothing left to push, got %+v", result)
+	}
+}
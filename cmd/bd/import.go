@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"time"
@@ -16,11 +17,30 @@ import (
 
 var importCmd = &cobra.Command{
 	Use:   "import",
-	Short: "Import issues from JSONL format",
+	Short: "Import issues from JSONL, CSV or Jira exports",
 	Long: `Import issues from JSON Lines format (one JSON object per line).
 
 Reads from stdin by default, or use -i flag for file input.
 
+With --format csv, the header row names the columns (as written by
+'bd export --format csv'). Columns missing from the file keep their stored
+values, rows without an id create new issues, and every row is validated
+before anything is written. A row whose updated_at matches the database is
+an edit and is applied as an update; other differences are collisions.
+
+With --format jira-xml or jira-csv, reads a Jira XML or CSV export. Issue
+keys become external_ref jira-<key>, so importing a newer export updates the
+issues of an earlier one. Sub-tasks and epic links become parent-child
+dependencies, "Blocks" links become blocks dependencies, and comments are
+added to the issues. Jira types, priorities, statuses and link types are
+mapped to bd values; override or extend the mapping with a JSON file
+(.beads/jira-mapping.json, or --mapping):
+
+  {"types": {"Spike": "task"}, "priorities": {"P1": 1},
+   "statuses": {"QA": "in_progress"}, "link_types": {"Duplicate": "related"}}
+
+Values without a mapping are imported with defaults and reported.
+
 Behavior:
   - Existing issues (same ID) are updated
   - New issues are created
@@ -33,6 +53,14 @@ Behavior:
 		strict, _ := cmd.Flags().GetBool("strict")
 		resolveCollisions, _ := cmd.Flags().GetBool("resolve-collisions")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		format, _ := cmd.Flags().GetString("format")
+
+		switch format {
+		case "jsonl", "csv", "jira-xml", "jira-csv":
+		default:
+			fmt.Fprintf(os.Stderr, "Error: unsupported format '%s' (use 'jsonl', 'csv', 'jira-xml' or 'jira-csv')\n", format)
+			os.Exit(1)
+		}
 
 		// Open input
 		in := os.Stdin
@@ -50,35 +78,24 @@ Behavior:
 			in = f
 		}
 
-		// Phase 1: Read and parse all JSONL
 		ctx := context.Background()
-		scanner := bufio.NewScanner(in)
+		if format == "jira-xml" || format == "jira-csv" {
+			mappingPath, _ := cmd.Flags().GetString("mapping")
+			importJira(ctx, in, format, mappingPath, dryRun)
+			return
+		}
 
+		// Phase 1: Read and parse all issues
 		var allIssues []*types.Issue
-		lineNum := 0
-
-		for scanner.Scan() {
-			lineNum++
-			line := scanner.Text()
-
-			// Skip empty lines
-			if line == "" {
-				continue
-			}
-
-			// Parse JSON
-			var issue types.Issue
-			if err := json.Unmarshal([]byte(line), &issue); err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing line %d: %v\n", lineNum, err)
+		if format == "csv" {
+			var err error
+			allIssues, err = readCSVIssues(ctx, in)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
-
-			allIssues = append(allIssues, &issue)
-		}
-
-		if err := scanner.Err(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
-			os.Exit(1)
+		} else {
+			allIssues = readJSONLIssues(in)
 		}
 
 		// Phase 2: Detect collisions
@@ -94,8 +111,18 @@ Behavior:
 			os.Exit(1)
 		}
 
+		// Spreadsheet edits to issues nobody else changed are updates, not collisions
+		var edits []*sqlite.CollisionDetail
+		if format == "csv" {
+			edits, collisionResult.Collisions = splitCSVEdits(collisionResult.Collisions)
+			if dryRun {
+				printCSVDiff(ctx, edits, allIssues)
+			}
+		}
+
 		var idMapping map[string]string
 		var created, updated, skipped int
+		var labelsAdded, labelsRemoved int
 
 		// Phase 3: Handle collisions
 		if len(collisionResult.Collisions) > 0 {
@@ -160,7 +187,7 @@ Behavior:
 			// No collisions in dry-run mode
 			fmt.Fprintf(os.Stderr, "No collisions detected.\n")
 			fmt.Fprintf(os.Stderr, "Would create %d new issues, update %d existing issues\n",
-				len(collisionResult.NewIssues), len(collisionResult.ExactMatches))
+				len(collisionResult.NewIssues), len(collisionResult.ExactMatches)+len(edits))
 			os.Exit(0)
 		}
 
@@ -233,6 +260,14 @@ Behavior:
 		  updates["external_ref"] = nil
 				}
 		}
+		if _, ok := rawData["due_at"]; ok {
+			updates["due_at"] = issue.DueAt
+		}
+		if _, ok := rawData["defer_until"]; ok {
+			updates["defer_until"] = issue.DeferUntil
+		}
+		// Always sync: the rule is omitted once it moves to the next occurrence
+		updates["recurrence"] = issue.Recurrence
 
 		if err := store.UpdateIssue(ctx, issue.ID, updates, "import"); err != nil {
 		 fmt.Fprintf(os.Stderr, "Error updating issue %s: %v\n", issue.ID, err)
@@ -253,7 +288,8 @@ Behavior:
 		}
 
 		 // Handle duplicates within the same import batch (last one wins)
-		 if idx, ok := seenNew[issue.ID]; ok {
+		 // CSV rows without an ID are all new issues
+		 if idx, ok := seenNew[issue.ID]; ok && issue.ID != "" {
 		 // Last one wins regardless of skipUpdate (skipUpdate only applies to existing DB issues)
 		 newIssues[idx] = issue
 		 } else {
@@ -270,6 +306,17 @@ Behavior:
 			os.Exit(1)
 		}
 		created += len(newIssues)
+
+		// New issues get their labels on creation, so Phase 7 finds nothing to add
+		for _, issue := range newIssues {
+			seen := make(map[string]bool)
+			for _, label := range issue.Labels {
+				if !seen[label] {
+					seen[label] = true
+					labelsAdded++
+				}
+			}
+		}
 	}
 
 		// Phase 5: Sync ID counters after importing issues with explicit IDs
@@ -332,7 +379,6 @@ Behavior:
 
 		// Phase 7: Process labels
 		// Sync labels for all imported issues
-		var labelsAdded, labelsRemoved int
 		for _, issue := range allIssues {
 			if issue.Labels == nil {
 				continue
@@ -378,6 +424,18 @@ Behavior:
 			}
 		}
 
+		// Phase 8: Process work logs
+		// Entries already recorded are skipped by the storage layer
+		for _, issue := range allIssues {
+			for _, entry := range issue.WorkLogs {
+				entry.IssueID = issue.ID
+				if err := store.AddWorkLog(ctx, entry, "import"); err != nil {
+					fmt.Fprintf(os.Stderr, "Error adding work log to %s: %v\n", issue.ID, err)
+					os.Exit(1)
+				}
+			}
+		}
+
 		// Schedule auto-flush after import completes
 		markDirtyAndScheduleFlush()
 
@@ -409,6 +467,39 @@ Behavior:
 	},
 }
 
+// readJSONLIssues reads one issue per line, exiting on malformed input
+func readJSONLIssues(in io.Reader) []*types.Issue {
+	scanner := bufio.NewScanner(in)
+
+	var allIssues []*types.Issue
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		// Skip empty lines
+		if line == "" {
+			continue
+		}
+
+		// Parse JSON
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing line %d: %v\n", lineNum, err)
+			os.Exit(1)
+		}
+
+		allIssues = append(allIssues, &issue)
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+		os.Exit(1)
+	}
+	return allIssues
+}
+
 // printCollisionReport prints a detailed report of detected collisions
 func printCollisionReport(result *sqlite.CollisionResult) {
 	fmt.Fprintf(os.Stderr, "\n=== Collision Detection Report ===\n")
@@ -464,6 +555,8 @@ func printRemappingReport(idMapping map[string]string, collisions []*sqlite.Coll
 
 func init() {
 	importCmd.Flags().StringP("input", "i", "", "Input file (default: stdin)")
+	importCmd.Flags().StringP("format", "f", "jsonl", "Input format (jsonl, csv, jira-xml, jira-csv)")
+	importCmd.Flags().String("mapping", "", "Jira value mapping file (default: .beads/jira-mapping.json if present)")
 	importCmd.Flags().BoolP("skip-existing", "s", false, "Skip existing issues instead of updating them")
 	importCmd.Flags().Bool("strict", false, "Fail on dependency errors instead of treating them as warnings")
 	importCmd.Flags().Bool("resolve-collisions", false, "Automatically resolve ID collisions by remapping")
@@ -0,0 +1,521 @@
+// This file implements 'bd import --format jira-xml|jira-csv', which brings issues
+// from Jira's offline exports into bd.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/jira"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const (
+	jiraRefPrefix       = "jira-"
+	jiraMappingFile     = "jira-mapping.json"
+	jiraDefaultPriority = 2
+)
+
+// jiraMapping maps Jira's display values to bd values. Keys match case-insensitively.
+// A mapping file only needs the entries it adds or overrides.
+type jiraMapping struct {
+	Types      map[string]types.IssueType      `json:"types"`
+	Priorities map[string]int                  `json:"priorities"`
+	Statuses   map[string]types.Status         `json:"statuses"`
+	LinkTypes  map[string]types.DependencyType `json:"link_types"`
+}
+
+// defaultJiraMapping covers the values of Jira's default schemes
+func defaultJiraMapping() *jiraMapping {
+	return &jiraMapping{
+		Types: map[string]types.IssueType{
+			"bug":         types.TypeBug,
+			"story":       types.TypeFeature,
+			"new feature": types.TypeFeature,
+			"improvement": types.TypeFeature,
+			"task":        types.TypeTask,
+			"sub-task":    types.TypeTask,
+			"subtask":     types.TypeTask,
+			"epic":        types.TypeEpic,
+		},
+		Priorities: map[string]int{
+			"highest": 0, "blocker": 0,
+			"high": 1, "critical": 1,
+			"medium": 2, "major": 2,
+			"low": 3, "minor": 3,
+			"lowest": 4, "trivial": 4,
+		},
+		Statuses: map[string]types.Status{
+			"open":                     types.StatusOpen,
+			"to do":                    types.StatusOpen,
+			"backlog":                  types.StatusOpen,
+			"selected for development": types.StatusOpen,
+			"reopened":                 types.StatusOpen,
+			"in progress":              types.StatusInProgress,
+			"in review":                types.StatusInProgress,
+			"blocked":                  types.StatusBlocked,
+			"done":                     types.StatusClosed,
+			"closed":                   types.StatusClosed,
+			"resolved":                 types.StatusClosed,
+		},
+		LinkTypes: map[string]types.DependencyType{
+			"blocks":  types.DepBlocks,
+			"relates": types.DepRelated,
+		},
+	}
+}
+
+// loadJiraMapping returns the default mapping with a mapping file's entries applied
+// over it. An empty path uses .beads/jira-mapping.json if it exists.
+func loadJiraMapping(path string) (*jiraMapping, error) {
+	mapping := defaultJiraMapping()
+	if path == "" {
+		if dbPath == "" {
+			return mapping, nil
+		}
+		path = filepath.Join(filepath.Dir(dbPath), jiraMappingFile)
+		if _, err := os.Stat(path); err != nil {
+			return mapping, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping: %w", err)
+	}
+	var custom jiraMapping
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping %s: %w", path, err)
+	}
+
+	for k, v := range custom.Types {
+		if !v.IsValid() {
+			return nil, fmt.Errorf("mapping %s: invalid issue type %q for %q", path, v, k)
+		}
+		mapping.Types[strings.ToLower(k)] = v
+	}
+	for k, v := range custom.Priorities {
+		if v < 0 || v > 4 {
+			return nil, fmt.Errorf("mapping %s: priority for %q must be between 0 and 4", path, k)
+		}
+		mapping.Priorities[strings.ToLower(k)] = v
+	}
+	for k, v := range custom.Statuses {
+		if !v.IsValid() {
+			return nil, fmt.Errorf("mapping %s: invalid status %q for %q", path, v, k)
+		}
+		mapping.Statuses[strings.ToLower(k)] = v
+	}
+	for k, v := range custom.LinkTypes {
+		if v != "" && !v.IsValid() {
+			return nil, fmt.Errorf("mapping %s: invalid dependency type %q for %q", path, v, k)
+		}
+		mapping.LinkTypes[strings.ToLower(k)] = v
+	}
+	return mapping, nil
+}
+
+// jiraUnmapped counts the Jira values that had no mapping, by field
+type jiraUnmapped map[string]map[string]int
+
+func (u jiraUnmapped) add(field, value string) {
+	if u[field] == nil {
+		u[field] = make(map[string]int)
+	}
+	u[field][value]++
+}
+
+// toIssue converts a Jira issue, recording values without a mapping. Unmapped
+// types become tasks, priorities P2, and statuses open (closed if Jira marked
+// the issue resolved).
+func (m *jiraMapping) toIssue(ji *jira.Issue, unmapped jiraUnmapped) *types.Issue {
+	ref := jiraRefPrefix + ji.Key
+	issue := &types.Issue{
+		Title:       ji.Summary,
+		Description: ji.Description,
+		Status:      types.StatusOpen,
+		Priority:    jiraDefaultPriority,
+		IssueType:   types.TypeTask,
+		Assignee:    ji.Assignee,
+		ExternalRef: &ref,
+		Labels:      ji.Labels,
+	}
+	if issue.Title == "" {
+		issue.Title = ji.Key
+	}
+
+	if ji.Type != "" {
+		if t, ok := m.Types[strings.ToLower(ji.Type)]; ok {
+			issue.IssueType = t
+		} else {
+			unmapped.add("type", ji.Type)
+		}
+	}
+	if ji.Priority != "" {
+		if p, ok := m.Priorities[strings.ToLower(ji.Priority)]; ok {
+			issue.Priority = p
+		} else {
+			unmapped.add("priority", ji.Priority)
+		}
+	}
+	if s, ok := m.Statuses[strings.ToLower(ji.Status)]; ok {
+		issue.Status = s
+	} else {
+		if ji.Status != "" {
+			unmapped.add("status", ji.Status)
+		}
+		if ji.IsResolved() {
+			issue.Status = types.StatusClosed
+		}
+	}
+	if issue.Status == types.StatusClosed {
+		closedAt := time.Now()
+		if ji.Resolved != nil {
+			closedAt = *ji.Resolved
+		}
+		issue.ClosedAt = &closedAt
+	}
+	return issue
+}
+
+// jiraDependency is a dependency between two Jira keys
+type jiraDependency struct {
+	From, To string // From depends on To
+	Type     types.DependencyType
+}
+
+// dependencies returns the bd dependencies implied by the parsed issues: sub-tasks
+// and epic members are children of their parent, and links map by type. For
+// blocking links the blocked issue depends on the blocker; other links depend from
+// the outward side. Links Jira exports on both ends appear once, as do reciprocal
+// links of a non-blocking type.
+func (m *jiraMapping) dependencies(issues []*jira.Issue, unmapped jiraUnmapped) []jiraDependency {
+	var deps []jiraDependency
+	seen := make(map[jiraDependency]bool)
+	add := func(dep jiraDependency) {
+		if dep.From == "" || dep.To == "" || dep.From == dep.To || seen[dep] {
+			return
+		}
+		if dep.Type != types.DepParentChild && dep.Type != types.DepBlocks &&
+			seen[jiraDependency{From: dep.To, To: dep.From, Type: dep.Type}] {
+			return // Reciprocal links like "relates to" are one relation
+		}
+		seen[dep] = true
+		deps = append(deps, dep)
+	}
+
+	seenLinks := make(map[jira.Link]bool)
+	for _, ji := range issues {
+		add(jiraDependency{From: ji.Key, To: ji.Parent, Type: types.DepParentChild})
+		add(jiraDependency{From: ji.Key, To: ji.EpicLink, Type: types.DepParentChild})
+		for _, link := range ji.Links {
+			if seenLinks[link] {
+				continue
+			}
+			seenLinks[link] = true
+			depType, ok := m.LinkTypes[strings.ToLower(link.Type)]
+			if !ok {
+				unmapped.add("link type", link.Type)
+				continue
+			}
+			switch depType {
+			case "":
+				// Mapped to "" to ignore the link type
+			case types.DepBlocks:
+				add(jiraDependency{From: link.To, To: link.From, Type: depType})
+			default:
+				add(jiraDependency{From: link.From, To: link.To, Type: depType})
+			}
+		}
+	}
+	return deps
+}
+
+// jiraImportResult summarizes a Jira import
+type jiraImportResult struct {
+	Created      []string     `json:"created"`
+	Updated      []string     `json:"updated"`
+	Unchanged    int          `json:"unchanged"`
+	Dependencies int          `json:"dependencies_added"`
+	Comments     int          `json:"comments_added"`
+	MissingLinks int          `json:"missing_links"` // Links to issues in neither the export nor bd
+	Unmapped     jiraUnmapped `json:"unmapped"`
+}
+
+// importJiraIssues creates or updates bd issues from parsed Jira issues. Issues are
+// matched by external_ref jira-<key>, so importing a newer export updates the issues
+// of an earlier one. Comments already on an issue are not added again.
+func importJiraIssues(ctx context.Context, jiraIssues []*jira.Issue, mapping *jiraMapping, dryRun bool) (*jiraImportResult, error) {
+	result := &jiraImportResult{Created: []string{}, Updated: []string{}, Unmapped: jiraUnmapped{}}
+
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+	existing := make(map[string]*types.Issue)
+	for _, issue := range all {
+		if issue.ExternalRef != nil && strings.HasPrefix(*issue.ExternalRef, jiraRefPrefix) {
+			existing[strings.TrimPrefix(*issue.ExternalRef, jiraRefPrefix)] = issue
+		}
+	}
+
+	// Convert everything first so the report covers the whole export
+	converted := make([]*types.Issue, len(jiraIssues))
+	for i, ji := range jiraIssues {
+		converted[i] = mapping.toIssue(ji, result.Unmapped)
+	}
+	deps := mapping.dependencies(jiraIssues, result.Unmapped)
+
+	var newIssues []*types.Issue
+	newIndex := make(map[string]int)
+	for i, ji := range jiraIssues {
+		incoming := converted[i]
+		current := existing[ji.Key]
+		if current == nil {
+			if _, dup := newIndex[ji.Key]; dup {
+				newIssues[newIndex[ji.Key]] = incoming // Last one wins
+				continue
+			}
+			newIndex[ji.Key] = len(newIssues)
+			newIssues = append(newIssues, incoming)
+			result.Created = append(result.Created, ji.Key)
+			continue
+		}
+
+		updates := jiraUpdates(current, incoming)
+		labels, err := store.GetLabels(ctx, current.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for %s: %w", current.ID, err)
+		}
+		labelsChanged := !sameLabels(labels, incoming.Labels)
+		if len(updates) == 0 && !labelsChanged {
+			result.Unchanged++
+			continue
+		}
+		result.Updated = append(result.Updated, current.ID)
+		if dryRun {
+			continue
+		}
+		if len(updates) > 0 {
+			if err := store.UpdateIssue(ctx, current.ID, updates, "import"); err != nil {
+				return nil, fmt.Errorf("failed to update %s: %w", current.ID, err)
+			}
+		}
+		if labelsChanged {
+			syncIssueLabels(ctx, current.ID, incoming.Labels)
+		}
+	}
+
+	// Dependencies are added once every issue exists, so a link that would form a
+	// cycle is reported and skipped instead of failing the whole import
+	var links []jiraDependency
+	for _, dep := range deps {
+		_, fromNew := newIndex[dep.From]
+		_, toNew := newIndex[dep.To]
+		if (!fromNew && existing[dep.From] == nil) || (!toNew && existing[dep.To] == nil) {
+			result.MissingLinks++
+			continue
+		}
+		if dryRun {
+			if fromNew || toNew || !jiraDependencyExists(ctx, existing[dep.From].ID, existing[dep.To]) {
+				result.Dependencies++
+			}
+			continue
+		}
+		links = append(links, dep)
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if len(newIssues) > 0 {
+		if err := store.CreateIssues(ctx, newIssues, "import"); err != nil {
+			return nil, fmt.Errorf("failed to create issues: %w", err)
+		}
+		for key, i := range newIndex {
+			existing[key] = newIssues[i]
+		}
+		for i, key := range result.Created {
+			result.Created[i] = existing[key].ID
+		}
+	}
+	for _, dep := range links {
+		if ensureDependency(ctx, existing[dep.From].ID, existing[dep.To].ID, dep.Type) {
+			result.Dependencies++
+		}
+	}
+
+	for _, ji := range jiraIssues {
+		if len(ji.Comments) == 0 {
+			continue
+		}
+		added, err := addJiraComments(ctx, existing[ji.Key].ID, ji.Comments)
+		if err != nil {
+			return nil, err
+		}
+		result.Comments += added
+	}
+	return result, nil
+}
+
+// jiraDependencyExists reports whether issueID already depends on target
+func jiraDependencyExists(ctx context.Context, issueID string, target *types.Issue) bool {
+	if target == nil {
+		return false
+	}
+	deps, err := store.GetDependencyRecords(ctx, issueID)
+	if err != nil {
+		return false
+	}
+	for _, dep := range deps {
+		if dep.DependsOnID == target.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// jiraUpdates returns the fields of current that differ from an imported issue
+func jiraUpdates(current, incoming *types.Issue) map[string]interface{} {
+	updates := make(map[string]interface{})
+	if current.Title != incoming.Title {
+		updates["title"] = incoming.Title
+	}
+	if current.Description != incoming.Description {
+		updates["description"] = incoming.Description
+	}
+	if current.Status != incoming.Status {
+		updates["status"] = string(incoming.Status)
+	}
+	if current.Priority != incoming.Priority {
+		updates["priority"] = incoming.Priority
+	}
+	if current.IssueType != incoming.IssueType {
+		updates["issue_type"] = string(incoming.IssueType)
+	}
+	if current.Assignee != incoming.Assignee {
+		updates["assignee"] = incoming.Assignee
+	}
+	return updates
+}
+
+// addJiraComments adds the comments an issue doesn't already have, attributed to
+// their Jira authors. Returns how many were added.
+func addJiraComments(ctx context.Context, issueID string, comments []jira.Comment) (int, error) {
+	events, err := store.GetEvents(ctx, issueID, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get comments of %s: %w", issueID, err)
+	}
+	have := make(map[string]bool)
+	for _, e := range events {
+		if e.EventType == types.EventCommented && e.Comment != nil {
+			have[*e.Comment] = true
+		}
+	}
+
+	added := 0
+	for _, c := range comments {
+		if have[c.Body] {
+			continue
+		}
+		author := c.Author
+		if author == "" {
+			author = "import"
+		}
+		if err := store.AddComment(ctx, issueID, author, c.Body); err != nil {
+			return added, fmt.Errorf("failed to add comment to %s: %w", issueID, err)
+		}
+		have[c.Body] = true
+		added++
+	}
+	return added, nil
+}
+
+// printJiraUnmapped reports the Jira values imported with defaults
+func printJiraUnmapped(w io.Writer, unmapped jiraUnmapped) {
+	if len(unmapped) == 0 {
+		return
+	}
+	fallback := map[string]string{
+		"type":      string(types.TypeTask),
+		"priority":  fmt.Sprintf("P%d", jiraDefaultPriority),
+		"status":    "open (closed if resolved)",
+		"link type": "skipped",
+	}
+
+	fmt.Fprintf(w, "\nUnmapped Jira values:\n")
+	for _, field := range []string{"type", "priority", "status", "link type"} {
+		values := make([]string, 0, len(unmapped[field]))
+		for value := range unmapped[field] {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			fmt.Fprintf(w, "  %s %q (%d) → %s\n", field, value, unmapped[field][value], fallback[field])
+		}
+	}
+	fmt.Fprintf(w, "Map them in %s (or a file given with --mapping).\n", filepath.Join(".beads", jiraMappingFile))
+}
+
+// importJira runs 'bd import' for the Jira formats
+func importJira(ctx context.Context, in io.Reader, format, mappingPath string, dryRun bool) {
+	mapping, err := loadJiraMapping(mappingPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var jiraIssues []*jira.Issue
+	if format == "jira-xml" {
+		jiraIssues, err = jira.ParseXML(in)
+	} else {
+		jiraIssues, err = jira.ParseCSV(in)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	result, err := importJiraIssues(ctx, jiraIssues, mapping, dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+	} else {
+		if dryRun {
+			fmt.Fprintf(os.Stderr, "Dry-run mode: would create %d issues, update %d", len(result.Created), len(result.Updated))
+		} else {
+			fmt.Fprintf(os.Stderr, "Import complete: %d created, %d updated", len(result.Created), len(result.Updated))
+		}
+		if result.Unchanged > 0 {
+			fmt.Fprintf(os.Stderr, ", %d unchanged", result.Unchanged)
+		}
+		if result.Dependencies > 0 && dryRun {
+			fmt.Fprintf(os.Stderr, ", add %d dependencies", result.Dependencies)
+		} else if result.Dependencies > 0 {
+			fmt.Fprintf(os.Stderr, ", %d dependencies added", result.Dependencies)
+		}
+		if result.Comments > 0 {
+			fmt.Fprintf(os.Stderr, ", %d comments added", result.Comments)
+		}
+		fmt.Fprintf(os.Stderr, "\n")
+		if result.MissingLinks > 0 {
+			fmt.Fprintf(os.Stderr, "Skipped %d links to issues not in the export\n", result.MissingLinks)
+		}
+		printJiraUnmapped(os.Stderr, result.Unmapped)
+	}
+
+	if !dryRun {
+		markDirtyAndScheduleFlush()
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/template"
+	"unicode"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage"
@@ -23,6 +25,9 @@ var listCmd = &cobra.Command{
 		formatStr, _ := cmd.Flags().GetString("format")
 		labels, _ := cmd.Flags().GetStringSlice("label")
 		titleSearch, _ := cmd.Flags().GetString("title")
+		overdue, _ := cmd.Flags().GetBool("overdue")
+		dueBefore, _ := cmd.Flags().GetString("due-before")
+		dueAfter, _ := cmd.Flags().GetString("due-after")
 
 		filter := types.IssueFilter{
 			Limit: limit,
@@ -49,6 +54,23 @@ var listCmd = &cobra.Command{
 		if titleSearch != "" {
 			filter.TitleSearch = titleSearch
 		}
+		filter.Overdue = overdue
+		if dueBefore != "" {
+			t, err := parseDateFlag(dueBefore, false)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --due-before: %v\n", err)
+				os.Exit(1)
+			}
+			filter.DueBefore = t
+		}
+		if dueAfter != "" {
+			t, err := parseDateFlag(dueAfter, false)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --due-after: %v\n", err)
+				os.Exit(1)
+			}
+			filter.DueAfter = t
+		}
 
 		ctx := context.Background()
 		issues, err := store.SearchIssues(ctx, "", filter)
@@ -78,6 +100,9 @@ var listCmd = &cobra.Command{
 			if issue.Assignee != "" {
 				fmt.Printf("  Assignee: %s\n", issue.Assignee)
 			}
+			if issue.DueAt != nil {
+				fmt.Printf("  Due: %s\n", formatDate(*issue.DueAt))
+			}
 			fmt.Println()
 		}
 	},
@@ -90,8 +115,11 @@ func init() {
 	listCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore)")
 	listCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (comma-separated, must have ALL labels)")
 	listCmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
+	listCmd.Flags().Bool("overdue", false, "Only unclosed issues whose due date has passed")
+	listCmd.Flags().String("due-before", "", "Only issues due before this date (e.g., 2025-11-01, 1w)")
+	listCmd.Flags().String("due-after", "", "Only issues due on or after this date")
 	listCmd.Flags().IntP("limit", "n", 0, "Limit results")
-	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
+	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), 'mermaid', 'plantuml', or Go template")
 	rootCmd.AddCommand(listCmd)
 }
 
@@ -119,18 +147,7 @@ func outputDotFormat(ctx context.Context, store storage.Storage, issues []*types
 			issue.Status)
 
 		// Color by status only - keep it simple
-		fillColor := "white"
-		fontColor := "black"
-
-		switch issue.Status {
-		case "closed":
-			fillColor = "lightgray"
-			fontColor = "dimgray"
-		case "in_progress":
-			fillColor = "lightyellow"
-		case "blocked":
-			fillColor = "lightcoral"
-		}
+		fillColor, fontColor := graphStatusColors(issue.Status)
 
 		fmt.Printf("  %q [label=%q, style=\"rounded,filled\", fillcolor=%q, fontcolor=%q];\n",
 			issue.ID, label, fillColor, fontColor)
@@ -147,21 +164,7 @@ func outputDotFormat(ctx context.Context, store storage.Storage, issues []*types
 			// Only output edges where both nodes are in the filtered list
 			if issueMap[dep.DependsOnID] != nil {
 				// Color code by dependency type
-				color := "black"
-				style := "solid"
-				switch dep.Type {
-				case "blocks":
-					color = "red"
-					style = "bold"
-				case "parent-child":
-					color = "blue"
-				case "discovered-from":
-					color = "green"
-					style = "dashed"
-				case "related":
-					color = "gray"
-					style = "dashed"
-				}
+				color, style := graphEdgeStyle(dep.Type)
 				fmt.Printf("  %q -> %q [label=%q, color=%s, style=%s];\n",
 					issue.ID, dep.DependsOnID, dep.Type, color, style)
 			}
@@ -172,11 +175,153 @@ func outputDotFormat(ctx context.Context, store storage.Storage, issues []*types
 	return nil
 }
 
+// graphStatusColors returns the fill and font colors for an issue node in graph output
+func graphStatusColors(status types.Status) (fill, font string) {
+	switch status {
+	case types.StatusClosed:
+		return "lightgray", "dimgray"
+	case types.StatusInProgress:
+		return "lightyellow", "black"
+	case types.StatusBlocked:
+		return "lightcoral", "black"
+	}
+	return "white", "black"
+}
+
+// graphEdgeStyle returns the color and line style (solid, bold or dashed) for a
+// dependency edge in graph output
+func graphEdgeStyle(depType types.DependencyType) (color, style string) {
+	switch depType {
+	case types.DepBlocks:
+		return "red", "bold"
+	case types.DepParentChild:
+		return "blue", "solid"
+	case types.DepDiscoveredFrom:
+		return "green", "dashed"
+	case types.DepRelated:
+		return "gray", "dashed"
+	}
+	return "black", "solid"
+}
+
+// graphEdges returns the dependencies between issues in the list
+func graphEdges(ctx context.Context, store storage.Storage, issues []*types.Issue) []*types.Dependency {
+	inList := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		inList[issue.ID] = true
+	}
+	var edges []*types.Dependency
+	for _, issue := range issues {
+		deps, err := store.GetDependencyRecords(ctx, issue.ID)
+		if err != nil {
+			continue
+		}
+		for _, dep := range deps {
+			if inList[dep.DependsOnID] {
+				edges = append(edges, dep)
+			}
+		}
+	}
+	return edges
+}
+
+// graphNodeID turns an issue ID into an identifier Mermaid and PlantUML accept
+func graphNodeID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// outputMermaidFormat outputs issues as a Mermaid flowchart, styled like outputDotFormat
+func outputMermaidFormat(ctx context.Context, store storage.Storage, issues []*types.Issue) error {
+	// Mermaid labels are HTML; quotes would end the label
+	escape := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace
+
+	fmt.Println("flowchart TB")
+	for _, issue := range issues {
+		label := fmt.Sprintf("%s<br/>[%s P%d]<br/>%s<br/>(%s)",
+			escape(issue.ID), issue.IssueType, issue.Priority, escape(issue.Title), issue.Status)
+		fmt.Printf("  %s[\"%s\"]:::%s\n", graphNodeID(issue.ID), label, issue.Status)
+	}
+
+	edges := graphEdges(ctx, store, issues)
+	if len(edges) > 0 {
+		fmt.Println()
+	}
+	for _, dep := range edges {
+		_, style := graphEdgeStyle(dep.Type)
+		arrow := "-->"
+		switch style {
+		case "bold":
+			arrow = "==>"
+		case "dashed":
+			arrow = "-.->"
+		}
+		fmt.Printf("  %s %s|%s| %s\n", graphNodeID(dep.IssueID), arrow, dep.Type, graphNodeID(dep.DependsOnID))
+	}
+
+	fmt.Println()
+	for _, status := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusClosed} {
+		fill, font := graphStatusColors(status)
+		fmt.Printf("  classDef %s fill:%s,color:%s\n", status, fill, font)
+	}
+	for i, dep := range edges {
+		color, style := graphEdgeStyle(dep.Type)
+		width := "1px"
+		if style == "bold" {
+			width = "2px"
+		}
+		fmt.Printf("  linkStyle %d stroke:%s,stroke-width:%s\n", i, color, width)
+	}
+	return nil
+}
+
+// outputPlantUMLFormat outputs issues as a PlantUML diagram, styled like outputDotFormat
+func outputPlantUMLFormat(ctx context.Context, store storage.Storage, issues []*types.Issue) error {
+	// PlantUML strings can't escape double quotes
+	escape := strings.NewReplacer(`"`, "'", `\`, `\\`).Replace
+
+	fmt.Println("@startuml")
+	fmt.Println("skinparam rectangle {")
+	fmt.Println("  RoundCorner 15")
+	fmt.Println("}")
+	fmt.Println()
+	for _, issue := range issues {
+		label := fmt.Sprintf("%s\\n[%s P%d]\\n%s\\n(%s)",
+			escape(issue.ID), issue.IssueType, issue.Priority, escape(issue.Title), issue.Status)
+		fill, font := graphStatusColors(issue.Status)
+		fmt.Printf("rectangle \"%s\" as %s #%s;text:%s\n", label, graphNodeID(issue.ID), fill, font)
+	}
+
+	edges := graphEdges(ctx, store, issues)
+	if len(edges) > 0 {
+		fmt.Println()
+	}
+	for _, dep := range edges {
+		color, style := graphEdgeStyle(dep.Type)
+		arrowStyle := "#" + color
+		if style != "solid" {
+			arrowStyle += "," + style
+		}
+		fmt.Printf("%s -[%s]-> %s : %s\n", graphNodeID(dep.IssueID), arrowStyle, graphNodeID(dep.DependsOnID), dep.Type)
+	}
+	fmt.Println("@enduml")
+	return nil
+}
+
 // outputFormattedList outputs issues in a custom format (preset or Go template)
 func outputFormattedList(ctx context.Context, store storage.Storage, issues []*types.Issue, formatStr string) error {
-	// Handle special 'dot' format (Graphviz output)
-	if formatStr == "dot" {
+	// Handle graph formats
+	switch formatStr {
+	case "dot":
 		return outputDotFormat(ctx, store, issues)
+	case "mermaid":
+		return outputMermaidFormat(ctx, store, issues)
+	case "plantuml":
+		return outputPlantUMLFormat(ctx, store, issues)
 	}
 
 	// Built-in format presets
@@ -367,6 +367,9 @@ func autoImportIfNewer() {
 			if issue.ExternalRef != nil {
 				updates["external_ref"] = *issue.ExternalRef
 			}
+			updates["due_at"] = issue.DueAt
+			updates["defer_until"] = issue.DeferUntil
+			updates["recurrence"] = issue.Recurrence
 
 			// Enforce status/closed_at invariant (bd-226)
 			if issue.Status == "closed" {
@@ -426,6 +429,14 @@ func autoImportIfNewer() {
 		}
 	}
 
+	// Import work logs (already-recorded entries are skipped)
+	for _, issue := range allIssues {
+		for _, entry := range issue.WorkLogs {
+			entry.IssueID = issue.ID
+			_ = store.AddWorkLog(ctx, entry, "auto-import")
+		}
+	}
+
 	// Store new hash after successful import
 	_ = store.SetMetadata(ctx, "last_import_hash", currentHash)
 }
@@ -632,6 +643,14 @@ func flushToJSONL() {
 		}
 		issue.Dependencies = deps
 
+		// Get work logs for this issue
+		workLogs, err := store.GetWorkLogs(ctx, issueID)
+		if err != nil {
+			recordFailure(fmt.Errorf("failed to get work logs for %s: %w", issueID, err))
+			return
+		}
+		issue.WorkLogs = workLogs
+
 		// Update map
 		issueMap[issueID] = issue
 	}
@@ -709,10 +728,12 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&noAutoImport, "no-auto-import", false, "Disable automatic JSONL import when newer than DB")
 }
 
-// createIssuesFromMarkdown parses a markdown file and creates multiple issues
+// createIssuesFromMarkdown parses a markdown file (or a directory of them) and creates
+// multiple issues. Issues whose ### ID already exists, as in files written by
+// 'bd export --format markdown', are updated with the fields the file sets instead.
 func createIssuesFromMarkdown(cmd *cobra.Command, filepath string) {
 	// Parse markdown file
-	templates, err := parseMarkdownFile(filepath)
+	templates, err := parseMarkdownPath(filepath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing markdown file: %v\n", err)
 		os.Exit(1)
@@ -725,19 +746,71 @@ func createIssuesFromMarkdown(cmd *cobra.Command, filepath string) {
 
 	ctx := context.Background()
 	createdIssues := []*types.Issue{}
+	updatedIssues := []*types.Issue{}
 	failedIssues := []string{}
+	unchanged := 0
+
+	// Issues written so far, for the dependency pass
+	type markdownIssue struct {
+		issue    *types.Issue
+		template *IssueTemplate
+		existed  bool
+		changed  bool
+	}
+	var written []*markdownIssue
 
-	// Create each issue
+	// Create or update each issue
 	for _, template := range templates {
+		var existing *types.Issue
+		if template.ID != "" {
+			existing, err = store.GetIssue(ctx, template.ID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error checking issue %s: %v\n", template.ID, err)
+				failedIssues = append(failedIssues, template.Title)
+				continue
+			}
+		}
+
+		if existing != nil {
+			updates := markdownUpdates(existing, template)
+			if len(updates) > 0 {
+				if err := store.UpdateIssue(ctx, existing.ID, updates, actor); err != nil {
+					fmt.Fprintf(os.Stderr, "Error updating issue %s: %v\n", existing.ID, err)
+					failedIssues = append(failedIssues, template.Title)
+					continue
+				}
+			}
+			changed := len(updates) > 0
+			if template.Fields["labels"] && syncIssueLabels(ctx, existing.ID, template.Labels) {
+				changed = true
+			}
+			written = append(written, &markdownIssue{issue: existing, template: template, existed: true, changed: changed})
+			continue
+		}
+
 		issue := &types.Issue{
+			ID:                 template.ID, // Empty unless the file names one
 			Title:              template.Title,
 			Description:        template.Description,
 			Design:             template.Design,
 			AcceptanceCriteria: template.AcceptanceCriteria,
+			Notes:              template.Notes,
 			Status:             types.StatusOpen,
 			Priority:           template.Priority,
 			IssueType:          template.IssueType,
 			Assignee:           template.Assignee,
+			EstimatedMinutes:   template.EstimatedMinutes,
+		}
+		if template.Status != "" {
+			issue.Status = template.Status
+		}
+		if issue.Status == types.StatusClosed {
+			now := time.Now()
+			issue.ClosedAt = &now
+		}
+		if template.ExternalRef != "" {
+			externalRef := template.ExternalRef
+			issue.ExternalRef = &externalRef
 		}
 
 		if err := store.CreateIssue(ctx, issue, actor); err != nil {
@@ -753,50 +826,36 @@ func createIssuesFromMarkdown(cmd *cobra.Command, filepath string) {
 			}
 		}
 
-		// Add dependencies
-		for _, depSpec := range template.Dependencies {
-			depSpec = strings.TrimSpace(depSpec)
-			if depSpec == "" {
-				continue
-			}
-
-			var depType types.DependencyType
-			var dependsOnID string
-
-			// Parse format: "type:id" or just "id" (defaults to "blocks")
-			if strings.Contains(depSpec, ":") {
-				parts := strings.SplitN(depSpec, ":", 2)
-				if len(parts) != 2 {
-					fmt.Fprintf(os.Stderr, "Warning: invalid dependency format '%s' for %s\n", depSpec, issue.ID)
-					continue
-				}
-				depType = types.DependencyType(strings.TrimSpace(parts[0]))
-				dependsOnID = strings.TrimSpace(parts[1])
-			} else {
-				depType = types.DepBlocks
-				dependsOnID = depSpec
-			}
+		written = append(written, &markdownIssue{issue: issue, template: template})
+		createdIssues = append(createdIssues, issue)
+	}
 
-			if !depType.IsValid() {
-				fmt.Fprintf(os.Stderr, "Warning: invalid dependency type '%s' for %s\n", depType, issue.ID)
-				continue
-			}
+	// Add dependencies once every issue exists, so a file can refer to issues it
+	// defines further down. Existing issues only sync dependencies the file lists.
+	for _, w := range written {
+		if w.existed && !w.template.Fields["dependencies"] {
+			continue
+		}
+		if syncMarkdownDependencies(ctx, w.issue.ID, w.template.Dependencies, w.existed) {
+			w.changed = true
+		}
+	}
 
-			dep := &types.Dependency{
-				IssueID:     issue.ID,
-				DependsOnID: dependsOnID,
-				Type:        depType,
-			}
-			if err := store.AddDependency(ctx, dep, actor); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to add dependency %s -> %s: %v\n", issue.ID, dependsOnID, err)
+	for _, w := range written {
+		switch {
+		case !w.existed:
+		case w.changed:
+			if issue, err := store.GetIssue(ctx, w.issue.ID); err == nil && issue != nil {
+				w.issue = issue
 			}
+			updatedIssues = append(updatedIssues, w.issue)
+		default:
+			unchanged++
 		}
-
-		createdIssues = append(createdIssues, issue)
 	}
 
 	// Schedule auto-flush
-	if len(createdIssues) > 0 {
+	if len(createdIssues) > 0 || len(updatedIssues) > 0 {
 		markDirtyAndScheduleFlush()
 	}
 
@@ -810,22 +869,189 @@ func createIssuesFromMarkdown(cmd *cobra.Command, filepath string) {
 	}
 
 	if jsonOutput {
-		outputJSON(createdIssues)
+		outputJSON(append(createdIssues, updatedIssues...))
 	} else {
 		green := color.New(color.FgGreen).SprintFunc()
 		fmt.Printf("%s Created %d issues from %s:\n", green("✓"), len(createdIssues), filepath)
 		for _, issue := range createdIssues {
 			fmt.Printf("  %s: %s [P%d, %s]\n", issue.ID, issue.Title, issue.Priority, issue.IssueType)
 		}
+		if len(updatedIssues) > 0 {
+			fmt.Printf("%s Updated %d issues:\n", green("✓"), len(updatedIssues))
+			for _, issue := range updatedIssues {
+				fmt.Printf("  %s: %s [P%d, %s]\n", issue.ID, issue.Title, issue.Priority, issue.IssueType)
+			}
+		}
+		if unchanged > 0 {
+			fmt.Printf("  %d unchanged\n", unchanged)
+		}
+	}
+}
+
+// markdownUpdates returns the fields a parsed markdown issue changes on an existing issue
+func markdownUpdates(existing *types.Issue, template *IssueTemplate) map[string]interface{} {
+	updates := make(map[string]interface{})
+	setString := func(field, old, value string) {
+		if template.Fields[field] && old != value {
+			updates[field] = value
+		}
+	}
+
+	if template.Title != existing.Title {
+		updates["title"] = template.Title
+	}
+	setString("description", existing.Description, template.Description)
+	setString("design", existing.Design, template.Design)
+	setString("acceptance_criteria", existing.AcceptanceCriteria, template.AcceptanceCriteria)
+	setString("notes", existing.Notes, template.Notes)
+	setString("assignee", existing.Assignee, template.Assignee)
+	setString("status", string(existing.Status), string(template.Status))
+	setString("issue_type", string(existing.IssueType), string(template.IssueType))
+	if template.Fields["priority"] && template.Priority != existing.Priority {
+		updates["priority"] = template.Priority
+	}
+	if template.Fields["estimated_minutes"] && template.EstimatedMinutes != nil &&
+		(existing.EstimatedMinutes == nil || *existing.EstimatedMinutes != *template.EstimatedMinutes) {
+		updates["estimated_minutes"] = *template.EstimatedMinutes
+	}
+	if template.Fields["external_ref"] {
+		old := ""
+		if existing.ExternalRef != nil {
+			old = *existing.ExternalRef
+		}
+		if old != template.ExternalRef {
+			if template.ExternalRef == "" {
+				updates["external_ref"] = nil
+			} else {
+				updates["external_ref"] = template.ExternalRef
+			}
+		}
+	}
+	return updates
+}
+
+// syncIssueLabels makes an issue's labels match the given set.
+// Returns whether anything changed.
+func syncIssueLabels(ctx context.Context, issueID string, labels []string) bool {
+	current, err := store.GetLabels(ctx, issueID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to get labels for %s: %v\n", issueID, err)
+		return false
+	}
+	currentSet := make(map[string]bool, len(current))
+	for _, label := range current {
+		currentSet[label] = true
+	}
+	wanted := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		wanted[label] = true
+	}
+
+	changed := false
+	for _, label := range labels {
+		if !currentSet[label] {
+			if err := store.AddLabel(ctx, issueID, label, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to add label %s to %s: %v\n", label, issueID, err)
+				continue
+			}
+			changed = true
+		}
+	}
+	for _, label := range current {
+		if !wanted[label] {
+			if err := store.RemoveLabel(ctx, issueID, label, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to remove label %s from %s: %v\n", label, issueID, err)
+				continue
+			}
+			changed = true
+		}
+	}
+	return changed
+}
+
+// syncMarkdownDependencies adds the dependencies listed in a markdown ### Dependencies
+// section ("id" for blocks, or "type:id"), and with removeMissing drops the issue's
+// dependencies the section no longer lists. Returns whether anything changed.
+func syncMarkdownDependencies(ctx context.Context, issueID string, specs []string, removeMissing bool) bool {
+	current, err := store.GetDependencyRecords(ctx, issueID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to get dependencies for %s: %v\n", issueID, err)
+		return false
+	}
+	currentSet := make(map[string]bool, len(current))
+	for _, dep := range current {
+		currentSet[string(dep.Type)+":"+dep.DependsOnID] = true
+	}
+
+	changed := false
+	wanted := make(map[string]bool, len(specs))
+	for _, depSpec := range specs {
+		depSpec = strings.TrimSpace(depSpec)
+		if depSpec == "" {
+			continue
+		}
+
+		// Parse format: "type:id" or just "id" (defaults to "blocks")
+		depType := types.DepBlocks
+		dependsOnID := depSpec
+		if t, id, ok := strings.Cut(depSpec, ":"); ok {
+			depType = types.DependencyType(strings.TrimSpace(t))
+			dependsOnID = strings.TrimSpace(id)
+		}
+		if !depType.IsValid() {
+			fmt.Fprintf(os.Stderr, "Warning: invalid dependency type '%s' for %s\n", depType, issueID)
+			continue
+		}
+
+		key := string(depType) + ":" + dependsOnID
+		wanted[key] = true
+		if currentSet[key] {
+			continue
+		}
+		dep := &types.Dependency{
+			IssueID:     issueID,
+			DependsOnID: dependsOnID,
+			Type:        depType,
+		}
+		if err := store.AddDependency(ctx, dep, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to add dependency %s -> %s: %v\n", issueID, dependsOnID, err)
+			continue
+		}
+		changed = true
+	}
+
+	if removeMissing {
+		for _, dep := range current {
+			if wanted[string(dep.Type)+":"+dep.DependsOnID] {
+				continue
+			}
+			if err := store.RemoveDependency(ctx, issueID, dep.DependsOnID, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to remove dependency %s -> %s: %v\n", issueID, dep.DependsOnID, err)
+				continue
+			}
+			changed = true
+		}
 	}
+	return changed
 }
 
 var createCmd = &cobra.Command{
 	Use:   "create [title]",
-	Short: "Create a new issue (or multiple issues from markdown file)",
+	Short: "Create a new issue (or multiple issues from markdown file or template)",
 	Args:  cobra.MinimumNArgs(0), // Changed to allow no args when using -f
 	Run: func(cmd *cobra.Command, args []string) {
 		file, _ := cmd.Flags().GetString("file")
+		templateName, _ := cmd.Flags().GetString("template")
+
+		// If template flag is provided, instantiate the template's issue tree
+		if templateName != "" {
+			if len(args) > 0 || file != "" {
+				fmt.Fprintf(os.Stderr, "Error: --template cannot be combined with a title or --file\n")
+				os.Exit(1)
+			}
+			createIssuesFromTemplate(cmd, templateName)
+			return
+		}
 
 		// If file flag is provided, parse markdown and create multiple issues
 		if file != "" {
@@ -839,7 +1065,7 @@ var createCmd = &cobra.Command{
 
 		// Original single-issue creation logic
 		if len(args) == 0 {
-			fmt.Fprintf(os.Stderr, "Error: title required (or use --file to create from markdown)\n")
+			fmt.Fprintf(os.Stderr, "Error: title required (or use --file to create from markdown, --template to create from a template)\n")
 			os.Exit(1)
 		}
 
@@ -855,6 +1081,35 @@ var createCmd = &cobra.Command{
 		externalRef, _ := cmd.Flags().GetString("external-ref")
 		deps, _ := cmd.Flags().GetStringSlice("deps")
 
+		var estimatedMinutes *int
+		if cmd.Flags().Changed("estimate") {
+			estimate, _ := cmd.Flags().GetInt("estimate")
+			estimatedMinutes = &estimate
+		}
+
+		dueStr, _ := cmd.Flags().GetString("due")
+		dueAt, err := parseDateFlag(dueStr, true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --due: %v\n", err)
+			os.Exit(1)
+		}
+		deferStr, _ := cmd.Flags().GetString("defer")
+		deferUntil, err := parseDateFlag(deferStr, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --defer: %v\n", err)
+			os.Exit(1)
+		}
+		recurrence, _ := cmd.Flags().GetString("recur")
+		if recurrence != "" && dueAt == nil {
+			// The first occurrence is the next one from now
+			first, err := firstOccurrence(recurrence)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --recur: %v\n", err)
+				os.Exit(1)
+			}
+			dueAt = &first
+		}
+
 		// Validate explicit ID format if provided (prefix-number)
 		if explicitID != "" {
 			// Check format: must contain hyphen and have numeric suffix
@@ -885,7 +1140,11 @@ var createCmd = &cobra.Command{
 			Priority:           priority,
 			IssueType:          types.IssueType(issueType),
 			Assignee:           assignee,
+			EstimatedMinutes:   estimatedMinutes,
 			ExternalRef:        externalRefPtr,
+			DueAt:              dueAt,
+			DeferUntil:         deferUntil,
+			Recurrence:         recurrence,
 		}
 
 		ctx := context.Background()
@@ -961,15 +1220,21 @@ var createCmd = &cobra.Command{
 
 func init() {
 	createCmd.Flags().StringP("file", "f", "", "Create multiple issues from markdown file")
+	createCmd.Flags().String("template", "", "Create an issue tree from .beads/templates/<name>.json")
+	createCmd.Flags().StringArray("var", nil, "Template variable as name=value (repeatable)")
 	createCmd.Flags().StringP("description", "d", "", "Issue description")
 	createCmd.Flags().String("design", "", "Design notes")
 	createCmd.Flags().String("acceptance", "", "Acceptance criteria")
 	createCmd.Flags().IntP("priority", "p", 2, "Priority (0-4, 0=highest)")
 	createCmd.Flags().StringP("type", "t", "task", "Issue type (bug|feature|task|epic|chore)")
 	createCmd.Flags().StringP("assignee", "a", "", "Assignee")
+	createCmd.Flags().IntP("estimate", "e", 0, "Estimated effort in minutes")
 	createCmd.Flags().StringSliceP("labels", "l", []string{}, "Labels (comma-separated)")
 	createCmd.Flags().String("id", "", "Explicit issue ID (e.g., 'bd-42' for partitioning)")
 	createCmd.Flags().String("external-ref", "", "External reference (e.g., 'gh-9', 'jira-ABC')")
+	createCmd.Flags().String("due", "", "Due date (e.g., 2025-11-01, tomorrow, 3d)")
+	createCmd.Flags().String("defer", "", "Hide from ready work until this date (e.g., 2025-11-01, 1w)")
+	createCmd.Flags().String("recur", "", "Repeat on a schedule (daily, weekly, monthly, \"every 2w\", or cron \"0 9 * * 1\")")
 	createCmd.Flags().StringSlice("deps", []string{}, "Dependencies in format 'type:id' or 'id' (e.g., 'discovered-from:bd-20,blocks:bd-15' or 'bd-20')")
 	rootCmd.AddCommand(createCmd)
 }
@@ -997,11 +1262,16 @@ var showCmd = &cobra.Command{
 				Labels      []string       `json:"labels,omitempty"`
 				Dependencies []*types.Issue `json:"dependencies,omitempty"`
 				Dependents   []*types.Issue `json:"dependents,omitempty"`
+				EpicStatus   *types.EpicStatus `json:"epic_status,omitempty"`
 			}
+			issue.WorkLogs, _ = store.GetWorkLogs(ctx, issue.ID)
 			details := &IssueDetails{Issue: issue}
 			details.Labels, _ = store.GetLabels(ctx, issue.ID)
 			details.Dependencies, _ = store.GetDependencies(ctx, issue.ID)
 			details.Dependents, _ = store.GetDependents(ctx, issue.ID)
+			if issue.IssueType == types.TypeEpic {
+				details.EpicStatus, _ = store.GetEpicStatus(ctx, issue.ID)
+			}
 			outputJSON(details)
 			return
 		}
@@ -1030,6 +1300,31 @@ var showCmd = &cobra.Command{
 		if issue.EstimatedMinutes != nil {
 			fmt.Printf("Estimated: %d minutes\n", *issue.EstimatedMinutes)
 		}
+		if workLogs, _ := store.GetWorkLogs(ctx, issue.ID); len(workLogs) > 0 {
+			logged, running := loggedMinutes(workLogs)
+			if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+				fmt.Printf("Logged: %s of %s estimated (%.0f%%)\n", formatMinutes(logged),
+					formatMinutes(*issue.EstimatedMinutes), float64(logged)/float64(*issue.EstimatedMinutes)*100)
+			} else {
+				fmt.Printf("Logged: %s\n", formatMinutes(logged))
+			}
+			if running != nil {
+				fmt.Printf("Timer running: %s since %s\n", running.Actor, running.StartedAt.Local().Format("2006-01-02 15:04"))
+			}
+		}
+		if issue.DueAt != nil {
+			overdue := ""
+			if issue.Status != types.StatusClosed && issue.DueAt.Before(time.Now()) {
+				overdue = color.New(color.FgRed).Sprint(" (overdue)")
+			}
+			fmt.Printf("Due: %s%s\n", formatDate(*issue.DueAt), overdue)
+		}
+		if issue.DeferUntil != nil && issue.DeferUntil.After(time.Now()) {
+			fmt.Printf("Deferred until: %s\n", formatDate(*issue.DeferUntil))
+		}
+		if issue.Recurrence != "" {
+			fmt.Printf("Repeats: %s\n", issue.Recurrence)
+		}
 		fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04"))
 		fmt.Printf("Updated: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04"))
 
@@ -1071,6 +1366,14 @@ var showCmd = &cobra.Command{
 			fmt.Printf("\nAcceptance Criteria:\n%s\n", issue.AcceptanceCriteria)
 		}
 
+		// Show progress rollup for epics
+		if issue.IssueType == types.TypeEpic {
+			if epicStatus, err := store.GetEpicStatus(ctx, issue.ID); err == nil {
+				fmt.Printf("\nEpic progress:\n")
+				printEpicStatus(epicStatus)
+			}
+		}
+
 		// Show labels
 		labels, _ := store.GetLabels(ctx, issue.ID)
 		if len(labels) > 0 {
@@ -1126,6 +1429,10 @@ var updateCmd = &cobra.Command{
 			assignee, _ := cmd.Flags().GetString("assignee")
 			updates["assignee"] = assignee
 		}
+		if cmd.Flags().Changed("estimate") {
+			estimate, _ := cmd.Flags().GetInt("estimate")
+			updates["estimated_minutes"] = estimate
+		}
 		if cmd.Flags().Changed("design") {
 			design, _ := cmd.Flags().GetString("design")
 			updates["design"] = design
@@ -1142,6 +1449,28 @@ var updateCmd = &cobra.Command{
 			externalRef, _ := cmd.Flags().GetString("external-ref")
 			updates["external_ref"] = externalRef
 		}
+		if cmd.Flags().Changed("due") {
+			dueStr, _ := cmd.Flags().GetString("due")
+			dueAt, err := parseDateFlag(dueStr, true)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --due: %v\n", err)
+				os.Exit(1)
+			}
+			updates["due_at"] = dueAt
+		}
+		if cmd.Flags().Changed("defer") {
+			deferStr, _ := cmd.Flags().GetString("defer")
+			deferUntil, err := parseDateFlag(deferStr, false)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --defer: %v\n", err)
+				os.Exit(1)
+			}
+			updates["defer_until"] = deferUntil
+		}
+		if cmd.Flags().Changed("recur") {
+			recurrence, _ := cmd.Flags().GetString("recur")
+			updates["recurrence"] = recurrence
+		}
 
 		if len(updates) == 0 {
 			fmt.Println("No updates specified")
@@ -1149,11 +1478,28 @@ var updateCmd = &cobra.Command{
 		}
 
 		ctx := context.Background()
+		if recurrence, ok := updates["recurrence"].(string); ok && recurrence != "" {
+			// A newly recurring issue without a due date gets its first occurrence
+			if _, hasDue := updates["due_at"]; !hasDue {
+				issue, err := store.GetIssue(ctx, args[0])
+				if err == nil && issue != nil && issue.DueAt == nil {
+					if first, err := firstOccurrence(recurrence); err == nil {
+						updates["due_at"] = &first
+					}
+				}
+			}
+		}
 		if err := store.UpdateIssue(ctx, args[0], updates, actor); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
+		// Closing a recurring issue spawns its next occurrence
+		var spawned *types.Issue
+		if updates["status"] == string(types.StatusClosed) {
+			spawned = spawnNextOccurrence(ctx, args[0])
+		}
+
 		// Schedule auto-flush
 		markDirtyAndScheduleFlush()
 
@@ -1164,6 +1510,7 @@ var updateCmd = &cobra.Command{
 		} else {
 			green := color.New(color.FgGreen).SprintFunc()
 			fmt.Printf("%s Updated issue: %s\n", green("✓"), args[0])
+			printSpawnedOccurrence(spawned)
 		}
 	},
 }
@@ -1173,10 +1520,14 @@ func init() {
 	updateCmd.Flags().IntP("priority", "p", 0, "New priority")
 	updateCmd.Flags().String("title", "", "New title")
 	updateCmd.Flags().StringP("assignee", "a", "", "New assignee")
+	updateCmd.Flags().IntP("estimate", "e", 0, "Estimated effort in minutes")
 	updateCmd.Flags().String("design", "", "Design notes")
 	updateCmd.Flags().String("notes", "", "Additional notes")
 	updateCmd.Flags().String("acceptance-criteria", "", "Acceptance criteria")
 	updateCmd.Flags().String("external-ref", "", "External reference (e.g., 'gh-9', 'jira-ABC')")
+	updateCmd.Flags().String("due", "", "Due date (e.g., 2025-11-01, tomorrow, 3d; \"\" clears)")
+	updateCmd.Flags().String("defer", "", "Hide from ready work until this date (\"\" clears)")
+	updateCmd.Flags().String("recur", "", "Repeat on a schedule (daily, weekly, \"every 2w\", cron; \"\" stops repeating)")
 	rootCmd.AddCommand(updateCmd)
 }
 
@@ -1197,6 +1548,8 @@ var closeCmd = &cobra.Command{
 				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
 				continue
 			}
+			// Closing a recurring issue spawns its next occurrence
+			spawned := spawnNextOccurrence(ctx, id)
 			if jsonOutput {
 				issue, _ := store.GetIssue(ctx, id)
 				if issue != nil {
@@ -1205,6 +1558,7 @@ var closeCmd = &cobra.Command{
 			} else {
 				green := color.New(color.FgGreen).SprintFunc()
 				fmt.Printf("%s Closed %s: %s\n", green("✓"), id, reason)
+				printSpawnedOccurrence(spawned)
 			}
 		}
 
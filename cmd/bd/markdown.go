@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -25,15 +26,32 @@ var (
 
 // IssueTemplate represents a parsed issue from markdown
 type IssueTemplate struct {
+	ID                 string // Set when the file was written by 'bd export --format markdown'
 	Title              string
 	Description        string
 	Design             string
 	AcceptanceCriteria string
+	Notes              string
+	Status             types.Status
 	Priority           int
 	IssueType          types.IssueType
 	Assignee           string
+	EstimatedMinutes   *int
+	ExternalRef        string
 	Labels             []string
 	Dependencies       []string
+
+	// Fields records which fields the file sets (by storage field name), so that
+	// re-importing an existing issue only updates what the file describes
+	Fields map[string]bool
+}
+
+// setField records that the file sets a field
+func (t *IssueTemplate) setField(field string) {
+	if t.Fields == nil {
+		t.Fields = make(map[string]bool)
+	}
+	t.Fields[field] = true
 }
 
 // parsePriority extracts and validates a priority value from content.
@@ -70,6 +88,27 @@ func parseIssueType(content, issueTitle string) types.IssueType {
 	return issueType
 }
 
+// parseStatus extracts and validates an issue status from content.
+// Returns the status or empty string if invalid.
+func parseStatus(content, issueTitle string) types.Status {
+	status := types.Status(strings.TrimSpace(content))
+	if !status.IsValid() {
+		fmt.Fprintf(os.Stderr, "Warning: invalid status '%s' in '%s', ignoring\n", status, issueTitle)
+		return ""
+	}
+	return status
+}
+
+// parseEstimate extracts an estimate in minutes from content.
+// Returns nil if invalid.
+func parseEstimate(content string) *int {
+	var minutes int
+	if _, err := fmt.Sscanf(content, "%d", &minutes); err == nil && minutes >= 0 {
+		return &minutes
+	}
+	return nil
+}
+
 // parseStringList extracts a list of strings from content, splitting by comma or whitespace.
 // This is a generic helper used by parseLabels and parseDependencies.
 func parseStringList(content string) []string {
@@ -97,31 +136,62 @@ func parseDependencies(content string) []string {
 }
 
 // processIssueSection processes a parsed section and updates the issue template.
+// Text, label and dependency sections left empty clear the field on re-import.
 func processIssueSection(issue *IssueTemplate, section, content string) {
 	content = strings.TrimSpace(content)
-	if content == "" {
-		return
-	}
 
 	switch strings.ToLower(section) {
-	case "priority":
-		if p := parsePriority(content); p != -1 {
-			issue.Priority = p
-		}
-	case "type":
-		issue.IssueType = parseIssueType(content, issue.Title)
 	case "description":
 		issue.Description = content
+		issue.setField("description")
 	case "design":
 		issue.Design = content
+		issue.setField("design")
 	case "acceptance criteria", "acceptance":
 		issue.AcceptanceCriteria = content
+		issue.setField("acceptance_criteria")
+	case "notes":
+		issue.Notes = content
+		issue.setField("notes")
 	case "assignee":
-		issue.Assignee = strings.TrimSpace(content)
+		issue.Assignee = content
+		issue.setField("assignee")
+	case "external ref", "external_ref":
+		issue.ExternalRef = content
+		issue.setField("external_ref")
 	case "labels":
 		issue.Labels = parseLabels(content)
+		issue.setField("labels")
 	case "dependencies", "deps":
 		issue.Dependencies = parseDependencies(content)
+		issue.setField("dependencies")
+	}
+
+	if content == "" {
+		return
+	}
+
+	switch strings.ToLower(section) {
+	case "id":
+		issue.ID = content
+	case "status":
+		if s := parseStatus(content, issue.Title); s != "" {
+			issue.Status = s
+			issue.setField("status")
+		}
+	case "priority":
+		if p := parsePriority(content); p != -1 {
+			issue.Priority = p
+			issue.setField("priority")
+		}
+	case "type":
+		issue.IssueType = parseIssueType(content, issue.Title)
+		issue.setField("issue_type")
+	case "estimate":
+		if e := parseEstimate(content); e != nil {
+			issue.EstimatedMinutes = e
+			issue.setField("estimated_minutes")
+		}
 	}
 }
 
@@ -183,6 +253,10 @@ func validateMarkdownPath(path string) (string, error) {
 //
 //   ### Dependencies
 //   bd-10, bd-20
+//
+// Files written by 'bd export --format markdown' also carry ### ID, ### Status,
+// ### Estimate, ### External Ref and ### Notes sections. Content lines that would
+// read as headers are escaped with a leading backslash.
 // markdownParseState holds state for parsing markdown files
 type markdownParseState struct {
 	issues         []*IssueTemplate
@@ -235,6 +309,11 @@ func (s *markdownParseState) handleContentLine(line string) {
 		return
 	}
 
+	// Unescape content lines that would otherwise read as headers
+	if strings.HasPrefix(line, `\##`) {
+		line = line[1:]
+	}
+
 	// Content within a section
 	if s.currentSection != "" {
 		if s.sectionContent.Len() > 0 {
@@ -250,6 +329,7 @@ func (s *markdownParseState) handleContentLine(line string) {
 			s.currentIssue.Description += "\n"
 		}
 		s.currentIssue.Description += line
+		s.currentIssue.setField("description")
 	}
 }
 
@@ -323,3 +403,31 @@ func parseMarkdownFile(path string) ([]*IssueTemplate, error) {
 
 	return state.finalize()
 }
+
+// parseMarkdownPath parses a markdown file, or every .md file in a directory such
+// as one written by 'bd export --format markdown -o dir/'
+func parseMarkdownPath(path string) ([]*IssueTemplate, error) {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return parseMarkdownFile(path)
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .md files found in %s", path)
+	}
+	sort.Strings(files)
+
+	var issues []*IssueTemplate
+	for _, file := range files {
+		parsed, err := parseMarkdownFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		issues = append(issues, parsed...)
+	}
+	return issues, nil
+}
@@ -0,0 +1,120 @@
+// This file implements markdown export, the inverse of the parser in markdown.go.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// writeMarkdownIssue writes one issue in the structure parseMarkdownFile reads, so
+// the output can be edited and re-imported with 'bd create -f'
+func writeMarkdownIssue(w io.Writer, issue *types.Issue) error {
+	bw := bufio.NewWriter(w)
+
+	section := func(name, content string) {
+		if content == "" {
+			return
+		}
+		fmt.Fprintf(bw, "### %s\n%s\n\n", name, escapeMarkdownContent(content))
+	}
+
+	fmt.Fprintf(bw, "## %s\n\n", issue.Title)
+	section("ID", issue.ID)
+	section("Status", string(issue.Status))
+	section("Priority", fmt.Sprintf("%d", issue.Priority))
+	section("Type", string(issue.IssueType))
+	section("Assignee", issue.Assignee)
+	if issue.EstimatedMinutes != nil {
+		section("Estimate", fmt.Sprintf("%d", *issue.EstimatedMinutes))
+	}
+	if issue.ExternalRef != nil {
+		section("External Ref", *issue.ExternalRef)
+	}
+	section("Labels", strings.Join(issue.Labels, ", "))
+	section("Dependencies", strings.Join(formatMarkdownDependencies(issue.Dependencies), ", "))
+	section("Description", issue.Description)
+	section("Design", issue.Design)
+	section("Acceptance Criteria", issue.AcceptanceCriteria)
+	section("Notes", issue.Notes)
+
+	return bw.Flush()
+}
+
+// formatMarkdownDependencies renders dependencies as the ### Dependencies section
+// lists them: blocks as a bare ID, other types as "type:id"
+func formatMarkdownDependencies(deps []*types.Dependency) []string {
+	specs := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		if dep.Type == types.DepBlocks {
+			specs = append(specs, dep.DependsOnID)
+		} else {
+			specs = append(specs, fmt.Sprintf("%s:%s", dep.Type, dep.DependsOnID))
+		}
+	}
+	sort.Strings(specs)
+	return specs
+}
+
+// escapeMarkdownContent escapes lines that would read as issue or section headers
+func escapeMarkdownContent(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "##") {
+			lines[i] = `\` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// exportMarkdown writes issues to stdout, a single markdown file, or one <id>.md
+// file per issue when output is a directory (an existing one, or a path ending in a
+// separator)
+func exportMarkdown(issues []*types.Issue, output string) error {
+	if output == "" {
+		for _, issue := range issues {
+			if err := writeMarkdownIssue(os.Stdout, issue); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := validateExportPath(output); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(output)
+	isDir := (err == nil && info.IsDir()) || strings.HasSuffix(output, string(filepath.Separator)) || strings.HasSuffix(output, "/")
+	if isDir {
+		if err := os.MkdirAll(output, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		for _, issue := range issues {
+			if err := writeMarkdownFile(filepath.Join(output, issue.ID+".md"), []*types.Issue{issue}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return writeMarkdownFile(output, issues)
+}
+
+// writeMarkdownFile atomically replaces path with the markdown for issues
+func writeMarkdownFile(path string, issues []*types.Issue) error {
+	return writeFileAtomic(path, func(w io.Writer) error {
+		for _, issue := range issues {
+			if err := writeMarkdownIssue(w, issue); err != nil {
+				return fmt.Errorf("failed to write %s: %w", issue.ID, err)
+			}
+		}
+		return nil
+	})
+}
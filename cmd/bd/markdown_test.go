@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 func TestParseMarkdownFile(t *testing.T) {
@@ -236,3 +238,98 @@ func stringSlicesEqual(a, b []string) bool {
 	}
 	return true
 }
+
+func TestMarkdownExportRoundTrip(t *testing.T) {
+	estimate := 90
+	ref := "gh-12"
+	issue := &types.Issue{
+		ID:                 "bd-7",
+		Title:              "Round trip",
+		Description:        "First line\n\n## Not a header\n### Nor this",
+		AcceptanceCriteria: "- works",
+		Notes:              "See thread",
+		Status:             types.StatusInProgress,
+		Priority:           1,
+		IssueType:          types.TypeFeature,
+		Assignee:           "alice",
+		EstimatedMinutes:   &estimate,
+		ExternalRef:        &ref,
+		Labels:             []string{"backend", "ux"},
+		Dependencies: []*types.Dependency{
+			{IssueID: "bd-7", DependsOnID: "bd-3", Type: types.DepBlocks},
+			{IssueID: "bd-7", DependsOnID: "bd-1", Type: types.DepParentChild},
+		},
+	}
+
+	tmpFile := filepath.Join(t.TempDir(), "issues.md")
+	if err := writeMarkdownFile(tmpFile, []*types.Issue{issue}); err != nil {
+		t.Fatalf("writeMarkdownFile failed: %v", err)
+	}
+	got, err := parseMarkdownFile(tmpFile)
+	if err != nil {
+		t.Fatalf("parseMarkdownFile failed: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(got))
+	}
+	tmpl := got[0]
+
+	if tmpl.ID != issue.ID || tmpl.Title != issue.Title || tmpl.Status != issue.Status {
+		t.Errorf("ID/title/status not preserved: %+v", tmpl)
+	}
+	if tmpl.Description != issue.Description {
+		t.Errorf("Description = %q, want %q", tmpl.Description, issue.Description)
+	}
+	if tmpl.AcceptanceCriteria != issue.AcceptanceCriteria || tmpl.Notes != issue.Notes || tmpl.Design != "" {
+		t.Errorf("Text fields not preserved: %+v", tmpl)
+	}
+	if tmpl.Priority != 1 || tmpl.IssueType != types.TypeFeature || tmpl.Assignee != "alice" {
+		t.Errorf("Priority/type/assignee not preserved: %+v", tmpl)
+	}
+	if tmpl.EstimatedMinutes == nil || *tmpl.EstimatedMinutes != 90 || tmpl.ExternalRef != "gh-12" {
+		t.Errorf("Estimate/external ref not preserved: %+v", tmpl)
+	}
+	if !stringSlicesEqual(tmpl.Labels, []string{"backend", "ux"}) {
+		t.Errorf("Labels = %v", tmpl.Labels)
+	}
+	if !stringSlicesEqual(tmpl.Dependencies, []string{"bd-3", "parent-child:bd-1"}) {
+		t.Errorf("Dependencies = %v", tmpl.Dependencies)
+	}
+	// Design was empty and not written, so re-import leaves it alone
+	if tmpl.Fields["design"] || !tmpl.Fields["description"] || !tmpl.Fields["labels"] {
+		t.Errorf("Unexpected fields set: %v", tmpl.Fields)
+	}
+}
+
+func TestParseMarkdownFile_EmptySectionClears(t *testing.T) {
+	content := `## Cleared
+
+### ID
+bd-4
+
+### Labels
+
+### Notes
+
+### Priority
+`
+	tmpFile := filepath.Join(t.TempDir(), "test.md")
+	if err := os.WriteFile(tmpFile, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	got, err := parseMarkdownFile(tmpFile)
+	if err != nil {
+		t.Fatalf("parseMarkdownFile failed: %v", err)
+	}
+	tmpl := got[0]
+	if tmpl.ID != "bd-4" {
+		t.Errorf("ID = %q, want bd-4", tmpl.ID)
+	}
+	if !tmpl.Fields["labels"] || !tmpl.Fields["notes"] {
+		t.Errorf("Expected empty labels and notes sections to be recorded, got %v", tmpl.Fields)
+	}
+	// An empty priority has no value to set
+	if tmpl.Fields["priority"] {
+		t.Error("Expected empty priority section to be ignored")
+	}
+}
@@ -0,0 +1,445 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// FlowStats summarizes lead or cycle times, in hours
+type FlowStats struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean_hours"`
+	P50   float64 `json:"p50_hours"`
+	P75   float64 `json:"p75_hours"`
+	P85   float64 `json:"p85_hours"`
+	P95   float64 `json:"p95_hours"`
+	Max   float64 `json:"max_hours"`
+}
+
+// FlowPeriod is one day or week of the throughput, WIP and burndown series
+type FlowPeriod struct {
+	Start     time.Time `json:"start"`
+	Created   int       `json:"created"`
+	Closed    int       `json:"closed"`    // Throughput: closes during the period
+	WIP       int       `json:"wip"`       // Issues in_progress at the end of the period
+	Scope     int       `json:"scope"`     // Issues that existed at the end of the period
+	Done      int       `json:"done"`      // Of those, closed at the end of the period (burnup)
+	Remaining int       `json:"remaining"` // Scope - Done (burndown)
+}
+
+// FlowIssue is an issue closed in the reporting window
+type FlowIssue struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"` // First change to in_progress
+	ClosedAt   time.Time  `json:"closed_at"`
+	LeadHours  float64    `json:"lead_hours"`
+	CycleHours *float64   `json:"cycle_hours,omitempty"` // Unset when the issue was never in_progress
+}
+
+// FlowMetrics is the output of 'bd metrics'
+type FlowMetrics struct {
+	Since      time.Time     `json:"since"`
+	Until      time.Time     `json:"until"`
+	Interval   string        `json:"interval"`
+	Issues     int           `json:"issues"`
+	LeadTime   FlowStats     `json:"lead_time"`  // Created → closed
+	CycleTime  FlowStats     `json:"cycle_time"` // First in_progress → closed
+	Throughput float64       `json:"throughput"` // Mean closes per period
+	Periods    []*FlowPeriod `json:"periods"`
+	Closed     []*FlowIssue  `json:"closed"`
+}
+
+// issueTimeline returns an issue's status changes, oldest first, starting with open
+// at creation. Issues imported without history end with their current status.
+// Events are stored with second precision, so a change is never placed before the
+// one preceding it.
+func issueTimeline(issue *types.Issue, changes []*types.StatusChange) []*types.StatusChange {
+	timeline := make([]*types.StatusChange, 0, len(changes)+2)
+	timeline = append(timeline, &types.StatusChange{IssueID: issue.ID, Status: types.StatusOpen, At: issue.CreatedAt})
+	for _, change := range changes {
+		if prev := timeline[len(timeline)-1].At; change.At.Before(prev) {
+			change = &types.StatusChange{IssueID: change.IssueID, Status: change.Status, At: prev}
+		}
+		timeline = append(timeline, change)
+	}
+	if last := timeline[len(timeline)-1]; last.Status != issue.Status {
+		at := issue.UpdatedAt
+		if issue.ClosedAt != nil {
+			at = *issue.ClosedAt
+		}
+		timeline = append(timeline, &types.StatusChange{IssueID: issue.ID, Status: issue.Status, At: at})
+	}
+	return timeline
+}
+
+// statusAt returns the status in effect just before t, or "" before the issue existed
+func statusAt(timeline []*types.StatusChange, t time.Time) types.Status {
+	var status types.Status
+	for _, change := range timeline {
+		if !change.At.Before(t) {
+			break
+		}
+		status = change.Status
+	}
+	return status
+}
+
+// periodStart returns the start of the day or week (from Monday) containing t
+func periodStart(t time.Time, interval string) time.Time {
+	t = t.Local()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	if interval == "week" {
+		day = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return day
+}
+
+// flowStats computes percentiles (nearest rank) over durations in hours
+func flowStats(hours []float64) FlowStats {
+	stats := FlowStats{Count: len(hours)}
+	if len(hours) == 0 {
+		return stats
+	}
+	sorted := append([]float64(nil), hours...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, h := range sorted {
+		sum += h
+	}
+	rank := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	stats.Mean = sum / float64(len(sorted))
+	stats.P50, stats.P75, stats.P85, stats.P95 = rank(0.50), rank(0.75), rank(0.85), rank(0.95)
+	stats.Max = sorted[len(sorted)-1]
+	return stats
+}
+
+// buildFlowMetrics computes lead and cycle times for issues closed between since and
+// until, and throughput, WIP and burndown per day or week over the same window
+func buildFlowMetrics(issues []*types.Issue, history map[string][]*types.StatusChange, since, until time.Time, interval string) *FlowMetrics {
+	metrics := &FlowMetrics{Since: since, Until: until, Interval: interval, Issues: len(issues), Periods: []*FlowPeriod{}, Closed: []*FlowIssue{}}
+
+	timelines := make(map[string][]*types.StatusChange, len(issues))
+	var leadHours, cycleHours []float64
+	for _, issue := range issues {
+		timeline := issueTimeline(issue, history[issue.ID])
+		timelines[issue.ID] = timeline
+
+		if issue.Status != types.StatusClosed {
+			continue
+		}
+		closedAt := timeline[len(timeline)-1].At
+		if issue.ClosedAt != nil && !issue.ClosedAt.Before(issue.CreatedAt) {
+			closedAt = *issue.ClosedAt
+		}
+		if closedAt.Before(since) || closedAt.After(until) {
+			continue
+		}
+		flow := &FlowIssue{
+			ID:        issue.ID,
+			Title:     issue.Title,
+			CreatedAt: issue.CreatedAt,
+			ClosedAt:  closedAt,
+			LeadHours: closedAt.Sub(issue.CreatedAt).Hours(),
+		}
+		for _, change := range timeline {
+			if change.Status == types.StatusInProgress {
+				started := change.At
+				cycle := math.Max(closedAt.Sub(started).Hours(), 0)
+				flow.StartedAt, flow.CycleHours = &started, &cycle
+				cycleHours = append(cycleHours, cycle)
+				break
+			}
+		}
+		leadHours = append(leadHours, flow.LeadHours)
+		metrics.Closed = append(metrics.Closed, flow)
+	}
+	sort.Slice(metrics.Closed, func(i, j int) bool { return metrics.Closed[i].ClosedAt.Before(metrics.Closed[j].ClosedAt) })
+	metrics.LeadTime = flowStats(leadHours)
+	metrics.CycleTime = flowStats(cycleHours)
+
+	for start := periodStart(since, interval); start.Before(until); {
+		next := start.AddDate(0, 0, 1)
+		if interval == "week" {
+			next = start.AddDate(0, 0, 7)
+		}
+		end := next
+		if end.After(until) {
+			end = until
+		}
+
+		period := &FlowPeriod{Start: start}
+		for _, issue := range issues {
+			timeline := timelines[issue.ID]
+			if !issue.CreatedAt.Before(start) && issue.CreatedAt.Before(end) {
+				period.Created++
+			}
+			for i, change := range timeline {
+				if i > 0 && change.Status == types.StatusClosed && timeline[i-1].Status != types.StatusClosed &&
+					!change.At.Before(start) && change.At.Before(end) {
+					period.Closed++
+				}
+			}
+			switch statusAt(timeline, end) {
+			case "":
+				continue
+			case types.StatusInProgress:
+				period.WIP++
+			case types.StatusClosed:
+				period.Done++
+			}
+			period.Scope++
+		}
+		period.Remaining = period.Scope - period.Done
+		metrics.Periods = append(metrics.Periods, period)
+		start = next
+	}
+
+	if len(metrics.Periods) > 0 {
+		closed := 0
+		for _, period := range metrics.Periods {
+			closed += period.Closed
+		}
+		metrics.Throughput = float64(closed) / float64(len(metrics.Periods))
+	}
+	return metrics
+}
+
+// formatHours renders a duration in hours as e.g. "45m", "5.2h" or "3.1d"
+func formatHours(hours float64) string {
+	switch {
+	case hours < 1:
+		return fmt.Sprintf("%.0fm", hours*60)
+	case hours < 48:
+		return fmt.Sprintf("%.1fh", hours)
+	}
+	return fmt.Sprintf("%.1fd", hours/24)
+}
+
+// writeFlowCSV writes the period series as CSV for charting
+func writeFlowCSV(w io.Writer, metrics *FlowMetrics) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"period_start", "created", "closed", "wip", "scope", "done", "remaining"}); err != nil {
+		return err
+	}
+	for _, p := range metrics.Periods {
+		err := cw.Write([]string{
+			p.Start.Format("2006-01-02"), strconv.Itoa(p.Created), strconv.Itoa(p.Closed), strconv.Itoa(p.WIP),
+			strconv.Itoa(p.Scope), strconv.Itoa(p.Done), strconv.Itoa(p.Remaining),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// outputFlowMetrics prints the percentiles and the period table
+func outputFlowMetrics(metrics *FlowMetrics, scope string) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("\n%s Flow metrics for %s, %s to %s (%d issues)\n\n", cyan("📈"), scope,
+		metrics.Since.Local().Format("2006-01-02"), metrics.Until.Local().Format("2006-01-02"), metrics.Issues)
+
+	printStats := func(title string, s FlowStats) {
+		if s.Count == 0 {
+			fmt.Printf("%-35s no issues\n", title+":")
+			return
+		}
+		fmt.Printf("%-35s p50 %-7s p75 %-7s p85 %-7s p95 %-7s max %-7s mean %s (%d issues)\n", title+":",
+			formatHours(s.P50), formatHours(s.P75), formatHours(s.P85), formatHours(s.P95), formatHours(s.Max),
+			formatHours(s.Mean), s.Count)
+	}
+	printStats("Lead time (created → closed)", metrics.LeadTime)
+	printStats("Cycle time (in_progress → closed)", metrics.CycleTime)
+	fmt.Printf("%-35s %.1f closed per %s\n\n", "Throughput:", metrics.Throughput, metrics.Interval)
+
+	heading := "Week of"
+	if metrics.Interval == "day" {
+		heading = "Day"
+	}
+	fmt.Printf("%-12s %7s %7s %5s %6s %6s %10s\n", heading, "created", "closed", "wip", "scope", "done", "remaining")
+	for _, p := range metrics.Periods {
+		fmt.Printf("%-12s %7d %7d %5d %6d %6d %10d\n", p.Start.Format("2006-01-02"),
+			p.Created, p.Closed, p.WIP, p.Scope, p.Done, p.Remaining)
+	}
+	fmt.Println()
+}
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Show flow metrics: lead and cycle time, throughput, WIP and burndown",
+	Long: `Show flow metrics computed from the audit trail.
+
+Lead time runs from creation to close and cycle time from the first change to
+in_progress to close, for issues closed in the window, with p50/p75/p85/p95
+percentiles. Per day or week, the series counts issues created and closed
+(throughput), issues in progress at the end of the period (WIP), and the total
+scope, done and remaining issues for burnup and burndown charts.
+
+Restrict the issues with --epic (all descendants), --label, --assignee or
+--type. Use --json for everything, or --csv for the series alone.
+
+Examples:
+  bd metrics                         # Last 12 weeks, by week
+  bd metrics --epic bd-10 --since 30d --interval day
+  bd metrics --label backend --csv > backend.csv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		untilFlag, _ := cmd.Flags().GetString("until")
+		interval, _ := cmd.Flags().GetString("interval")
+		epicID, _ := cmd.Flags().GetString("epic")
+		label, _ := cmd.Flags().GetString("label")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		issueType, _ := cmd.Flags().GetString("type")
+		asCSV, _ := cmd.Flags().GetBool("csv")
+		ctx := context.Background()
+
+		if interval != "day" && interval != "week" {
+			fmt.Fprintf(os.Stderr, "Error: --interval must be 'day' or 'week'\n")
+			os.Exit(1)
+		}
+		until := time.Now()
+		if untilFlag != "" {
+			t, err := parseDateFlag(untilFlag, true)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --until: %v\n", err)
+				os.Exit(1)
+			}
+			until = *t
+		}
+		since, err := parseSinceFlag(sinceFlag, until)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --since: %v\n", err)
+			os.Exit(1)
+		}
+
+		issues, scope, err := metricsIssues(ctx, epicID, label, assignee, issueType)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		history, err := store.GetStatusHistory(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		metrics := buildFlowMetrics(issues, history, since, until, interval)
+		switch {
+		case jsonOutput:
+			outputJSON(metrics)
+		case asCSV:
+			if err := writeFlowCSV(os.Stdout, metrics); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			outputFlowMetrics(metrics, scope)
+		}
+	},
+}
+
+// parseSinceFlag accepts a window length before until ("12w", "30d") or a date
+func parseSinceFlag(s string, until time.Time) (time.Time, error) {
+	if d, err := parseRelativeDuration(s); err == nil {
+		return until.Add(-d), nil
+	}
+	t, err := parseDateFlag(s, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t == nil {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	return *t, nil
+}
+
+// metricsIssues returns the issues to measure and a description of them
+func metricsIssues(ctx context.Context, epicID, label, assignee, issueType string) ([]*types.Issue, string, error) {
+	filter := types.IssueFilter{}
+	var parts []string
+	if epicID != "" {
+		parts = append(parts, "epic "+epicID)
+	}
+	if label != "" {
+		filter.Labels = []string{label}
+		parts = append(parts, "label "+label)
+	}
+	if assignee != "" {
+		filter.Assignee = &assignee
+		parts = append(parts, "assignee "+assignee)
+	}
+	if issueType != "" {
+		t := types.IssueType(issueType)
+		filter.IssueType = &t
+		parts = append(parts, "type "+issueType)
+	}
+	scope := "all issues"
+	if len(parts) > 0 {
+		scope = strings.Join(parts, ", ")
+	}
+
+	issues, err := store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, "", err
+	}
+	if epicID == "" {
+		return issues, scope, nil
+	}
+
+	epic, err := store.GetIssue(ctx, epicID)
+	if err != nil {
+		return nil, "", err
+	}
+	if epic == nil {
+		return nil, "", fmt.Errorf("issue %s not found", epicID)
+	}
+	descendants, err := store.GetDescendants(ctx, epicID, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	inEpic := make(map[string]bool, len(descendants))
+	for _, node := range descendants {
+		inEpic[node.ID] = true
+	}
+	var filtered []*types.Issue
+	for _, issue := range issues {
+		if inEpic[issue.ID] {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered, scope, nil
+}
+
+func init() {
+	metricsCmd.Flags().String("since", "12w", "Start of the window: a length before --until (30d, 12w) or a date")
+	metricsCmd.Flags().String("until", "", "End of the window (default: now)")
+	metricsCmd.Flags().String("interval", "week", "Series interval: 'day' or 'week'")
+	metricsCmd.Flags().String("epic", "", "Only descendants of this epic")
+	metricsCmd.Flags().StringP("label", "l", "", "Only issues with this label")
+	metricsCmd.Flags().StringP("assignee", "a", "", "Only issues assigned to this person")
+	metricsCmd.Flags().StringP("type", "t", "", "Only issues of this type")
+	metricsCmd.Flags().Bool("csv", false, "Write the period series as CSV")
+	rootCmd.AddCommand(metricsCmd)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildFlowMetrics(t *testing.T) {
+	// Monday 2025-06-02 00:00 local
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.Local)
+	day := func(n int) time.Time { return monday.AddDate(0, 0, n) }
+	closedAt := func(n int) *time.Time { t := day(n); return &t }
+
+	issues := []*types.Issue{
+		// Started on day 1, closed on day 3
+		{ID: "a", Status: types.StatusClosed, CreatedAt: day(0), ClosedAt: closedAt(3)},
+		// Closed on day 9 without going in_progress
+		{ID: "b", Status: types.StatusClosed, CreatedAt: day(0), ClosedAt: closedAt(9)},
+		// In progress since day 8
+		{ID: "c", Status: types.StatusInProgress, CreatedAt: day(2), UpdatedAt: day(8)},
+		// Imported closed issue without any history
+		{ID: "d", Status: types.StatusClosed, CreatedAt: day(7), ClosedAt: closedAt(11)},
+	}
+	history := map[string][]*types.StatusChange{
+		"a": {
+			{IssueID: "a", Status: types.StatusOpen, At: day(0)},
+			{IssueID: "a", Status: types.StatusInProgress, At: day(1)},
+			{IssueID: "a", Status: types.StatusClosed, At: day(3)},
+		},
+		"b": {
+			{IssueID: "b", Status: types.StatusOpen, At: day(0)},
+			{IssueID: "b", Status: types.StatusClosed, At: day(9)},
+		},
+		"c": {
+			{IssueID: "c", Status: types.StatusOpen, At: day(2)},
+			{IssueID: "c", Status: types.StatusInProgress, At: day(8)},
+		},
+	}
+
+	metrics := buildFlowMetrics(issues, history, day(0), day(14), "week")
+
+	if metrics.LeadTime.Count != 3 || metrics.LeadTime.P50 != 4*24 || metrics.LeadTime.Max != 9*24 {
+		t.Errorf("Unexpected lead time: %+v", metrics.LeadTime)
+	}
+	if metrics.CycleTime.Count != 1 || metrics.CycleTime.P50 != 2*24 {
+		t.Errorf("Unexpected cycle time: %+v", metrics.CycleTime)
+	}
+	if len(metrics.Closed) != 3 || metrics.Closed[0].ID != "a" || metrics.Closed[1].CycleHours != nil {
+		t.Errorf("Unexpected closed issues: %+v", metrics.Closed)
+	}
+
+	if len(metrics.Periods) != 2 {
+		t.Fatalf("Expected 2 weekly periods, got %d", len(metrics.Periods))
+	}
+	week1, week2 := metrics.Periods[0], metrics.Periods[1]
+	if week1.Created != 3 || week1.Closed != 1 || week1.WIP != 0 || week1.Scope != 3 || week1.Done != 1 || week1.Remaining != 2 {
+		t.Errorf("Unexpected first week: %+v", week1)
+	}
+	if week2.Created != 1 || week2.Closed != 2 || week2.WIP != 1 || week2.Scope != 4 || week2.Done != 3 || week2.Remaining != 1 {
+		t.Errorf("Unexpected second week: %+v", week2)
+	}
+	if metrics.Throughput != 1.5 {
+		t.Errorf("Throughput = %v, want 1.5", metrics.Throughput)
+	}
+
+	var buf bytes.Buffer
+	if err := writeFlowCSV(&buf, metrics); err != nil {
+		t.Fatalf("writeFlowCSV failed: %v", err)
+	}
+	want := "period_start,created,closed,wip,scope,done,remaining\n2025-06-02,3,1,0,3,1,2\n2025-06-09,1,2,1,4,3,1\n"
+	if buf.String() != want {
+		t.Errorf("CSV = %q, want %q", buf.String(), want)
+	}
+
+	daily := buildFlowMetrics(issues, history, day(0).Add(12*time.Hour), day(3), "day")
+	if len(daily.Periods) != 3 || !daily.Periods[0].Start.Equal(day(0)) {
+		t.Errorf("Expected 3 daily periods starting at midnight, got %+v", daily.Periods)
+	}
+}
+
+func TestFlowStatsPercentiles(t *testing.T) {
+	var hours []float64
+	for i := 1; i <= 20; i++ {
+		hours = append(hours, float64(i))
+	}
+	stats := flowStats(hours)
+	if stats.P50 != 10 || stats.P75 != 15 || stats.P85 != 17 || stats.P95 != 19 || stats.Max != 20 || stats.Mean != 10.5 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if s := flowStats(nil); s.Count != 0 || s.P50 != 0 {
+		t.Errorf("Expected empty stats, got %+v", s)
+	}
+	if got := formatHours(0.5) + " " + formatHours(5.25) + " " + formatHours(72); got != "30m 5.2h 3.0d" {
+		t.Errorf("formatHours = %q", got)
+	}
+	if !strings.HasPrefix(formatHours(47.9), "47.9") {
+		t.Errorf("formatHours(47.9) = %q", formatHours(47.9))
+	}
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// PlanEntry is the schedule for one issue under an epic. All times are in minutes
+// of remaining effort from now, assuming unlimited parallelism.
+type PlanEntry struct {
+	ID              string       `json:"id"`
+	Title           string       `json:"title"`
+	Status          types.Status `json:"status"`
+	Assignee        string       `json:"assignee,omitempty"`
+	DurationMinutes int          `json:"duration_minutes"` // Remaining effort (0 once closed)
+	Estimated       bool         `json:"estimated"`        // False if the default estimate was used
+	EarliestStart   int          `json:"earliest_start"`
+	EarliestFinish  int          `json:"earliest_finish"`
+	LatestStart     int          `json:"latest_start"`
+	LatestFinish    int          `json:"latest_finish"`
+	Slack           int          `json:"slack"`
+	Critical        bool         `json:"critical"`
+	Predecessors    []string     `json:"predecessors,omitempty"`
+}
+
+// PlanResult is the critical path analysis of an epic
+type PlanResult struct {
+	EpicID           string       `json:"epic_id"`
+	EpicTitle        string       `json:"epic_title"`
+	Issues           []*PlanEntry `json:"issues"`
+	CriticalPath     []string     `json:"critical_path"`
+	DurationMinutes  int          `json:"duration_minutes"`  // Length of the critical path
+	RemainingMinutes int          `json:"remaining_minutes"` // Total effort left across all open issues
+	Unestimated      []string     `json:"unestimated,omitempty"`
+}
+
+var planCmd = &cobra.Command{
+	Use:   "plan [epic-id]",
+	Short: "Critical path and schedule analysis for an epic",
+	Long: `Analyze the work under an epic as a schedule.
+
+Builds the graph of all parent-child descendants of the epic, using 'blocks'
+dependencies and parent-child containment as ordering constraints and
+estimated_minutes as durations (closed issues count as done). Reports the
+earliest start/finish of each issue, its slack, the critical path, and the
+total remaining effort. Issues on the critical path have zero slack: any
+delay to them delays the whole epic, so they are the ones to staff first.
+
+Output formats:
+  (default)     table
+  --json        machine-readable schedule
+  --format dot  Graphviz graph with the critical path highlighted`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		formatStr, _ := cmd.Flags().GetString("format")
+		defaultEstimate, _ := cmd.Flags().GetInt("default-estimate")
+
+		ctx := context.Background()
+		epic, err := store.GetIssue(ctx, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if epic == nil {
+			fmt.Fprintf(os.Stderr, "Issue %s not found\n", args[0])
+			os.Exit(1)
+		}
+
+		descendants, err := store.GetDescendants(ctx, epic.ID, 0)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		issues := make([]*types.Issue, len(descendants))
+		for i, node := range descendants {
+			issues[i] = &node.Issue
+		}
+
+		allDeps, err := store.GetAllDependencyRecords(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		plan, err := computePlan(epic, issues, allDeps, defaultEstimate)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		switch {
+		case formatStr == "dot":
+			outputPlanDot(plan)
+		case formatStr != "":
+			fmt.Fprintf(os.Stderr, "Error: unknown format '%s' (supported: dot)\n", formatStr)
+			os.Exit(1)
+		case jsonOutput:
+			outputJSON(plan)
+		default:
+			outputPlanTable(plan)
+		}
+	},
+}
+
+// computePlan runs a critical path analysis over the descendants of root.
+//
+// Ordering constraints (predecessor must finish before successor starts):
+//   - A depends on B via 'blocks': B → A
+//   - C is a child of container P: C → P (a container finishes after its children)
+//   - P is blocked by B: B → every descendant of P (children inherit blocking, as in ready work)
+func computePlan(root *types.Issue, issues []*types.Issue, allDeps map[string][]*types.Dependency, defaultEstimate int) (*PlanResult, error) {
+	plan := &PlanResult{
+		EpicID:       root.ID,
+		EpicTitle:    root.Title,
+		Issues:       []*PlanEntry{},
+		CriticalPath: []string{},
+	}
+
+	entries := make(map[string]*PlanEntry, len(issues))
+	for _, issue := range issues {
+		entry := &PlanEntry{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Status:   issue.Status,
+			Assignee: issue.Assignee,
+		}
+		switch {
+		case issue.Status == types.StatusClosed:
+			entry.Estimated = true
+		case issue.EstimatedMinutes != nil:
+			entry.DurationMinutes = *issue.EstimatedMinutes
+			entry.Estimated = true
+		default:
+			entry.DurationMinutes = defaultEstimate
+			plan.Unestimated = append(plan.Unestimated, issue.ID)
+		}
+		plan.RemainingMinutes += entry.DurationMinutes
+		entries[issue.ID] = entry
+	}
+	sort.Strings(plan.Unestimated)
+
+	// Collect containment (children within the set) and direct blockers
+	children := make(map[string][]string)
+	blockers := make(map[string][]string)
+	for id := range entries {
+		for _, dep := range allDeps[id] {
+			if entries[dep.DependsOnID] == nil {
+				continue // Outside the epic (including the epic itself)
+			}
+			switch dep.Type {
+			case types.DepParentChild:
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], id)
+			case types.DepBlocks:
+				blockers[id] = append(blockers[id], dep.DependsOnID)
+			}
+		}
+	}
+
+	preds := make(map[string]map[string]bool, len(entries))
+	addEdge := func(from, to string) {
+		if from == to {
+			return
+		}
+		if preds[to] == nil {
+			preds[to] = make(map[string]bool)
+		}
+		preds[to][from] = true
+	}
+	var descendantsOf func(id string, visit func(string))
+	descendantsOf = func(id string, visit func(string)) {
+		for _, child := range children[id] {
+			visit(child)
+			descendantsOf(child, visit)
+		}
+	}
+	for id := range entries {
+		for _, child := range children[id] {
+			addEdge(child, id)
+		}
+		for _, blocker := range blockers[id] {
+			addEdge(blocker, id)
+			descendantsOf(id, func(d string) { addEdge(blocker, d) })
+		}
+	}
+
+	// Topological order (Kahn), deterministic by ID
+	succs := make(map[string][]string)
+	inDegree := make(map[string]int, len(entries))
+	for id := range entries {
+		inDegree[id] = len(preds[id])
+		for p := range preds[id] {
+			succs[p] = append(succs[p], id)
+		}
+	}
+	var queue []string
+	for id, n := range inDegree {
+		if n == 0 {
+			queue = append(queue, id)
+		}
+	}
+	sort.Strings(queue)
+	order := make([]string, 0, len(entries))
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		order = append(order, id)
+		next := succs[id]
+		sort.Strings(next)
+		for _, s := range next {
+			inDegree[s]--
+			if inDegree[s] == 0 {
+				queue = append(queue, s)
+			}
+		}
+	}
+	if len(order) != len(entries) {
+		var stuck []string
+		for id, n := range inDegree {
+			if n > 0 {
+				stuck = append(stuck, id)
+			}
+		}
+		sort.Strings(stuck)
+		return nil, fmt.Errorf("ordering constraints form a cycle among: %s", strings.Join(stuck, ", "))
+	}
+
+	// Forward pass: earliest start/finish
+	for _, id := range order {
+		entry := entries[id]
+		for p := range preds[id] {
+			if entries[p].EarliestFinish > entry.EarliestStart {
+				entry.EarliestStart = entries[p].EarliestFinish
+			}
+		}
+		entry.EarliestFinish = entry.EarliestStart + entry.DurationMinutes
+		if entry.EarliestFinish > plan.DurationMinutes {
+			plan.DurationMinutes = entry.EarliestFinish
+		}
+	}
+
+	// Backward pass: latest start/finish and slack
+	for i := len(order) - 1; i >= 0; i-- {
+		entry := entries[order[i]]
+		entry.LatestFinish = plan.DurationMinutes
+		for _, s := range succs[entry.ID] {
+			if entries[s].LatestStart < entry.LatestFinish {
+				entry.LatestFinish = entries[s].LatestStart
+			}
+		}
+		entry.LatestStart = entry.LatestFinish - entry.DurationMinutes
+		entry.Slack = entry.LatestStart - entry.EarliestStart
+		entry.Critical = entry.Slack == 0 && entry.DurationMinutes > 0
+	}
+
+	for _, id := range order {
+		entry := entries[id]
+		for p := range preds[id] {
+			entry.Predecessors = append(entry.Predecessors, p)
+		}
+		sort.Strings(entry.Predecessors)
+		plan.Issues = append(plan.Issues, entry)
+	}
+	sort.SliceStable(plan.Issues, func(i, j int) bool {
+		a, b := plan.Issues[i], plan.Issues[j]
+		if a.EarliestStart != b.EarliestStart {
+			return a.EarliestStart < b.EarliestStart
+		}
+		if a.Slack != b.Slack {
+			return a.Slack < b.Slack
+		}
+		return a.ID < b.ID
+	})
+
+	// Walk back from the last-finishing critical issue to recover one critical path
+	var current *PlanEntry
+	for _, entry := range plan.Issues {
+		if entry.Critical && entry.EarliestFinish == plan.DurationMinutes &&
+			(current == nil || entry.ID < current.ID) {
+			current = entry
+		}
+	}
+	for current != nil {
+		plan.CriticalPath = append([]string{current.ID}, plan.CriticalPath...)
+		var prev *PlanEntry
+		for _, p := range current.Predecessors {
+			cand := entries[p]
+			if cand.Critical && cand.EarliestFinish == current.EarliestStart && (prev == nil || cand.ID < prev.ID) {
+				prev = cand
+			}
+		}
+		current = prev
+	}
+
+	return plan, nil
+}
+
+// formatMinutes renders a duration in minutes as e.g. "2h30m", "45m" or "0"
+func formatMinutes(minutes int) string {
+	if minutes == 0 {
+		return "0"
+	}
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh%dm", h, m)
+}
+
+// outputPlanTable prints the schedule as a human-readable table
+func outputPlanTable(plan *PlanResult) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	red := color.New(color.FgRed, color.Bold).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	fmt.Printf("\n%s Plan for %s: %s\n\n", cyan("🗺"), plan.EpicID, plan.EpicTitle)
+	if len(plan.Issues) == 0 {
+		fmt.Printf("No child issues (add them with: bd dep add <child> %s --type parent-child)\n\n", plan.EpicID)
+		return
+	}
+
+	fmt.Printf("Remaining effort:  %s\n", formatMinutes(plan.RemainingMinutes))
+	fmt.Printf("Critical path:     %s (%s)\n", formatMinutes(plan.DurationMinutes), strings.Join(plan.CriticalPath, " → "))
+	fmt.Println()
+
+	idWidth := len("ID")
+	for _, entry := range plan.Issues {
+		if len(entry.ID) > idWidth {
+			idWidth = len(entry.ID)
+		}
+	}
+
+	fmt.Printf("  %-*s  %-7s %-7s %-7s %-7s %-11s %s\n", idWidth, "ID", "EST", "START", "FINISH", "SLACK", "STATUS", "TITLE")
+	for _, entry := range plan.Issues {
+		marker := " "
+		if entry.Critical {
+			marker = red("*")
+		}
+		est := formatMinutes(entry.DurationMinutes)
+		if !entry.Estimated {
+			est += "?"
+		}
+		fmt.Printf("%s %-*s  %-7s %-7s %-7s %-7s %-11s %s\n", marker, idWidth, entry.ID,
+			est, formatMinutes(entry.EarliestStart), formatMinutes(entry.EarliestFinish),
+			formatMinutes(entry.Slack), entry.Status, entry.Title)
+	}
+
+	fmt.Printf("\n%s = critical path (zero slack)\n", red("*"))
+	if len(plan.Unestimated) > 0 {
+		fmt.Printf("%s %d issue(s) have no estimate (marked ?): %s\n", yellow("⚠"), len(plan.Unestimated), strings.Join(plan.Unestimated, ", "))
+	}
+	fmt.Println()
+}
+
+// outputPlanDot prints the schedule as a Graphviz graph with the critical path highlighted.
+// Edges point from predecessor to successor (the order work flows).
+func outputPlanDot(plan *PlanResult) {
+	onPath := make(map[string]bool, len(plan.CriticalPath))
+	for i := 1; i < len(plan.CriticalPath); i++ {
+		onPath[plan.CriticalPath[i-1]+"->"+plan.CriticalPath[i]] = true
+	}
+
+	fmt.Println("digraph plan {")
+	fmt.Println("  rankdir=LR;")
+	fmt.Println("  node [shape=box, style=rounded];")
+	fmt.Printf("  label=%q;\n", fmt.Sprintf("%s: %s (critical path %s)", plan.EpicID, plan.EpicTitle, formatMinutes(plan.DurationMinutes)))
+	fmt.Println()
+
+	for _, entry := range plan.Issues {
+		label := fmt.Sprintf("%s\n%s\nest %s | %s-%s | slack %s",
+			entry.ID, entry.Title, formatMinutes(entry.DurationMinutes),
+			formatMinutes(entry.EarliestStart), formatMinutes(entry.EarliestFinish), formatMinutes(entry.Slack))
+
+		fillColor := "white"
+		fontColor := "black"
+		switch entry.Status {
+		case types.StatusClosed:
+			fillColor = "lightgray"
+			fontColor = "dimgray"
+		case types.StatusInProgress:
+			fillColor = "lightyellow"
+		case types.StatusBlocked:
+			fillColor = "lightcoral"
+		}
+		borderColor := "black"
+		penWidth := 1
+		if entry.Critical {
+			borderColor = "red"
+			penWidth = 3
+		}
+
+		fmt.Printf("  %q [label=%q, style=\"rounded,filled\", fillcolor=%q, fontcolor=%q, color=%q, penwidth=%d];\n",
+			entry.ID, label, fillColor, fontColor, borderColor, penWidth)
+	}
+	fmt.Println()
+
+	for _, entry := range plan.Issues {
+		for _, p := range entry.Predecessors {
+			if onPath[p+"->"+entry.ID] {
+				fmt.Printf("  %q -> %q [color=red, style=bold, penwidth=3];\n", p, entry.ID)
+			} else {
+				fmt.Printf("  %q -> %q [color=gray];\n", p, entry.ID)
+			}
+		}
+	}
+
+	fmt.Println("}")
+}
+
+func init() {
+	planCmd.Flags().String("format", "", "Output format: 'dot' (Graphviz with critical path highlighted)")
+	planCmd.Flags().Int("default-estimate", 0, "Minutes to assume for open issues without an estimate")
+	rootCmd.AddCommand(planCmd)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func planIssue(id string, status types.Status, estimate int) *types.Issue {
+	issue := &types.Issue{ID: id, Title: id, Status: status, Priority: 2, IssueType: types.TypeTask}
+	if estimate >= 0 {
+		issue.EstimatedMinutes = &estimate
+	}
+	return issue
+}
+
+func planDeps(edges ...[3]string) map[string][]*types.Dependency {
+	deps := make(map[string][]*types.Dependency)
+	for _, e := range edges {
+		deps[e[0]] = append(deps[e[0]], &types.Dependency{IssueID: e[0], DependsOnID: e[1], Type: types.DependencyType(e[2])})
+	}
+	return deps
+}
+
+func planEntries(plan *PlanResult) map[string]*PlanEntry {
+	m := make(map[string]*PlanEntry, len(plan.Issues))
+	for _, e := range plan.Issues {
+		m[e.ID] = e
+	}
+	return m
+}
+
+func TestComputePlan(t *testing.T) {
+	epic := &types.Issue{ID: "e", Title: "Epic", IssueType: types.TypeEpic}
+	issues := []*types.Issue{
+		planIssue("a", types.StatusOpen, 60),
+		planIssue("b", types.StatusOpen, 120),
+		planIssue("c", types.StatusOpen, 30),
+		planIssue("d", types.StatusOpen, -1),
+		planIssue("f", types.StatusOpen, 45),
+		planIssue("g", types.StatusOpen, 10),
+	}
+	deps := planDeps(
+		[3]string{"a", "e", "parent-child"},
+		[3]string{"b", "e", "parent-child"},
+		[3]string{"c", "e", "parent-child"},
+		[3]string{"d", "e", "parent-child"},
+		[3]string{"f", "e", "parent-child"},
+		[3]string{"g", "f", "parent-child"}, // f is a container for g
+		[3]string{"b", "a", "blocks"},
+		[3]string{"c", "a", "blocks"},
+		[3]string{"d", "b", "blocks"},
+		[3]string{"f", "c", "blocks"}, // g inherits the block on f
+	)
+
+	plan, err := computePlan(epic, issues, deps, 15)
+	if err != nil {
+		t.Fatalf("computePlan failed: %v", err)
+	}
+	entries := planEntries(plan)
+
+	// a(60) → b(120) → d(15 default) = 195; a → c(30) → g(10) → f(45) = 145
+	if plan.DurationMinutes != 195 {
+		t.Errorf("expected duration 195, got %d", plan.DurationMinutes)
+	}
+	if plan.RemainingMinutes != 280 {
+		t.Errorf("expected remaining 280, got %d", plan.RemainingMinutes)
+	}
+	if want := []string{"a", "b", "d"}; !reflect.DeepEqual(plan.CriticalPath, want) {
+		t.Errorf("expected critical path %v, got %v", want, plan.CriticalPath)
+	}
+	if want := []string{"d"}; !reflect.DeepEqual(plan.Unestimated, want) {
+		t.Errorf("expected unestimated %v, got %v", want, plan.Unestimated)
+	}
+	if g := entries["g"]; g.EarliestStart != 90 || g.EarliestFinish != 100 {
+		t.Errorf("expected g to start after c (90-100), got %d-%d", g.EarliestStart, g.EarliestFinish)
+	}
+	if f := entries["f"]; f.EarliestStart != 100 || f.Slack != 50 || f.Critical {
+		t.Errorf("expected f at 100 with slack 50, got start=%d slack=%d critical=%v", f.EarliestStart, f.Slack, f.Critical)
+	}
+}
+
+func TestComputePlanClosedIssuesAreDone(t *testing.T) {
+	epic := &types.Issue{ID: "e", Title: "Epic", IssueType: types.TypeEpic}
+	issues := []*types.Issue{
+		planIssue("a", types.StatusClosed, 60),
+		planIssue("b", types.StatusOpen, 30),
+	}
+	deps := planDeps([3]string{"b", "a", "blocks"})
+
+	plan, err := computePlan(epic, issues, deps, 0)
+	if err != nil {
+		t.Fatalf("computePlan failed: %v", err)
+	}
+	if plan.DurationMinutes != 30 || plan.RemainingMinutes != 30 {
+		t.Errorf("expected 30 minutes left, got duration=%d remaining=%d", plan.DurationMinutes, plan.RemainingMinutes)
+	}
+	if want := []string{"b"}; !reflect.DeepEqual(plan.CriticalPath, want) {
+		t.Errorf("expected critical path %v, got %v", want, plan.CriticalPath)
+	}
+}
+
+func TestComputePlanCycle(t *testing.T) {
+	epic := &types.Issue{ID: "e", Title: "Epic", IssueType: types.TypeEpic}
+	issues := []*types.Issue{
+		planIssue("a", types.StatusOpen, 10),
+		planIssue("b", types.StatusOpen, 10),
+	}
+	// A container blocking its own child cannot be scheduled
+	deps := planDeps([3]string{"b", "a", "parent-child"}, [3]string{"b", "a", "blocks"})
+
+	if _, err := computePlan(epic, issues, deps, 0); err == nil {
+		t.Fatal("expected an error for cyclic ordering constraints")
+	}
+}
+
+func TestFormatMinutes(t *testing.T) {
+	for minutes, want := range map[int]string{0: "0", 45: "45m", 60: "1h", 150: "2h30m"} {
+		if got := formatMinutes(minutes); got != want {
+			t.Errorf("formatMinutes(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -16,10 +17,14 @@ var readyCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		limit, _ := cmd.Flags().GetInt("limit")
 		assignee, _ := cmd.Flags().GetString("assignee")
+		dueWithin, _ := cmd.Flags().GetString("due-within")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		includeDeferred, _ := cmd.Flags().GetBool("include-deferred")
 
 		filter := types.WorkFilter{
-			Status: types.StatusOpen,
-			Limit:  limit,
+			Status:          types.StatusOpen,
+			IncludeDeferred: includeDeferred,
+			Limit:           limit,
 		}
 		// Use Changed() to properly handle P0 (priority=0)
 		if cmd.Flags().Changed("priority") {
@@ -29,6 +34,24 @@ var readyCmd = &cobra.Command{
 		if assignee != "" {
 			filter.Assignee = &assignee
 		}
+		if dueWithin != "" {
+			d, err := parseRelativeDuration(dueWithin)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --due-within: %v\n", err)
+				os.Exit(1)
+			}
+			dueBefore := time.Now().Add(d)
+			filter.DueBefore = &dueBefore
+			filter.SortByDue = true
+		}
+		switch sortBy {
+		case "priority":
+		case "due":
+			filter.SortByDue = true
+		default:
+			fmt.Fprintf(os.Stderr, "Error: invalid --sort '%s' (supported: priority, due)\n", sortBy)
+			os.Exit(1)
+		}
 
 		ctx := context.Background()
 		issues, err := store.GetReadyWork(ctx, filter)
@@ -64,6 +87,9 @@ var readyCmd = &cobra.Command{
 			if issue.Assignee != "" {
 				fmt.Printf("   Assignee: %s\n", issue.Assignee)
 			}
+			if issue.DueAt != nil {
+				fmt.Printf("   Due: %s\n", formatDate(*issue.DueAt))
+			}
 		}
 		fmt.Println()
 	},
@@ -112,6 +138,45 @@ var statsCmd = &cobra.Command{
 	Short: "Show statistics",
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
+
+		if estimates, _ := cmd.Flags().GetBool("estimates"); estimates {
+			actuals, err := store.GetEstimateActuals(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			report := buildEstimateReport(actuals)
+			if jsonOutput {
+				outputJSON(report)
+				return
+			}
+			outputEstimateReport(report)
+			return
+		}
+
+		if groupBy, _ := cmd.Flags().GetString("by"); groupBy != "" {
+			var closedSince *time.Time
+			if since, _ := cmd.Flags().GetString("since"); since != "" {
+				t, err := parseSinceFlag(since, time.Now())
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: invalid --since: %v\n", err)
+					os.Exit(1)
+				}
+				closedSince = &t
+			}
+			groups, err := store.GetStatisticsBy(ctx, groupBy, closedSince)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if jsonOutput {
+				outputJSON(groups)
+				return
+			}
+			outputStatisticsGroups(groupBy, closedSince, groups)
+			return
+		}
+
 		stats, err := store.GetStatistics(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -141,10 +206,52 @@ var statsCmd = &cobra.Command{
 	},
 }
 
+// outputStatisticsGroups prints a 'bd stats --by' breakdown as a table
+func outputStatisticsGroups(groupBy string, closedSince *time.Time, groups []*types.StatisticsGroup) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("\n%s Statistics by %s:\n\n", cyan("📊"), groupBy)
+	if len(groups) == 0 {
+		fmt.Printf("No issues\n\n")
+		return
+	}
+
+	closedHeading := "closed"
+	if closedSince != nil {
+		closedHeading = "closed*"
+	}
+	fmt.Printf("%-20s %6s %6s %12s %8s %6s %8s %10s\n", "", "total", "open", "in_progress", "blocked", "ready", closedHeading, "avg lead")
+	for _, g := range groups {
+		key := g.Key
+		if key == "" {
+			key = "(no label)"
+			if groupBy == "assignee" {
+				key = "(unassigned)"
+			}
+		}
+		lead := "-"
+		if g.ClosedIssues > 0 {
+			lead = formatHours(g.AverageLeadTime)
+		}
+		fmt.Printf("%-20s %6d %6d %12d %8d %6d %8d %10s\n", key, g.TotalIssues, g.OpenIssues,
+			g.InProgressIssues, g.BlockedIssues, g.ReadyIssues, g.ClosedIssues, lead)
+	}
+	if closedSince != nil {
+		fmt.Printf("\n* Closed since %s; average lead time covers the same issues.\n", closedSince.Local().Format("2006-01-02 15:04"))
+	}
+	fmt.Println()
+}
+
 func init() {
 	readyCmd.Flags().IntP("limit", "n", 10, "Maximum issues to show")
 	readyCmd.Flags().IntP("priority", "p", 0, "Filter by priority")
 	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	readyCmd.Flags().String("due-within", "", "Only issues due within this window, soonest first (e.g., 3d, 2w)")
+	readyCmd.Flags().String("sort", "priority", "Sort order: 'priority' or 'due' (undated issues last)")
+	readyCmd.Flags().Bool("include-deferred", false, "Include issues deferred to a future date")
+	statsCmd.Flags().String("by", "", "Group by 'label', 'assignee', 'type' or 'priority'")
+	statsCmd.Flags().String("since", "", "With --by, only count issues closed since this date or window (7d, 2w)")
+	statsCmd.Flags().Bool("estimates", false, "Compare estimated and actual time on closed issues by type, label and assignee")
 
 	rootCmd.AddCommand(readyCmd)
 	rootCmd.AddCommand(blockedCmd)
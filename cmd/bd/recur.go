@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/recur"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// firstOccurrence returns the first time a recurrence rule yields after now. Used as
+// the due date of a new recurring issue when none is given.
+func firstOccurrence(rule string) (time.Time, error) {
+	sched, err := recur.Parse(rule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	first := sched.Next(time.Now())
+	if first.IsZero() {
+		return time.Time{}, fmt.Errorf("recurrence %q has no upcoming occurrences", rule)
+	}
+	return first, nil
+}
+
+// spawnNextOccurrence creates the next instance of a just-closed recurring issue.
+// Returns nil if the issue does not recur; failures are reported as warnings since
+// the close itself succeeded.
+func spawnNextOccurrence(ctx context.Context, id string) *types.Issue {
+	spawned, err := store.SpawnNextOccurrence(ctx, id, actor)
+	if err != nil {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Fprintf(os.Stderr, "%s Failed to spawn next occurrence of %s: %v\n", yellow("⚠"), id, err)
+		return nil
+	}
+	return spawned
+}
+
+// printSpawnedOccurrence reports a spawned recurring issue
+func printSpawnedOccurrence(issue *types.Issue) {
+	if issue == nil {
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	due := ""
+	if issue.DueAt != nil {
+		due = fmt.Sprintf(" (due %s)", formatDate(*issue.DueAt))
+	}
+	fmt.Printf("%s Next occurrence: %s%s\n", green("↻"), issue.ID, due)
+}
+
+var recurCmd = &cobra.Command{
+	Use:   "recur",
+	Short: "Manage recurring issues",
+	Long: `Manage recurring issues.
+
+An issue created with --recur repeats on a schedule. When it is closed (or its
+due date passes while the daemon is running) a copy is created, due at the next
+occurrence and linked to the previous instance with a related dependency. The
+recurrence rule moves to the new issue.
+
+Rules:
+  daily, weekly, monthly, yearly
+  every 3d, every 2w, every 12h
+  0 9 * * 1          (cron: minute hour day-of-month month day-of-week)
+
+Examples:
+  bd create "Rotate credentials" --recur monthly
+  bd create "Team sync notes" --recur "0 9 * * 1" --due "2025-11-03 09:00"
+  bd update bd-12 --recur ""        # stop repeating`,
+}
+
+var recurListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring issues and their upcoming occurrences",
+	Run: func(cmd *cobra.Command, args []string) {
+		count, _ := cmd.Flags().GetInt("next")
+
+		ctx := context.Background()
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Recurring: true})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		type recurringIssue struct {
+			ID         string      `json:"id"`
+			Title      string      `json:"title"`
+			Status     string      `json:"status"`
+			Recurrence string      `json:"recurrence"`
+			DueAt      *time.Time  `json:"due_at,omitempty"`
+			Upcoming   []time.Time `json:"upcoming"`
+			Error      string      `json:"error,omitempty"`
+		}
+		results := make([]recurringIssue, 0, len(issues))
+		for _, issue := range issues {
+			r := recurringIssue{
+				ID:         issue.ID,
+				Title:      issue.Title,
+				Status:     string(issue.Status),
+				Recurrence: issue.Recurrence,
+				DueAt:      issue.DueAt,
+				Upcoming:   []time.Time{},
+			}
+			sched, err := recur.Parse(issue.Recurrence)
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				from := time.Now()
+				if issue.DueAt != nil {
+					from = issue.DueAt.Local()
+				}
+				r.Upcoming = recur.Upcoming(sched, from, count)
+			}
+			results = append(results, r)
+		}
+
+		if jsonOutput {
+			outputJSON(results)
+			return
+		}
+
+		if len(results) == 0 {
+			fmt.Println("No recurring issues")
+			return
+		}
+
+		cyan := color.New(color.FgCyan).SprintFunc()
+		yellow := color.New(color.FgYellow).SprintFunc()
+		for _, r := range results {
+			fmt.Printf("%s %s [%s]\n", cyan(r.ID), r.Title, r.Recurrence)
+			if r.Error != "" {
+				fmt.Printf("  %s %s\n", yellow("⚠"), r.Error)
+				continue
+			}
+			if r.DueAt != nil {
+				fmt.Printf("  Due: %s\n", formatDate(*r.DueAt))
+			}
+			if len(r.Upcoming) > 0 {
+				dates := make([]string, len(r.Upcoming))
+				for i, t := range r.Upcoming {
+					dates[i] = formatDate(t)
+				}
+				fmt.Printf("  Then: %s\n", strings.Join(dates, ", "))
+			}
+		}
+	},
+}
+
+func init() {
+	recurListCmd.Flags().IntP("next", "n", 3, "Number of upcoming occurrences to show")
+	recurCmd.AddCommand(recurListCmd)
+	rootCmd.AddCommand(recurCmd)
+}
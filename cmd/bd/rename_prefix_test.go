@@ -106,6 +106,13 @@ func TestRenamePrefixCommand(t *testing.T) {
 		t.Fatalf("Failed to add dependency: %v", err)
 	}
 
+	if err := testStore.AddWorkLog(ctx, &types.WorkLog{IssueID: "old-1", Actor: "alice", Minutes: 30}, "test"); err != nil {
+		t.Fatalf("Failed to add work log: %v", err)
+	}
+	if _, err := testStore.StartWorkLog(ctx, "old-2", "alice", ""); err != nil {
+		t.Fatalf("Failed to start timer: %v", err)
+	}
+
 	issues := []*types.Issue{issue1, issue2, issue3}
 	if err := renamePrefixInDB(ctx, "old", "new", issues); err != nil {
 		t.Fatalf("renamePrefixInDB failed: %v", err)
@@ -160,6 +167,17 @@ func TestRenamePrefixCommand(t *testing.T) {
 		t.Errorf("Expected dependency ID 'new-2', got %q", deps[0].ID)
 	}
 
+	logs, err := testStore.GetWorkLogs(ctx, "new-1")
+	if err != nil {
+		t.Fatalf("Failed to get work logs: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Minutes != 30 {
+		t.Errorf("Expected the 30-minute work log on new-1, got %+v", logs)
+	}
+	if _, err := testStore.StopWorkLog(ctx, "new-2", "alice"); err != nil {
+		t.Errorf("Expected the running timer to move to new-2: %v", err)
+	}
+
 	oldIssue, err := testStore.GetIssue(ctx, "old-1")
 	if err == nil && oldIssue != nil {
 		t.Errorf("Expected old-1 to not exist, but got: %+v", oldIssue)
@@ -2,165 +2,197 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
 
+var restoreApply bool
+
 var restoreCmd = &cobra.Command{
 	Use:   "restore <issue-id>",
-	Short: "Restore full history of a compacted issue from git",
-	Long: `Restore full history of a compacted issue from git version control.
+	Short: "Restore the original content of a compacted issue",
+	Long: `Restore the original content of a compacted issue.
 
-When an issue is compacted, the git commit hash is saved. This command:
-1. Reads the compacted_at_commit from the database
-2. Checks out that commit temporarily
-3. Reads the full issue from JSONL at that point in history
-4. Displays the full issue history (description, events, etc.)
-5. Returns to the current git state
+The original is read from the snapshot saved locally when the issue was compacted,
+including any events archived by Tier 2. If there is no snapshot (for example in a
+fresh clone), the issue is read from the JSONL file as it was at the git commit saved
+during compaction, using 'git show' so the working tree is never touched.
 
-This is read-only and does not modify the database or git state.`,
+By default this only displays the original. With --apply the issue is un-compacted:
+its description, design, notes and acceptance criteria are restored in the database,
+archived events return to the audit trail and the compaction level is reset.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		issueID := args[0]
 		ctx := context.Background()
 
-		// Check if we're in a git repository
-		if !isGitRepo() {
-			fmt.Fprintf(os.Stderr, "Error: not in a git repository\n")
-			fmt.Fprintf(os.Stderr, "Hint: restore requires git to access historical versions\n")
-			os.Exit(1)
-		}
-
 		// Get the issue
 		issue, err := store.GetIssue(ctx, issueID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: issue %s not found: %v\n", issueID, err)
 			os.Exit(1)
 		}
-
-		// Check if issue is compacted
-		if issue.CompactedAtCommit == nil || *issue.CompactedAtCommit == "" {
-			fmt.Fprintf(os.Stderr, "Error: issue %s is not compacted (no git commit saved)\n", issueID)
-			fmt.Fprintf(os.Stderr, "Hint: only compacted issues can be restored from git history\n")
-			os.Exit(1)
-		}
-
-		commitHash := *issue.CompactedAtCommit
-
-		// Find JSONL path
-		jsonlPath := findJSONLPath()
-		if jsonlPath == "" {
-			fmt.Fprintf(os.Stderr, "Error: not in a bd workspace (no .beads directory found)\n")
+		if issue == nil {
+			fmt.Fprintf(os.Stderr, "Error: issue %s not found\n", issueID)
 			os.Exit(1)
 		}
 
-		// Get current git HEAD for restoration
-		currentHead, err := getCurrentGitHead()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: cannot determine current git HEAD: %v\n", err)
+		// Check if issue is compacted
+		if issue.CompactionLevel == 0 && (issue.CompactedAtCommit == nil || *issue.CompactedAtCommit == "") {
+			fmt.Fprintf(os.Stderr, "Error: issue %s is not compacted\n", issueID)
 			os.Exit(1)
 		}
 
-		// Check for uncommitted changes
-		hasChanges, err := gitHasUncommittedChanges()
+		original, archived, source, err := findCompactedOriginal(ctx, issue)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error checking git status: %v\n", err)
-			os.Exit(1)
-		}
-		if hasChanges {
-			fmt.Fprintf(os.Stderr, "Error: you have uncommitted changes\n")
-			fmt.Fprintf(os.Stderr, "Hint: commit or stash changes before running restore\n")
-			os.Exit(1)
-		}
-
-		// Checkout the historical commit
-		if err := gitCheckout(commitHash); err != nil {
-			fmt.Fprintf(os.Stderr, "Error checking out commit %s: %v\n", commitHash, err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		// Ensure we return to current state
-		defer func() {
-			if err := gitCheckout(currentHead); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to return to %s: %v\n", currentHead, err)
+		if restoreApply {
+			sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: restore --apply requires SQLite storage\n")
+				os.Exit(1)
 			}
-		}()
-
-		// Read the issue from JSONL at this commit
-		historicalIssue, err := readIssueFromJSONL(jsonlPath, issueID)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading issue from historical JSONL: %v\n", err)
-			os.Exit(1)
+			if err := sqliteStore.RestoreCompaction(ctx, original, archived, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			markDirtyAndScheduleFlush()
 		}
 
-		if historicalIssue == nil {
-			fmt.Fprintf(os.Stderr, "Error: issue %s not found in JSONL at commit %s\n", issueID, commitHash)
-			os.Exit(1)
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue":           original,
+				"source":          source,
+				"archived_events": archived,
+				"applied":         restoreApply,
+			})
+			return
 		}
 
 		// Display the restored issue
-		displayRestoredIssue(historicalIssue, commitHash)
+		displayRestoredIssue(original, source, archived)
+		if restoreApply {
+			green := color.New(color.FgGreen).SprintFunc()
+			fmt.Printf("%s Restored %s from %s", green("✓"), issueID, source)
+			if len(archived) > 0 {
+				fmt.Printf(" (%d archived events back in the audit trail)", len(archived))
+			}
+			fmt.Println()
+		}
 	},
 }
 
 func init() {
+	restoreCmd.Flags().BoolVar(&restoreApply, "apply", false, "Un-compact the issue in the database instead of only displaying it")
 	rootCmd.AddCommand(restoreCmd)
 }
 
-// getCurrentGitHead returns the current HEAD reference (branch or commit)
-func getCurrentGitHead() (string, error) {
-	// Try to get symbolic ref (branch name) first
-	cmd := exec.Command("git", "symbolic-ref", "--short", "HEAD")
-	if output, err := cmd.Output(); err == nil {
-		return strings.TrimSpace(string(output)), nil
+// findCompactedOriginal returns an issue as it was before compaction along with the
+// events archived from it, and a description of where it was found. Local snapshots
+// are preferred; otherwise the JSONL at the compaction commit is read from git.
+func findCompactedOriginal(ctx context.Context, issue *types.Issue) (*types.Issue, []*types.Event, string, error) {
+	if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+		snapshots, err := sqliteStore.GetSnapshots(ctx, issue.ID)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if len(snapshots) > 0 {
+			var archived []*types.Event
+			for _, snap := range snapshots {
+				archived = append(archived, snap.ArchivedEvents...)
+			}
+			sort.SliceStable(archived, func(i, j int) bool { return archived[i].CreatedAt.Before(archived[j].CreatedAt) })
+			// The first snapshot was taken before Tier 1, so it holds the full original
+			return snapshots[0].Issue, archived, "local snapshot", nil
+		}
+	}
+
+	if issue.CompactedAtCommit == nil || *issue.CompactedAtCommit == "" {
+		return nil, nil, "", fmt.Errorf("no local snapshot or git commit saved for %s", issue.ID)
 	}
+	commitHash := *issue.CompactedAtCommit
 
-	// If not on a branch, get commit hash
-	cmd = exec.Command("git", "rev-parse", "HEAD")
-	output, err := cmd.Output()
+	if !isGitRepo() {
+		return nil, nil, "", fmt.Errorf("no local snapshot of %s and not in a git repository to read commit %s", issue.ID, shortCommit(commitHash))
+	}
+	jsonlPath := findJSONLPath()
+	if jsonlPath == "" {
+		return nil, nil, "", fmt.Errorf("not in a bd workspace (no .beads directory found)")
+	}
+
+	data, err := gitShowFile(commitHash, jsonlPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to get HEAD: %w", err)
+		return nil, nil, "", err
+	}
+	historicalIssue, err := readIssueFromJSONL(bytes.NewReader(data), issue.ID)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to read historical JSONL: %w", err)
+	}
+	if historicalIssue == nil {
+		return nil, nil, "", fmt.Errorf("issue %s not found in JSONL at commit %s", issue.ID, shortCommit(commitHash))
 	}
-	return strings.TrimSpace(string(output)), nil
+	return historicalIssue, nil, "git commit " + shortCommit(commitHash), nil
 }
 
-// gitHasUncommittedChanges checks for any uncommitted changes
-func gitHasUncommittedChanges() (bool, error) {
-	cmd := exec.Command("git", "status", "--porcelain")
-	output, err := cmd.Output()
+// gitShowFile returns the contents of path as of commit without checking it out
+func gitShowFile(commit, path string) ([]byte, error) {
+	out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
 	if err != nil {
-		return false, fmt.Errorf("git status failed: %w", err)
+		return nil, fmt.Errorf("failed to find git repository root: %w", err)
+	}
+	root := strings.TrimSpace(string(out))
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if resolved, err := filepath.EvalSymlinks(filepath.Dir(absPath)); err == nil {
+		absPath = filepath.Join(resolved, filepath.Base(absPath))
+	}
+	relPath, err := filepath.Rel(root, absPath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return nil, fmt.Errorf("%s is outside the git repository", path)
 	}
-	return len(strings.TrimSpace(string(output))) > 0, nil
-}
 
-// gitCheckout checks out a specific commit or branch
-func gitCheckout(ref string) error {
-	cmd := exec.Command("git", "checkout", ref)
-	output, err := cmd.CombinedOutput()
+	cmd := exec.Command("git", "show", commit+":"+filepath.ToSlash(relPath))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	data, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("git checkout failed: %w\n%s", err, output)
+		return nil, fmt.Errorf("git show %s:%s failed: %s", shortCommit(commit), filepath.ToSlash(relPath), strings.TrimSpace(stderr.String()))
 	}
-	return nil
+	return data, nil
 }
 
-// readIssueFromJSONL reads a specific issue from JSONL file
-func readIssueFromJSONL(jsonlPath, issueID string) (*types.Issue, error) {
-	file, err := os.Open(jsonlPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open JSONL: %w", err)
+// shortCommit abbreviates a commit hash for display
+func shortCommit(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
 	}
-	defer file.Close()
+	return hash
+}
 
-	scanner := bufio.NewScanner(file)
+// readIssueFromJSONL reads a specific issue from JSONL content
+func readIssueFromJSONL(r io.Reader, issueID string) (*types.Issue, error) {
+	scanner := bufio.NewScanner(r)
 	// Increase buffer size for large issues
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, 10*1024*1024) // 10MB max
@@ -183,13 +215,13 @@ func readIssueFromJSONL(jsonlPath, issueID string) (*types.Issue, error) {
 }
 
 // displayRestoredIssue displays the restored issue in a readable format
-func displayRestoredIssue(issue *types.Issue, commitHash string) {
+func displayRestoredIssue(issue *types.Issue, source string, archived []*types.Event) {
 	cyan := color.New(color.FgCyan).SprintFunc()
 	green := color.New(color.FgGreen).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 	bold := color.New(color.Bold).SprintFunc()
 
-	fmt.Printf("\n%s %s (restored from git commit %s)\n", cyan("📜"), bold(issue.ID), yellow(commitHash[:8]))
+	fmt.Printf("\n%s %s (restored from %s)\n", cyan("📜"), bold(issue.ID), yellow(source))
 	fmt.Printf("%s\n\n", bold(issue.Title))
 
 	if issue.Description != "" {
@@ -235,6 +267,17 @@ func displayRestoredIssue(issue *types.Issue, commitHash string) {
 		}
 	}
 
+	if len(archived) > 0 {
+		fmt.Printf("\n%s\n", bold(fmt.Sprintf("Archived events (%d):", len(archived))))
+		for _, event := range archived {
+			fmt.Printf("  %s %s %s", event.CreatedAt.Format("2006-01-02 15:04"), event.EventType, event.Actor)
+			if event.Comment != nil {
+				fmt.Printf(": %s", *event.Comment)
+			}
+			fmt.Println()
+		}
+	}
+
 	if issue.CompactionLevel > 0 {
 		fmt.Printf("\n%s Level %d", yellow("⚠️  This issue was compacted:"), issue.CompactionLevel)
 		if issue.CompactedAt != nil {
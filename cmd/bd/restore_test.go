@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/compact"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRestoreFromSnapshot(t *testing.T) {
+	testStore, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer testStore.Close()
+
+	oldStore := store
+	store = testStore
+	defer func() { store = oldStore }()
+
+	ctx := context.Background()
+	for key, value := range map[string]string{"compact_tier1_days": "0", "compact_tier2_days": "0", "compact_tier2_commits": "1"} {
+		if err := testStore.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+
+	closedAt := time.Now().Add(-48 * time.Hour)
+	original := &types.Issue{
+		ID:                 "test-1",
+		Title:              "Fix session expiry",
+		Description:        strings.Repeat("Sessions expired early for users behind the corporate proxy. ", 6),
+		Design:             "Set SameSite=Lax explicitly",
+		AcceptanceCriteria: "- Sessions last 24h",
+		Notes:              strings.Repeat("Debugging notes. ", 20),
+		Status:             types.StatusClosed,
+		Priority:           1,
+		IssueType:          types.TypeBug,
+		CreatedAt:          closedAt.Add(-time.Hour),
+		UpdatedAt:          closedAt,
+		ClosedAt:           &closedAt,
+	}
+	if err := testStore.CreateIssue(ctx, original, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := testStore.AddComment(ctx, original.ID, "alice", "Proxy strips SameSite"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	compactor, err := compact.New(testStore, "", &compact.CompactConfig{Provider: compact.ProviderOffline})
+	if err != nil {
+		t.Fatalf("compact.New failed: %v", err)
+	}
+	if err := compactor.CompactTier1(ctx, original.ID); err != nil {
+		t.Fatalf("CompactTier1 failed: %v", err)
+	}
+	if err := compactor.CompactTier2(ctx, original.ID); err != nil {
+		t.Fatalf("CompactTier2 failed: %v", err)
+	}
+
+	issue, _ := testStore.GetIssue(ctx, original.ID)
+	restored, archived, source, err := findCompactedOriginal(ctx, issue)
+	if err != nil {
+		t.Fatalf("findCompactedOriginal failed: %v", err)
+	}
+	if source != "local snapshot" || restored.Notes != original.Notes || restored.Design != original.Design {
+		t.Errorf("Expected the pre-Tier 1 content from the local snapshot, got %s: %+v", source, restored)
+	}
+	var comment bool
+	for i, e := range archived {
+		comment = comment || (e.Comment != nil && *e.Comment == "Proxy strips SameSite")
+		if i > 0 && e.CreatedAt.Before(archived[i-1].CreatedAt) {
+			t.Error("Archived events should be in chronological order")
+		}
+	}
+	if !comment {
+		t.Errorf("Expected Alice's comment among the %d archived events", len(archived))
+	}
+
+	// --apply un-compacts the issue
+	if err := testStore.RestoreCompaction(ctx, restored, archived, "test"); err != nil {
+		t.Fatalf("RestoreCompaction failed: %v", err)
+	}
+	issue, _ = testStore.GetIssue(ctx, original.ID)
+	if issue.CompactionLevel != 0 || issue.Description != original.Description || issue.Notes != original.Notes ||
+		issue.AcceptanceCriteria != original.AcceptanceCriteria || issue.CompactedAtCommit != nil {
+		t.Errorf("Expected original content and no compaction metadata, got %+v", issue)
+	}
+	events, _ := testStore.GetEvents(ctx, original.ID, 0)
+	var restoredComment bool
+	for _, e := range events {
+		restoredComment = restoredComment || (e.Comment != nil && *e.Comment == "Proxy strips SameSite")
+	}
+	if !restoredComment {
+		t.Error("Expected archived events back in the audit trail")
+	}
+	if snapshots, _ := testStore.GetSnapshots(ctx, original.ID); len(snapshots) != 0 {
+		t.Errorf("Expected snapshots to be removed after restoring, got %d", len(snapshots))
+	}
+	if eligible, _, _ := testStore.CheckEligibility(ctx, original.ID, 1); !eligible {
+		t.Error("Restored issue should be eligible for compaction again")
+	}
+}
+
+func TestRestoreFromGitWithoutCheckout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("Failed to create .beads: %v", err)
+	}
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	original := &types.Issue{ID: "test-1", Title: "Old issue", Description: "Full original description", Notes: "Original notes",
+		Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask}
+	line, _ := json.Marshal(original)
+	jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(jsonlPath, append(line, '\n'), 0644); err != nil {
+		t.Fatalf("Failed to write JSONL: %v", err)
+	}
+	git("init", "-q")
+	git("add", ".beads/issues.jsonl")
+	git("commit", "-q", "-m", "issues")
+	commit := git("rev-parse", "HEAD")
+
+	// Uncommitted changes in the working tree must not matter and must survive
+	if err := os.WriteFile(jsonlPath, []byte(`{"id":"test-1","title":"Old issue","description":"Summary"}`+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write JSONL: %v", err)
+	}
+
+	testStore, err := sqlite.New(filepath.Join(beadsDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer testStore.Close()
+
+	oldStore, oldDBPath := store, dbPath
+	store, dbPath = testStore, filepath.Join(beadsDir, "test.db")
+	defer func() { store, dbPath = oldStore, oldDBPath }()
+
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to chdir: %v", err)
+	}
+	defer os.Chdir(oldDir)
+
+	compacted := &types.Issue{ID: "test-1", Description: "Summary", CompactionLevel: 1, CompactedAtCommit: &commit}
+	restored, archived, source, err := findCompactedOriginal(context.Background(), compacted)
+	if err != nil {
+		t.Fatalf("findCompactedOriginal failed: %v", err)
+	}
+	if restored.Description != "Full original description" || restored.Notes != "Original notes" || len(archived) != 0 {
+		t.Errorf("Unexpected issue from git: %+v", restored)
+	}
+	if source != "git commit "+commit[:8] {
+		t.Errorf("Unexpected source %q", source)
+	}
+
+	data, _ := os.ReadFile(jsonlPath)
+	if !strings.Contains(string(data), `"description":"Summary"`) {
+		t.Error("Working tree JSONL should be untouched")
+	}
+	if head := git("rev-parse", "HEAD"); head != commit {
+		t.Errorf("HEAD moved to %s", head)
+	}
+}
+
+func TestRestoreFromGitAfterTier2(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("Failed to create .beads: %v", err)
+	}
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+
+	testStore, err := sqlite.New(filepath.Join(beadsDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer testStore.Close()
+
+	oldStore, oldDBPath := store, dbPath
+	store, dbPath = testStore, filepath.Join(beadsDir, "test.db")
+	defer func() { store, dbPath = oldStore, oldDBPath }()
+
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to chdir: %v", err)
+	}
+	defer os.Chdir(oldDir)
+
+	ctx := context.Background()
+	for key, value := range map[string]string{"compact_tier1_days": "0", "compact_tier2_days": "0", "compact_tier2_commits": "1"} {
+		if err := testStore.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+	closedAt := time.Now().Add(-48 * time.Hour)
+	original := &types.Issue{
+		ID:          "test-1",
+		Title:       "Fix session expiry",
+		Description: strings.Repeat("Sessions expired early for users behind the corporate proxy. ", 6),
+		Notes:       strings.Repeat("Debugging notes. ", 20),
+		Status:      types.StatusClosed,
+		Priority:    1,
+		IssueType:   types.TypeBug,
+		CreatedAt:   closedAt.Add(-time.Hour),
+		UpdatedAt:   closedAt,
+		ClosedAt:    &closedAt,
+	}
+	if err := testStore.CreateIssue(ctx, original, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := testStore.AddComment(ctx, original.ID, "alice", "Proxy strips SameSite"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+	commit := func(message string) string {
+		t.Helper()
+		if err := exportToJSONL(ctx, jsonlPath); err != nil {
+			t.Fatalf("exportToJSONL failed: %v", err)
+		}
+		git("add", ".beads/issues.jsonl")
+		git("commit", "-q", "-m", message)
+		return git("rev-parse", "HEAD")
+	}
+	originalCommit := commit("original")
+
+	compactor, err := compact.New(testStore, "", &compact.CompactConfig{Provider: compact.ProviderOffline})
+	if err != nil {
+		t.Fatalf("compact.New failed: %v", err)
+	}
+	if err := compactor.CompactTier1(ctx, original.ID); err != nil {
+		t.Fatalf("CompactTier1 failed: %v", err)
+	}
+	commit("tier 1")
+	if err := compactor.CompactTier2(ctx, original.ID); err != nil {
+		t.Fatalf("CompactTier2 failed: %v", err)
+	}
+	commit("tier 2")
+
+	compacted, err := testStore.GetIssue(ctx, original.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if compacted.CompactionLevel != 2 || compacted.CompactedAtCommit == nil || *compacted.CompactedAtCommit != originalCommit {
+		t.Fatalf("Expected Tier 2 to keep the Tier 1 commit %s, got %+v", originalCommit, compacted)
+	}
+	if compacted.OriginalSize != len(original.Description)+len(original.Notes) {
+		t.Errorf("Expected Tier 2 to keep the original size, got %d", compacted.OriginalSize)
+	}
+
+	// Without local snapshots the original comes from the JSONL at the saved commit
+	cloneStore, err := sqlite.New(filepath.Join(t.TempDir(), "clone.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer cloneStore.Close()
+	store = cloneStore
+
+	restored, _, source, err := findCompactedOriginal(ctx, compacted)
+	if err != nil {
+		t.Fatalf("findCompactedOriginal failed: %v", err)
+	}
+	if source != "git commit "+originalCommit[:8] {
+		t.Errorf("Unexpected source %q", source)
+	}
+	if restored.Description != original.Description || restored.Notes != original.Notes {
+		t.Errorf("Expected the full original from git, got %+v", restored)
+	}
+}
@@ -0,0 +1,676 @@
+// This file implements 'bd site', which renders the database as a static HTML site
+// that can be browsed without bd installed.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// siteLink is a related issue as shown on an issue page
+type siteLink struct {
+	ID     string
+	Title  string
+	Status types.Status
+	Type   types.DependencyType
+}
+
+// siteIssue is everything an issue page shows
+type siteIssue struct {
+	*types.Issue
+	Labels     []string
+	Deps       []siteLink
+	Dependents []siteLink
+	Events     []*types.Event
+	Epic       *types.EpicStatus
+}
+
+// siteEpic is an epic with its progress and descendants
+type siteEpic struct {
+	*types.Issue
+	Status      *types.EpicStatus
+	Descendants []*types.TreeNode
+}
+
+// sitePage is the data every page template receives
+type sitePage struct {
+	SiteTitle string
+	Title     string
+	Root      string // Relative path from the page to the site root
+	Generated time.Time
+	Body      interface{}
+}
+
+// siteIndex is the data for index.html
+type siteIndex struct {
+	Issues     []*siteIssue
+	Statuses   []types.Status
+	Types      []types.IssueType
+	Assignees  []string
+	Labels     []string
+	Priorities []int
+}
+
+// siteFileName turns an issue ID into a safe file name
+func siteFileName(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, id) + ".html"
+}
+
+var siteFuncs = template.FuncMap{
+	"issueURL": func(root, id string) string { return root + "issues/" + siteFileName(id) },
+	"epicURL":  func(root, id string) string { return root + "epics/" + siteFileName(id) },
+	"time":     func(t time.Time) string { return t.Local().Format("2006-01-02 15:04") },
+	"date":     func(t *time.Time) string { return formatDate(*t) },
+	"minutes":  formatMinutes,
+	"pct":      func(f float64) string { return fmt.Sprintf("%.0f%%", f) },
+	"count":    func(m map[types.Status]int, s types.Status) int { return m[s] },
+	"indent":   func(depth int) int { return (depth - 1) * 20 },
+	// filterAttr joins values as "|a|b|" so the index script can match whole values
+	"filterAttr": func(values interface{}) string {
+		if list, ok := values.([]string); ok {
+			return "|" + strings.Join(list, "|") + "|"
+		}
+		return fmt.Sprintf("|%v|", values)
+	},
+	"search": func(issue *siteIssue) string {
+		return strings.ToLower(strings.Join(append([]string{issue.ID, issue.Title, issue.Assignee}, issue.Labels...), " "))
+	},
+	"deref": func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	},
+	"eventDetail": siteEventDetail,
+	"derefInt":    func(n *int) int { return *n },
+	"statuses": func() []types.Status {
+		return []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusClosed}
+	},
+}
+
+// siteEventDetail describes an event for the history table: its comment, or the
+// fields an update changed. Creation snapshots are left out.
+func siteEventDetail(e *types.Event) string {
+	if e.Comment != nil {
+		return *e.Comment
+	}
+	if e.NewValue == nil || e.EventType == types.EventCreated {
+		return ""
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(*e.NewValue), &fields); err != nil {
+		return *e.NewValue
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := fmt.Sprint(fields[key])
+		if utf8.RuneCountInString(value) > 80 {
+			value = string([]rune(value)[:79]) + "…"
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", key, value))
+	}
+	return strings.Join(parts, ", ")
+}
+
+var siteTemplates = template.Must(template.New("site").Funcs(siteFuncs).Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} · {{.SiteTitle}}</title>
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body>
+<nav><strong>{{.SiteTitle}}</strong> <a href="{{.Root}}index.html">Issues</a> <a href="{{.Root}}epics/index.html">Epics</a> <a href="{{.Root}}graph.html">Dependency graph</a></nav>
+<main>
+{{end}}
+
+{{define "footer"}}</main>
+<footer>Generated by bd on {{time .Generated}}</footer>
+</body>
+</html>
+{{end}}
+
+{{define "status"}}<span class="status {{.}}">{{.}}</span>{{end}}
+
+{{define "progress"}}<span class="progress"><span style="width: {{pct .}}"></span></span>{{end}}
+
+{{define "index"}}{{template "header" .}}{{$root := .Root}}
+<h1>Issues</h1>
+<form class="filters" onsubmit="return false">
+<input id="q" type="search" placeholder="Search ID, title, assignee or label" autofocus>
+<select data-filter="status"><option value="">Any status</option><option value="!closed">Not closed</option>{{range .Body.Statuses}}<option>{{.}}</option>{{end}}</select>
+<select data-filter="type"><option value="">Any type</option>{{range .Body.Types}}<option>{{.}}</option>{{end}}</select>
+<select data-filter="priority"><option value="">Any priority</option>{{range $p := .Body.Priorities}}<option value="{{$p}}">P{{$p}}</option>{{end}}</select>
+<select data-filter="assignee"><option value="">Any assignee</option>{{range .Body.Assignees}}<option>{{.}}</option>{{end}}</select>
+<select data-filter="labels"><option value="">Any label</option>{{range .Body.Labels}}<option>{{.}}</option>{{end}}</select>
+<span id="count">{{len .Body.Issues}}</span> issues
+</form>
+<table id="issues">
+<thead><tr><th>ID</th><th>Title</th><th>Status</th><th>Priority</th><th>Type</th><th>Assignee</th><th>Labels</th><th>Updated</th></tr></thead>
+<tbody>
+{{range .Body.Issues}}<tr data-search="{{search .}}" data-status="{{filterAttr .Status}}" data-type="{{filterAttr .IssueType}}" data-priority="{{filterAttr .Priority}}" data-assignee="{{filterAttr .Assignee}}" data-labels="{{filterAttr .Labels}}">
+<td><a href="{{issueURL $root .ID}}">{{.ID}}</a></td><td><a href="{{issueURL $root .ID}}">{{.Title}}</a></td><td>{{template "status" .Status}}</td><td>P{{.Priority}}</td><td>{{.IssueType}}</td><td>{{.Assignee}}</td><td>{{range .Labels}}<span class="label">{{.}}</span> {{end}}</td><td>{{time .UpdatedAt}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+<script>
+(function () {
+  var q = document.getElementById("q");
+  var selects = document.querySelectorAll("select[data-filter]");
+  var rows = document.querySelectorAll("#issues tbody tr");
+  function apply() {
+    var text = q.value.toLowerCase(), shown = 0;
+    rows.forEach(function (row) {
+      var ok = row.dataset.search.indexOf(text) >= 0;
+      selects.forEach(function (s) {
+        var v = s.value, values = row.dataset[s.dataset.filter];
+        if (v.charAt(0) === "!") {
+          ok = ok && values.indexOf("|" + v.slice(1) + "|") < 0;
+        } else if (v) {
+          ok = ok && values.indexOf("|" + v + "|") >= 0;
+        }
+      });
+      row.hidden = !ok;
+      if (ok) shown++;
+    });
+    document.getElementById("count").textContent = shown;
+  }
+  q.addEventListener("input", apply);
+  selects.forEach(function (s) { s.addEventListener("change", apply); });
+})();
+</script>
+{{template "footer" .}}{{end}}
+
+{{define "issue"}}{{template "header" .}}{{$root := .Root}}{{with .Body}}
+<h1><span class="id">{{.ID}}</span> {{.Title}}</h1>
+<table class="meta">
+<tr><th>Status</th><td>{{template "status" .Status}}{{with .ClosedAt}} on {{time .}}{{end}}</td></tr>
+<tr><th>Priority</th><td>P{{.Priority}}</td></tr>
+<tr><th>Type</th><td>{{.IssueType}}</td></tr>
+{{if .Assignee}}<tr><th>Assignee</th><td>{{.Assignee}}</td></tr>{{end}}
+{{if .Labels}}<tr><th>Labels</th><td>{{range .Labels}}<span class="label">{{.}}</span> {{end}}</td></tr>{{end}}
+{{if .EstimatedMinutes}}<tr><th>Estimate</th><td>{{minutes (derefInt .EstimatedMinutes)}}</td></tr>{{end}}
+{{if .DueAt}}<tr><th>Due</th><td>{{date .DueAt}}</td></tr>{{end}}
+{{if .ExternalRef}}<tr><th>External ref</th><td>{{deref .ExternalRef}}</td></tr>{{end}}
+<tr><th>Created</th><td>{{time .CreatedAt}}</td></tr>
+<tr><th>Updated</th><td>{{time .UpdatedAt}}</td></tr>
+</table>
+{{with .Epic}}<h2>Progress</h2>
+<p>{{template "progress" .PercentComplete}} {{pct .PercentComplete}} of {{.TotalChildren}} child issues closed · <a href="{{epicURL $root .EpicID}}">Epic details</a></p>{{end}}
+{{if .Description}}<h2>Description</h2><div class="text">{{.Description}}</div>{{end}}
+{{if .Design}}<h2>Design</h2><div class="text">{{.Design}}</div>{{end}}
+{{if .AcceptanceCriteria}}<h2>Acceptance Criteria</h2><div class="text">{{.AcceptanceCriteria}}</div>{{end}}
+{{if .Notes}}<h2>Notes</h2><div class="text">{{.Notes}}</div>{{end}}
+{{if .Deps}}<h2>Depends on ({{len .Deps}})</h2>
+<ul>{{range .Deps}}<li><a href="{{issueURL $root .ID}}">{{.ID}}</a> {{.Title}} {{template "status" .Status}} <span class="dep">{{.Type}}</span></li>{{end}}</ul>{{end}}
+{{if .Dependents}}<h2>Dependents ({{len .Dependents}})</h2>
+<ul>{{range .Dependents}}<li><a href="{{issueURL $root .ID}}">{{.ID}}</a> {{.Title}} {{template "status" .Status}} <span class="dep">{{.Type}}</span></li>{{end}}</ul>{{end}}
+{{if .Events}}<h2>History</h2>
+<table class="history">
+{{range .Events}}<tr><td>{{time .CreatedAt}}</td><td>{{.Actor}}</td><td>{{.EventType}}{{with eventDetail .}}<div class="text">{{.}}</div>{{end}}</td></tr>
+{{end}}</table>{{end}}
+{{end}}{{template "footer" .}}{{end}}
+
+{{define "epics"}}{{template "header" .}}{{$root := .Root}}
+<h1>Epics</h1>
+{{if not .Body}}<p>No epics.</p>{{else}}
+<table>
+<thead><tr><th>ID</th><th>Title</th><th>Status</th><th>Progress</th><th>Children</th></tr></thead>
+<tbody>
+{{range .Body}}<tr><td><a href="{{epicURL $root .ID}}">{{.ID}}</a></td><td><a href="{{epicURL $root .ID}}">{{.Title}}</a></td><td>{{template "status" .Issue.Status}}</td><td>{{template "progress" .Status.PercentComplete}} {{pct .Status.PercentComplete}}</td><td>{{count .Status.StatusCounts "closed"}}/{{.Status.TotalChildren}} closed</td></tr>
+{{end}}</tbody>
+</table>{{end}}
+{{template "footer" .}}{{end}}
+
+{{define "epic"}}{{template "header" .}}{{$root := .Root}}{{with .Body}}
+<h1><span class="id">{{.ID}}</span> {{.Title}}</h1>
+<p>{{template "status" .Issue.Status}} · <a href="{{issueURL $root .ID}}">Issue page</a></p>
+{{if eq .Status.TotalChildren 0}}<p>No child issues.</p>{{else}}
+<h2>Progress</h2>
+<p>{{template "progress" .Status.PercentComplete}} {{pct .Status.PercentComplete}} ({{count .Status.StatusCounts "closed"}}/{{.Status.TotalChildren}} closed)</p>
+{{if .Status.EstimatedMinutes}}<p>{{template "progress" .Status.PercentCompleteByEstimate}} {{pct .Status.PercentCompleteByEstimate}} by estimate ({{minutes .Status.ClosedEstimatedMinutes}}/{{minutes .Status.EstimatedMinutes}}{{if .Status.UnestimatedChildren}}, {{.Status.UnestimatedChildren}} unestimated{{end}})</p>{{end}}
+<p>{{$counts := .Status.StatusCounts}}{{range statuses}}{{if count $counts .}}{{template "status" .}} {{count $counts .}} {{end}}{{end}}</p>
+{{if .Status.Ready}}<p>Ready: {{range .Status.Ready}}<a href="{{issueURL $root .}}">{{.}}</a> {{end}}</p>{{end}}
+{{if .Status.Blocked}}<p>Blocked: {{range .Status.Blocked}}<a href="{{issueURL $root .}}">{{.}}</a> {{end}}</p>{{end}}
+<h2>Child issues</h2>
+<table>
+<thead><tr><th>ID</th><th>Title</th><th>Status</th><th>Priority</th><th>Assignee</th></tr></thead>
+<tbody>
+{{range .Descendants}}<tr><td><a href="{{issueURL $root .ID}}">{{.ID}}</a></td><td style="padding-left: {{indent .Depth}}px">{{.Title}}</td><td>{{template "status" .Status}}</td><td>P{{.Priority}}</td><td>{{.Assignee}}</td></tr>
+{{end}}</tbody>
+</table>{{end}}
+{{end}}{{template "footer" .}}{{end}}
+
+{{define "graph"}}{{template "header" .}}
+<h1>Dependency graph</h1>
+<p class="legend"><span style="color: red">━ blocks</span> <span style="color: blue">━ parent-child</span> <span style="color: green">┅ discovered-from</span> <span style="color: gray">┅ related</span> · Arrows point at the dependency. <a href="graph.svg">Open SVG</a></p>
+<div class="graph">{{.Body}}</div>
+{{template "footer" .}}{{end}}
+`))
+
+const siteCSS = `body { font: 15px/1.5 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #222; }
+nav { background: #24292e; color: #fff; padding: 10px 20px; }
+nav a { color: #ddd; margin-left: 16px; text-decoration: none; }
+nav a:hover { color: #fff; }
+main { padding: 10px 20px; max-width: 1200px; }
+footer { color: #888; font-size: 12px; padding: 20px; }
+a { color: #0366d6; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 4px 8px; border-bottom: 1px solid #eee; vertical-align: top; }
+table.meta { width: auto; }
+table.history td:first-child { white-space: nowrap; color: #666; }
+.id { color: #666; font-weight: normal; }
+.status { border-radius: 3px; padding: 1px 6px; font-size: 12px; background: #e1f5e1; }
+.status.in_progress { background: #fff5c2; }
+.status.blocked { background: #fdd; }
+.status.closed { background: #eee; color: #666; }
+.label { background: #e8eefc; border-radius: 3px; padding: 1px 6px; font-size: 12px; }
+.dep { color: #888; font-size: 12px; }
+.text { white-space: pre-wrap; }
+.filters { margin: 10px 0; }
+.filters input { width: 280px; }
+.progress { display: inline-block; width: 160px; height: 10px; background: #eee; border-radius: 5px; vertical-align: middle; }
+.progress span { display: block; height: 100%; background: #2ea44f; border-radius: 5px; }
+.graph { overflow: auto; border: 1px solid #eee; }
+`
+
+// siteGraphNode is a node placed in the SVG dependency graph
+type siteGraphNode struct {
+	issue *types.Issue
+	layer int
+	x, y  int
+}
+
+const (
+	siteNodeWidth  = 200
+	siteNodeHeight = 48
+	siteGapX       = 30
+	siteGapY       = 60
+	siteMargin     = 20
+	siteCurve      = 150 // Horizontal pull of edges that skip layers
+)
+
+// renderSiteGraph lays out issues with dependencies in layers, each issue below
+// everything it depends on, and renders them as SVG. Links are relative to the
+// site root.
+func renderSiteGraph(issues map[string]*types.Issue, deps map[string][]*types.Dependency) string {
+	var edges []*types.Dependency
+	nodes := make(map[string]*siteGraphNode)
+	for _, issueDeps := range deps {
+		for _, dep := range issueDeps {
+			from, to := issues[dep.IssueID], issues[dep.DependsOnID]
+			if from == nil || to == nil {
+				continue
+			}
+			edges = append(edges, dep)
+			nodes[from.ID] = &siteGraphNode{issue: from}
+			nodes[to.ID] = &siteGraphNode{issue: to}
+		}
+	}
+	if len(nodes) == 0 {
+		return `<p>No dependencies between issues.</p>`
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].IssueID != edges[j].IssueID {
+			return edges[i].IssueID < edges[j].IssueID
+		}
+		return edges[i].DependsOnID < edges[j].DependsOnID
+	})
+
+	// Layer = longest chain of non-related dependencies below the issue
+	targets := make(map[string][]string)
+	for _, dep := range edges {
+		if dep.Type != types.DepRelated {
+			targets[dep.IssueID] = append(targets[dep.IssueID], dep.DependsOnID)
+		}
+	}
+	visiting := make(map[string]bool)
+	done := make(map[string]bool)
+	var assign func(id string) int
+	assign = func(id string) int {
+		node := nodes[id]
+		if done[id] || visiting[id] {
+			return node.layer
+		}
+		visiting[id] = true
+		for _, target := range targets[id] {
+			if l := assign(target) + 1; l > node.layer {
+				node.layer = l
+			}
+		}
+		visiting[id] = false
+		done[id] = true
+		return node.layer
+	}
+	var layers [][]*siteGraphNode
+	for id := range nodes {
+		assign(id)
+	}
+	for _, node := range nodes {
+		for len(layers) <= node.layer {
+			layers = append(layers, nil)
+		}
+		layers[node.layer] = append(layers[node.layer], node)
+	}
+
+	// Order each layer by priority, then place issues under the average position of
+	// their dependencies to reduce crossings
+	position := make(map[string]float64)
+	widest := 0
+	for i, layer := range layers {
+		sort.Slice(layer, func(a, b int) bool {
+			if layer[a].issue.Priority != layer[b].issue.Priority {
+				return layer[a].issue.Priority < layer[b].issue.Priority
+			}
+			return layer[a].issue.ID < layer[b].issue.ID
+		})
+		if i > 0 {
+			center := make(map[string]float64, len(layer))
+			for j, node := range layer {
+				sum, n := 0.0, 0
+				for _, target := range targets[node.issue.ID] {
+					if p, ok := position[target]; ok {
+						sum += p
+						n++
+					}
+				}
+				center[node.issue.ID] = float64(j)
+				if n > 0 {
+					center[node.issue.ID] = sum / float64(n)
+				}
+			}
+			sort.SliceStable(layer, func(a, b int) bool { return center[layer[a].issue.ID] < center[layer[b].issue.ID] })
+		}
+		for j, node := range layer {
+			position[node.issue.ID] = float64(j)
+		}
+		if len(layer) > widest {
+			widest = len(layer)
+		}
+	}
+
+	width := 2*siteMargin + widest*siteNodeWidth + (widest-1)*siteGapX + siteCurve/2
+	height := 2*siteMargin + len(layers)*siteNodeHeight + (len(layers)-1)*siteGapY
+	for i, layer := range layers {
+		offset := (widest - len(layer)) * (siteNodeWidth + siteGapX) / 2
+		for j, node := range layer {
+			node.x = siteMargin + offset + j*(siteNodeWidth+siteGapX)
+			node.y = siteMargin + i*(siteNodeHeight+siteGapY)
+		}
+	}
+
+	var b strings.Builder
+	esc := template.HTMLEscapeString
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+
+	b.WriteString("<defs>\n")
+	markers := make(map[string]bool)
+	for _, dep := range edges {
+		c, _ := graphEdgeStyle(dep.Type)
+		if !markers[c] {
+			markers[c] = true
+			fmt.Fprintf(&b, `<marker id="arrow-%s" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto-start-reverse"><path d="M0,0 L10,5 L0,10 z" fill="%s"/></marker>`+"\n", c, c)
+		}
+	}
+	b.WriteString("</defs>\n")
+
+	for _, dep := range edges {
+		from, to := nodes[dep.IssueID], nodes[dep.DependsOnID]
+		x1, y1 := from.x+siteNodeWidth/2, from.y
+		x2, y2 := to.x+siteNodeWidth/2, to.y+siteNodeHeight
+		switch {
+		case from.layer == to.layer && from.x < to.x:
+			x1, y1, x2, y2 = from.x+siteNodeWidth, from.y+siteNodeHeight/2, to.x, to.y+siteNodeHeight/2
+		case from.layer == to.layer:
+			x1, y1, x2, y2 = from.x, from.y+siteNodeHeight/2, to.x+siteNodeWidth, to.y+siteNodeHeight/2
+		case from.layer < to.layer: // Only possible through a cycle
+			y1, y2 = from.y+siteNodeHeight, to.y
+		}
+		path := fmt.Sprintf("M%d,%d L%d,%d", x1, y1, x2, y2)
+		if span := from.layer - to.layer; span > 1 || span < -1 {
+			// Curve long edges around the issues in the layers between
+			path = fmt.Sprintf("M%d,%d C%d,%d %d,%d %d,%d", x1, y1,
+				x1+siteCurve, y1-siteGapY, x2+siteCurve, y2+siteGapY, x2, y2)
+		}
+		c, style := graphEdgeStyle(dep.Type)
+		attrs := `stroke-width="1.5"`
+		switch style {
+		case "bold":
+			attrs = `stroke-width="2.5"`
+		case "dashed":
+			attrs += ` stroke-dasharray="6 4"`
+		}
+		fmt.Fprintf(&b, `<path d="%s" fill="none" stroke="%s" %s marker-end="url(#arrow-%s)"><title>%s depends on %s (%s)</title></path>`+"\n",
+			path, c, attrs, c, esc(dep.IssueID), esc(dep.DependsOnID), esc(string(dep.Type)))
+	}
+
+	for _, layer := range layers {
+		for _, node := range layer {
+			issue := node.issue
+			fill, font := graphStatusColors(issue.Status)
+			title := issue.Title
+			if utf8.RuneCountInString(title) > 30 {
+				title = string([]rune(title)[:29]) + "…"
+			}
+			fmt.Fprintf(&b, `<a href="issues/%s"><title>%s: %s (%s)</title>`, esc(siteFileName(issue.ID)), esc(issue.ID), esc(issue.Title), issue.Status)
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="4" fill="%s" stroke="#666"/>`, node.x, node.y, siteNodeWidth, siteNodeHeight, fill)
+			fmt.Fprintf(&b, `<text x="%d" y="%d" fill="%s" font-weight="bold">%s · P%d</text>`, node.x+8, node.y+18, font, esc(issue.ID), issue.Priority)
+			fmt.Fprintf(&b, `<text x="%d" y="%d" fill="%s">%s</text></a>`+"\n", node.x+8, node.y+36, font, esc(title))
+		}
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// buildSite writes the static site for every issue in the store to dir
+func buildSite(ctx context.Context, dir, siteTitle string) (issueCount, epicCount int, err error) {
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return 0, 0, err
+	}
+	deps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	byID := make(map[string]*types.Issue, len(all))
+	for _, issue := range all {
+		byID[issue.ID] = issue
+	}
+	link := func(id string, depType types.DependencyType) siteLink {
+		l := siteLink{ID: id, Type: depType}
+		if issue := byID[id]; issue != nil {
+			l.Title, l.Status = issue.Title, issue.Status
+		}
+		return l
+	}
+
+	pages := make([]*siteIssue, 0, len(all))
+	pageByID := make(map[string]*siteIssue, len(all))
+	for _, issue := range all {
+		page := &siteIssue{Issue: issue}
+		if page.Labels, err = store.GetLabels(ctx, issue.ID); err != nil {
+			return 0, 0, err
+		}
+		if page.Events, err = store.GetEvents(ctx, issue.ID, 0); err != nil {
+			return 0, 0, err
+		}
+		pages = append(pages, page)
+		pageByID[issue.ID] = page
+	}
+	for _, issueDeps := range deps {
+		for _, dep := range issueDeps {
+			if page := pageByID[dep.IssueID]; page != nil {
+				page.Deps = append(page.Deps, link(dep.DependsOnID, dep.Type))
+			}
+			if page := pageByID[dep.DependsOnID]; page != nil {
+				page.Dependents = append(page.Dependents, link(dep.IssueID, dep.Type))
+			}
+		}
+	}
+
+	var epics []*siteEpic
+	for _, page := range pages {
+		sort.Slice(page.Deps, func(i, j int) bool { return page.Deps[i].ID < page.Deps[j].ID })
+		sort.Slice(page.Dependents, func(i, j int) bool { return page.Dependents[i].ID < page.Dependents[j].ID })
+		if page.IssueType != types.TypeEpic {
+			continue
+		}
+		epic := &siteEpic{Issue: page.Issue}
+		if epic.Status, err = store.GetEpicStatus(ctx, page.ID); err != nil {
+			return 0, 0, err
+		}
+		if epic.Descendants, err = store.GetDescendants(ctx, page.ID, 0); err != nil {
+			return 0, 0, err
+		}
+		page.Epic = epic.Status
+		epics = append(epics, epic)
+	}
+
+	index := siteIndex{Issues: pages}
+	seenTypes := make(map[types.IssueType]bool)
+	seenAssignees := make(map[string]bool)
+	seenLabels := make(map[string]bool)
+	for _, page := range pages {
+		if !seenTypes[page.IssueType] {
+			seenTypes[page.IssueType] = true
+			index.Types = append(index.Types, page.IssueType)
+		}
+		if page.Assignee != "" && !seenAssignees[page.Assignee] {
+			seenAssignees[page.Assignee] = true
+			index.Assignees = append(index.Assignees, page.Assignee)
+		}
+		for _, label := range page.Labels {
+			if !seenLabels[label] {
+				seenLabels[label] = true
+				index.Labels = append(index.Labels, label)
+			}
+		}
+	}
+	sort.Slice(index.Types, func(i, j int) bool { return index.Types[i] < index.Types[j] })
+	sort.Strings(index.Assignees)
+	sort.Strings(index.Labels)
+	index.Statuses = []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusClosed}
+	index.Priorities = []int{0, 1, 2, 3, 4}
+
+	for _, sub := range []string{"issues", "epics"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return 0, 0, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	generated := time.Now()
+	render := func(name, path, root, title string, body interface{}) error {
+		f, err := os.Create(filepath.Join(dir, path))
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		page := sitePage{SiteTitle: siteTitle, Title: title, Root: root, Generated: generated, Body: body}
+		if err := siteTemplates.ExecuteTemplate(f, name, page); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to render %s: %w", path, err)
+		}
+		return f.Close()
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "style.css"), []byte(siteCSS), 0644); err != nil {
+		return 0, 0, fmt.Errorf("failed to write style.css: %w", err)
+	}
+	if err := render("index", "index.html", "", "Issues", index); err != nil {
+		return 0, 0, err
+	}
+	for _, page := range pages {
+		if err := render("issue", filepath.Join("issues", siteFileName(page.ID)), "../", page.ID+": "+page.Title, page); err != nil {
+			return 0, 0, err
+		}
+	}
+	if err := render("epics", filepath.Join("epics", "index.html"), "../", "Epics", epics); err != nil {
+		return 0, 0, err
+	}
+	for _, epic := range epics {
+		if err := render("epic", filepath.Join("epics", siteFileName(epic.ID)), "../", epic.ID+": "+epic.Title, epic); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	svg := renderSiteGraph(byID, deps)
+	if strings.HasPrefix(svg, "<svg") {
+		if err := os.WriteFile(filepath.Join(dir, "graph.svg"), []byte(svg), 0644); err != nil {
+			return 0, 0, fmt.Errorf("failed to write graph.svg: %w", err)
+		}
+	}
+	if err := render("graph", "graph.html", "", "Dependency graph", template.HTML(svg)); err != nil {
+		return 0, 0, err
+	}
+
+	return len(pages), len(epics), nil
+}
+
+var siteCmd = &cobra.Command{
+	Use:   "site",
+	Short: "Generate a static HTML site from the issue database",
+	Long: `Generate a self-contained static HTML site for browsing issues without bd.
+
+The site has an index of all issues with search and filters, a page per issue
+with its dependencies, dependents, labels and history, progress pages for
+epics, and an SVG dependency graph. It needs no server or external assets, so
+it can be published from CI as a build artifact or to any static host.
+
+Existing files in the output directory are overwritten.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		title, _ := cmd.Flags().GetString("title")
+		ctx := context.Background()
+
+		if title == "" {
+			title = "Issues"
+			if prefix, err := store.GetConfig(ctx, "issue_prefix"); err == nil && prefix != "" {
+				title = prefix + " issues"
+			}
+		}
+
+		issueCount, epicCount, err := buildSite(ctx, output, title)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"output": output, "issues": issueCount, "epics": epicCount})
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Wrote site for %d issues (%d epics) to %s\n", green("✓"), issueCount, epicCount, output)
+	},
+}
+
+func init() {
+	siteCmd.Flags().StringP("output", "o", "public", "Output directory")
+	siteCmd.Flags().String("title", "", "Site title (default: \"<prefix> issues\")")
+	rootCmd.AddCommand(siteCmd)
+}
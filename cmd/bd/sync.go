@@ -270,6 +270,15 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 		issue.Labels = labels
 	}
 
+	// Populate work logs for all issues
+	allWorkLogs, err := store.GetAllWorkLogs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get work logs: %w", err)
+	}
+	for _, issue := range issues {
+		issue.WorkLogs = allWorkLogs[issue.ID]
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
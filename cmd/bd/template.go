@@ -0,0 +1,372 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// templatesDirName is the directory under .beads holding issue templates
+const templatesDirName = "templates"
+
+// templateVarRegex matches {{name}} placeholders in template text
+var templateVarRegex = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+// IssueTreeTemplate is a reusable shape of work stored as JSON in .beads/templates/<name>.json
+type IssueTreeTemplate struct {
+	Description string            `json:"description,omitempty"`
+	Vars        map[string]string `json:"vars,omitempty"` // Placeholder defaults; "" means required
+	Issue       *TemplateNode     `json:"issue"`
+}
+
+// TemplateNode describes one issue of a template and its children. Children are
+// linked to their parent with parent-child dependencies; DependsOn lists keys of
+// sibling or other nodes that block this one ("type:key" for other dependency types).
+type TemplateNode struct {
+	Key                string          `json:"key,omitempty"`
+	Title              string          `json:"title"`
+	Description        string          `json:"description,omitempty"`
+	Design             string          `json:"design,omitempty"`
+	AcceptanceCriteria string          `json:"acceptance_criteria,omitempty"`
+	Notes              string          `json:"notes,omitempty"`
+	Type               string          `json:"type,omitempty"`
+	Priority           *int            `json:"priority,omitempty"`
+	Assignee           string          `json:"assignee,omitempty"`
+	Estimate           *int            `json:"estimate,omitempty"` // minutes
+	Labels             []string        `json:"labels,omitempty"`
+	DependsOn          []string        `json:"depends_on,omitempty"`
+	Children           []*TemplateNode `json:"children,omitempty"`
+}
+
+// templatesDir returns the templates directory next to the database
+func templatesDir() string {
+	return filepath.Join(filepath.Dir(dbPath), templatesDirName)
+}
+
+// loadTemplate reads and parses a named template
+func loadTemplate(name string) (*IssueTreeTemplate, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid template name %q", name)
+	}
+	path := filepath.Join(templatesDir(), name+".json")
+	// #nosec G304 - path is confined to the templates directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("template %q not found (looked for %s)", name, path)
+		}
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	var tmpl IssueTreeTemplate
+	if err := json.Unmarshal(data, &tmpl); err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+	if tmpl.Issue == nil {
+		return nil, fmt.Errorf("template %s has no \"issue\"", path)
+	}
+	return &tmpl, nil
+}
+
+// listTemplates returns the names of all templates, sorted
+func listTemplates() ([]string, error) {
+	entries, err := os.ReadDir(templatesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// parseTemplateVars parses --var name=value flags
+func parseTemplateVars(specs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		name, value, ok := strings.Cut(spec, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --var %q (expected name=value)", spec)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// instantiateTemplate expands a template into issues ready for CreateIssues. The root
+// issue comes first; children follow depth-first with parent-child dependencies on
+// their parent and dependencies between nodes expressed as batch references.
+func instantiateTemplate(tmpl *IssueTreeTemplate, vars map[string]string) ([]*types.Issue, error) {
+	values := make(map[string]string, len(tmpl.Vars)+len(vars))
+	for name, def := range tmpl.Vars {
+		values[name] = def
+	}
+	for name, value := range vars {
+		values[name] = value
+	}
+
+	// Every placeholder must have a value
+	missing := make(map[string]bool)
+	var collect func(node *TemplateNode)
+	collect = func(node *TemplateNode) {
+		texts := append([]string{node.Title, node.Description, node.Design, node.AcceptanceCriteria,
+			node.Notes, node.Assignee}, node.Labels...)
+		for _, text := range texts {
+			for _, m := range templateVarRegex.FindAllStringSubmatch(text, -1) {
+				if values[m[1]] == "" {
+					missing[m[1]] = true
+				}
+			}
+		}
+		for _, child := range node.Children {
+			collect(child)
+		}
+	}
+	collect(tmpl.Issue)
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("missing template variables: %s (use --var name=value)", strings.Join(names, ", "))
+	}
+
+	subst := func(text string) string {
+		return templateVarRegex.ReplaceAllStringFunc(text, func(m string) string {
+			return values[templateVarRegex.FindStringSubmatch(m)[1]]
+		})
+	}
+
+	var issues []*types.Issue
+	var nodes []*TemplateNode
+	index := make(map[string]int)
+	var walk func(node *TemplateNode, parent int) error
+	walk = func(node *TemplateNode, parent int) error {
+		if node.Key != "" {
+			if _, dup := index[node.Key]; dup {
+				return fmt.Errorf("duplicate template key %q", node.Key)
+			}
+			index[node.Key] = len(issues)
+		}
+
+		issueType := types.TypeTask
+		if node.Type != "" {
+			issueType = types.IssueType(node.Type)
+		} else if len(node.Children) > 0 {
+			issueType = types.TypeEpic
+		}
+		priority := 2
+		if node.Priority != nil {
+			priority = *node.Priority
+		}
+		labels := make([]string, 0, len(node.Labels))
+		for _, label := range node.Labels {
+			labels = append(labels, subst(label))
+		}
+
+		issue := &types.Issue{
+			Title:              subst(node.Title),
+			Description:        subst(node.Description),
+			Design:             subst(node.Design),
+			AcceptanceCriteria: subst(node.AcceptanceCriteria),
+			Notes:              subst(node.Notes),
+			Status:             types.StatusOpen,
+			Priority:           priority,
+			IssueType:          issueType,
+			Assignee:           subst(node.Assignee),
+			EstimatedMinutes:   node.Estimate,
+			Labels:             labels,
+		}
+		if parent >= 0 {
+			issue.Dependencies = append(issue.Dependencies, &types.Dependency{
+				DependsOnID: types.BatchRef(parent),
+				Type:        types.DepParentChild,
+			})
+		}
+		issues = append(issues, issue)
+		nodes = append(nodes, node)
+
+		self := len(issues) - 1
+		for _, child := range node.Children {
+			if err := walk(child, self); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(tmpl.Issue, -1); err != nil {
+		return nil, err
+	}
+
+	// Resolve depends_on keys now that every node has an index
+	for i, node := range nodes {
+		for _, spec := range node.DependsOn {
+			depType := types.DepBlocks
+			key := strings.TrimSpace(spec)
+			if t, k, ok := strings.Cut(key, ":"); ok {
+				depType = types.DependencyType(strings.TrimSpace(t))
+				key = strings.TrimSpace(k)
+			}
+			if !depType.IsValid() {
+				return nil, fmt.Errorf("invalid dependency type %q in template", depType)
+			}
+			target, ok := index[key]
+			if !ok {
+				return nil, fmt.Errorf("unknown template key %q in depends_on of %q", key, node.Title)
+			}
+			issues[i].Dependencies = append(issues[i].Dependencies, &types.Dependency{
+				DependsOnID: types.BatchRef(target),
+				Type:        depType,
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// createIssuesFromTemplate instantiates a template and creates the whole tree in one transaction
+func createIssuesFromTemplate(cmd *cobra.Command, name string) {
+	varSpecs, _ := cmd.Flags().GetStringArray("var")
+	vars, err := parseTemplateVars(varSpecs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	tmpl, err := loadTemplate(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	issues, err := instantiateTemplate(tmpl, vars)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	if err := store.CreateIssues(ctx, issues, actor); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Schedule auto-flush
+	markDirtyAndScheduleFlush()
+
+	if jsonOutput {
+		outputJSON(issues)
+		return
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Created %d issues from template %s:\n", green("✓"), len(issues), name)
+	for _, issue := range issues {
+		fmt.Printf("  %s: %s [P%d, %s]\n", issue.ID, issue.Title, issue.Priority, issue.IssueType)
+	}
+}
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage issue templates",
+	Long: `Manage issue templates stored in .beads/templates.
+
+A template is a JSON file describing an issue and a tree of child issues with
+fields, labels and dependencies. {{name}} placeholders are substituted from
+--var flags (or the template's "vars" defaults) when instantiated with
+'bd create --template <name>'.
+
+Example .beads/templates/release.json:
+
+  {
+    "description": "Release checklist",
+    "vars": {"version": ""},
+    "issue": {
+      "title": "Release {{version}}",
+      "labels": ["release"],
+      "children": [
+        {"key": "freeze", "title": "Code freeze for {{version}}"},
+        {"key": "notes", "title": "Write release notes", "depends_on": ["freeze"]},
+        {"title": "Tag v{{version}}", "depends_on": ["notes"], "priority": 1}
+      ]
+    }
+  }`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available templates",
+	Run: func(cmd *cobra.Command, args []string) {
+		names, err := listTemplates()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		type templateInfo struct {
+			Name        string   `json:"name"`
+			Description string   `json:"description,omitempty"`
+			Vars        []string `json:"vars,omitempty"`
+			Error       string   `json:"error,omitempty"`
+		}
+		infos := make([]templateInfo, 0, len(names))
+		for _, name := range names {
+			info := templateInfo{Name: name}
+			tmpl, err := loadTemplate(name)
+			if err != nil {
+				info.Error = err.Error()
+			} else {
+				info.Description = tmpl.Description
+				for v := range tmpl.Vars {
+					info.Vars = append(info.Vars, v)
+				}
+				sort.Strings(info.Vars)
+			}
+			infos = append(infos, info)
+		}
+
+		if jsonOutput {
+			outputJSON(infos)
+			return
+		}
+
+		if len(infos) == 0 {
+			fmt.Printf("No templates in %s\n", templatesDir())
+			return
+		}
+		yellow := color.New(color.FgYellow).SprintFunc()
+		for _, info := range infos {
+			switch {
+			case info.Error != "":
+				fmt.Printf("%s %s: %s\n", yellow("⚠"), info.Name, info.Error)
+			case len(info.Vars) > 0:
+				fmt.Printf("%s (vars: %s)  %s\n", info.Name, strings.Join(info.Vars, ", "), info.Description)
+			default:
+				fmt.Printf("%s  %s\n", info.Name, info.Description)
+			}
+		}
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(templateListCmd)
+	rootCmd.AddCommand(templateCmd)
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var timeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Track time spent on issues",
+	Long: `Track time spent on issues, to compare against estimates.
+
+Use 'bd time start' and 'bd time stop' to run a timer, or 'bd time log' to
+record time after the fact. Logged time is shown by 'bd show' and compared
+with estimates by 'bd stats --estimates'.`,
+}
+
+var timeStartCmd = &cobra.Command{
+	Use:   "start [id]",
+	Short: "Start a timer on an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		note, _ := cmd.Flags().GetString("note")
+		ctx := context.Background()
+
+		entry, err := store.StartWorkLog(ctx, args[0], actor, note)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(entry)
+			return
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Started timer on %s at %s\n", green("✓"), args[0], entry.StartedAt.Local().Format("15:04"))
+	},
+}
+
+var timeStopCmd = &cobra.Command{
+	Use:   "stop [id]",
+	Short: "Stop the running timer on an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+
+		entry, err := store.StopWorkLog(ctx, args[0], actor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(entry)
+			return
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Logged %s on %s\n", green("✓"), formatMinutes(entry.Minutes), args[0])
+	},
+}
+
+var timeLogCmd = &cobra.Command{
+	Use:   "log [id] [duration]",
+	Short: "Record time spent on an issue",
+	Long: `Record time spent on an issue after the fact.
+
+The duration accepts minutes, hours and days, e.g. 45m, 1h30m, 2h, 1d.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		note, _ := cmd.Flags().GetString("note")
+		ctx := context.Background()
+
+		d, err := parseRelativeDuration(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid duration: %v\n", err)
+			os.Exit(1)
+		}
+		minutes := int(math.Round(d.Minutes()))
+		if minutes <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: duration must be at least one minute\n")
+			os.Exit(1)
+		}
+
+		issue, err := store.GetIssue(ctx, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if issue == nil {
+			fmt.Fprintf(os.Stderr, "Issue %s not found\n", args[0])
+			os.Exit(1)
+		}
+
+		now := time.Now()
+		entry := &types.WorkLog{
+			IssueID:   issue.ID,
+			Actor:     actor,
+			StartedAt: now.Add(-time.Duration(minutes) * time.Minute),
+			EndedAt:   &now,
+			Minutes:   minutes,
+			Note:      note,
+		}
+		if err := store.AddWorkLog(ctx, entry, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(entry)
+			return
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Logged %s on %s\n", green("✓"), formatMinutes(minutes), issue.ID)
+	},
+}
+
+// loggedMinutes sums finished work-log entries and returns the running timer, if any
+func loggedMinutes(entries []*types.WorkLog) (int, *types.WorkLog) {
+	total := 0
+	var running *types.WorkLog
+	for _, entry := range entries {
+		if entry.EndedAt == nil {
+			running = entry
+			continue
+		}
+		total += entry.Minutes
+	}
+	return total, running
+}
+
+// EstimateGroup aggregates estimate accuracy for issues sharing a type, label or assignee
+type EstimateGroup struct {
+	Key              string  `json:"key"`
+	Issues           int     `json:"issues"`
+	Estimated        int     `json:"estimated_issues"`
+	EstimatedMinutes int     `json:"estimated_minutes"`
+	ActualMinutes    int     `json:"actual_minutes"`
+	Ratio            float64 `json:"ratio,omitempty"`
+}
+
+// EstimateReport is the output of 'bd stats --estimates'
+type EstimateReport struct {
+	ByType     []*EstimateGroup        `json:"by_type"`
+	ByLabel    []*EstimateGroup        `json:"by_label"`
+	ByAssignee []*EstimateGroup        `json:"by_assignee"`
+	Issues     []*types.EstimateActual `json:"issues"`
+}
+
+// buildEstimateReport groups closed issues by type, label and assignee. The
+// actual/estimate ratio only counts issues that have both an estimate and actual time,
+// so unestimated work doesn't skew it.
+func buildEstimateReport(actuals []*types.EstimateActual) *EstimateReport {
+	byType := make(map[string]*EstimateGroup)
+	byLabel := make(map[string]*EstimateGroup)
+	byAssignee := make(map[string]*EstimateGroup)
+
+	add := func(groups map[string]*EstimateGroup, key string, a *types.EstimateActual) {
+		g, ok := groups[key]
+		if !ok {
+			g = &EstimateGroup{Key: key}
+			groups[key] = g
+		}
+		g.Issues++
+		if a.EstimatedMinutes != nil && a.ActualMinutes > 0 {
+			g.Estimated++
+			g.EstimatedMinutes += *a.EstimatedMinutes
+			g.ActualMinutes += a.ActualMinutes
+		}
+	}
+
+	for _, a := range actuals {
+		add(byType, string(a.IssueType), a)
+		assignee := a.Assignee
+		if assignee == "" {
+			assignee = "(unassigned)"
+		}
+		add(byAssignee, assignee, a)
+		for _, label := range a.Labels {
+			add(byLabel, label, a)
+		}
+	}
+
+	sorted := func(groups map[string]*EstimateGroup) []*EstimateGroup {
+		result := make([]*EstimateGroup, 0, len(groups))
+		for _, g := range groups {
+			if g.EstimatedMinutes > 0 {
+				g.Ratio = float64(g.ActualMinutes) / float64(g.EstimatedMinutes)
+			}
+			result = append(result, g)
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].Key < result[j].Key
+		})
+		return result
+	}
+
+	return &EstimateReport{
+		ByType:     sorted(byType),
+		ByLabel:    sorted(byLabel),
+		ByAssignee: sorted(byAssignee),
+		Issues:     actuals,
+	}
+}
+
+// outputEstimateReport prints estimate accuracy tables
+func outputEstimateReport(report *EstimateReport) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+
+	fmt.Printf("\n%s Estimates vs Actuals (closed issues):\n", cyan("⏱"))
+	if len(report.Issues) == 0 {
+		fmt.Printf("\nNo closed issues yet\n\n")
+		return
+	}
+
+	printGroups := func(title string, groups []*EstimateGroup) {
+		if len(groups) == 0 {
+			return
+		}
+		fmt.Printf("\nBy %s:\n", title)
+		fmt.Printf("  %-20s %6s %10s %10s %7s\n", "", "issues", "estimated", "actual", "ratio")
+		for _, g := range groups {
+			ratio := "-"
+			if g.EstimatedMinutes > 0 {
+				ratio = fmt.Sprintf("%.2fx", g.Ratio)
+				if g.Ratio > 1.25 {
+					ratio = red(ratio)
+				} else if g.Ratio < 0.8 {
+					ratio = green(ratio)
+				}
+			}
+			fmt.Printf("  %-20s %6d %10s %10s %7s\n", g.Key, g.Issues,
+				formatMinutes(g.EstimatedMinutes), formatMinutes(g.ActualMinutes), ratio)
+		}
+	}
+
+	printGroups("type", report.ByType)
+	printGroups("label", report.ByLabel)
+	printGroups("assignee", report.ByAssignee)
+	fmt.Printf("\nRatio is actual/estimate over issues with both an estimate and tracked time.\n\n")
+}
+
+func init() {
+	timeStartCmd.Flags().String("note", "", "Note describing the work")
+	timeLogCmd.Flags().String("note", "", "Note describing the work")
+
+	timeCmd.AddCommand(timeStartCmd)
+	timeCmd.AddCommand(timeStopCmd)
+	timeCmd.AddCommand(timeLogCmd)
+	rootCmd.AddCommand(timeCmd)
+}
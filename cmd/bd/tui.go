@@ -0,0 +1,919 @@
+// This file implements 'bd tui', a full-screen terminal interface for browsing and
+// triaging issues. The model (state, key handling and rendering) is independent of
+// the terminal; tui_term_*.go handle raw mode and resizing.
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// tuiPanes are the issue lists the TUI can show, switched with 1-3 or Tab
+var tuiPanes = []string{"Ready", "Blocked", "All"}
+
+const (
+	tuiReset   = "\x1b[0m"
+	tuiReverse = "\x1b[7m"
+	tuiBold    = "\x1b[1m"
+	tuiDim     = "\x1b[2m"
+	tuiRed     = "\x1b[31m"
+	tuiYellow  = "\x1b[33m"
+	tuiCyan    = "\x1b[36m"
+)
+
+// tuiHelp lists the keybindings, shown with '?'
+var tuiHelp = []string{
+	"Navigation",
+	"  1 2 3, Tab        Ready / Blocked / All panes",
+	"  j k, arrows       Move; PgUp PgDn, g G jump",
+	"  /                 Fuzzy filter (Enter keeps it, Esc clears it)",
+	"  Enter             Show details, dependencies and events",
+	"  Esc, q            Back; q in the list quits",
+	"  r                 Refresh",
+	"",
+	"Editing the selected issue",
+	"  s                 Set status (open, in_progress, blocked, closed)",
+	"  c                 Close",
+	"  p, + -            Set priority, or raise / lower it",
+	"  a                 Set assignee",
+	"  l                 Labels: +name adds, -name removes",
+	"  d                 Dependencies: id or type:id adds, -id removes",
+	"",
+	"Press any key to return",
+}
+
+// tuiPrompt is a one-line input for editing the selected issue
+type tuiPrompt struct {
+	label string
+	input string
+	apply func(issue *types.Issue, input string) (string, error)
+}
+
+// tuiDetail is the loaded detail view of one issue
+type tuiDetail struct {
+	issue      *types.Issue
+	labels     []string
+	deps       []*types.Dependency
+	dependents []*types.Issue
+	events     []*types.Event
+	scroll     int
+}
+
+// tuiModel is the TUI's state. handleKey updates it and render draws it.
+type tuiModel struct {
+	ctx       context.Context
+	pane      int
+	issues    []*types.Issue
+	labels    map[string][]string
+	blockedBy map[string][]string
+	visible   []*types.Issue
+	cursor    int
+	offset    int
+	filter    string
+	filtering bool
+	detail    *tuiDetail
+	prompt    *tuiPrompt
+	help      bool
+	message   string
+	quit      bool
+	width     int
+	height    int
+}
+
+func newTUIModel(ctx context.Context, width, height int) *tuiModel {
+	return &tuiModel{ctx: ctx, width: width, height: height}
+}
+
+// load reads the current pane from storage, keeping the selection where possible
+func (m *tuiModel) load() error {
+	selectedID := ""
+	if issue := m.selected(); issue != nil {
+		selectedID = issue.ID
+	}
+
+	var issues []*types.Issue
+	m.blockedBy = make(map[string][]string)
+	switch m.pane {
+	case 0:
+		ready, err := store.GetReadyWork(m.ctx, types.WorkFilter{})
+		if err != nil {
+			return err
+		}
+		issues = ready
+	case 1:
+		blocked, err := store.GetBlockedIssues(m.ctx)
+		if err != nil {
+			return err
+		}
+		for _, b := range blocked {
+			issue := b.Issue
+			issues = append(issues, &issue)
+			m.blockedBy[issue.ID] = b.BlockedBy
+		}
+	default:
+		all, err := store.SearchIssues(m.ctx, "", types.IssueFilter{})
+		if err != nil {
+			return err
+		}
+		issues = all
+	}
+
+	m.issues = issues
+	m.labels = make(map[string][]string, len(issues))
+	for _, issue := range issues {
+		labels, err := store.GetLabels(m.ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		m.labels[issue.ID] = labels
+	}
+	m.applyFilter()
+
+	for i, issue := range m.visible {
+		if issue.ID == selectedID {
+			m.cursor = i
+		}
+	}
+	m.clampCursor()
+
+	if m.detail != nil {
+		return m.openDetail(m.detail.issue.ID)
+	}
+	return nil
+}
+
+// applyFilter narrows the pane to issues fuzzy-matching the filter, best matches first
+func (m *tuiModel) applyFilter() {
+	if m.filter == "" {
+		m.visible = m.issues
+		return
+	}
+	type scored struct {
+		issue *types.Issue
+		score int
+	}
+	var matches []scored
+	for _, issue := range m.issues {
+		text := strings.Join(append([]string{issue.ID, issue.Title, issue.Assignee}, m.labels[issue.ID]...), " ")
+		if score, ok := fuzzyMatch(m.filter, text); ok {
+			matches = append(matches, scored{issue, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	m.visible = make([]*types.Issue, len(matches))
+	for i, match := range matches {
+		m.visible[i] = match.issue
+	}
+}
+
+// fuzzyMatch reports whether pattern's characters appear in text in order, ignoring
+// case. Consecutive characters and matches at word starts score higher.
+func fuzzyMatch(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	score, pi, prev := 0, 0, -2
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		if t[ti] != p[pi] {
+			continue
+		}
+		score++
+		if ti == prev+1 {
+			score += 2
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3
+		}
+		prev = ti
+		pi++
+	}
+	return score, pi == len(p)
+}
+
+func (m *tuiModel) selected() *types.Issue {
+	if m.detail != nil {
+		return m.detail.issue
+	}
+	if m.cursor >= 0 && m.cursor < len(m.visible) {
+		return m.visible[m.cursor]
+	}
+	return nil
+}
+
+func (m *tuiModel) listHeight() int {
+	if h := m.height - 4; h > 1 {
+		return h
+	}
+	return 1
+}
+
+func (m *tuiModel) clampCursor() {
+	if m.cursor >= len(m.visible) {
+		m.cursor = len(m.visible) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if h := m.listHeight(); m.cursor >= m.offset+h {
+		m.offset = m.cursor - h + 1
+	}
+}
+
+func (m *tuiModel) openDetail(id string) error {
+	issue, err := store.GetIssue(m.ctx, id)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		m.detail = nil
+		return nil
+	}
+	d := &tuiDetail{issue: issue}
+	if m.detail != nil && m.detail.issue.ID == id {
+		d.scroll = m.detail.scroll
+	}
+	if d.labels, err = store.GetLabels(m.ctx, id); err != nil {
+		return err
+	}
+	if d.deps, err = store.GetDependencyRecords(m.ctx, id); err != nil {
+		return err
+	}
+	if d.dependents, err = store.GetDependents(m.ctx, id); err != nil {
+		return err
+	}
+	if d.events, err = store.GetEvents(m.ctx, id, 50); err != nil {
+		return err
+	}
+	m.detail = d
+	return nil
+}
+
+// handleKey applies one key press (as decoded by decodeTUIKeys)
+func (m *tuiModel) handleKey(key string) {
+	if key == "ctrl+c" {
+		m.quit = true
+		return
+	}
+	if m.help {
+		m.help = false
+		return
+	}
+	if m.prompt != nil {
+		m.handlePromptKey(key)
+		return
+	}
+	if m.filtering {
+		m.handleFilterKey(key)
+		return
+	}
+
+	m.message = ""
+	switch key {
+	case "q", "esc":
+		switch {
+		case m.detail != nil:
+			m.detail = nil
+		case key == "esc" && m.filter != "":
+			m.filter = ""
+			m.applyFilter()
+			m.clampCursor()
+		case key == "q":
+			m.quit = true
+		}
+	case "?":
+		m.help = true
+	case "1", "2", "3":
+		m.switchPane(int(key[0] - '1'))
+	case "tab":
+		m.switchPane((m.pane + 1) % len(tuiPanes))
+	case "shift+tab":
+		m.switchPane((m.pane + len(tuiPanes) - 1) % len(tuiPanes))
+	case "/":
+		if m.detail == nil {
+			m.filtering = true
+		}
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup":
+		m.move(-m.listHeight())
+	case "pgdown":
+		m.move(m.listHeight())
+	case "home", "g":
+		m.move(-len(m.visible) - 1000)
+	case "end", "G":
+		m.move(len(m.visible) + 1000)
+	case "enter":
+		if issue := m.selected(); issue != nil && m.detail == nil {
+			m.report(m.openDetail(issue.ID))
+		}
+	case "r":
+		m.report(m.load())
+	case "s":
+		m.startPrompt("Status (open, in_progress, blocked, closed): ", "", m.setStatus)
+	case "c":
+		m.edit(func(issue *types.Issue) (string, error) { return m.setStatus(issue, "closed") })
+	case "p":
+		m.startPrompt("Priority (0-4): ", "", m.setPriority)
+	case "+":
+		m.edit(func(issue *types.Issue) (string, error) {
+			return m.setPriority(issue, strconv.Itoa(issue.Priority-1))
+		})
+	case "-":
+		m.edit(func(issue *types.Issue) (string, error) {
+			return m.setPriority(issue, strconv.Itoa(issue.Priority+1))
+		})
+	case "a":
+		if issue := m.selected(); issue != nil {
+			m.startPrompt("Assignee: ", issue.Assignee, m.setAssignee)
+		}
+	case "l":
+		m.startPrompt("Labels (+add -remove): ", "", m.editLabels)
+	case "d":
+		m.startPrompt("Dependencies (id or type:id adds, -id removes): ", "", m.editDependencies)
+	}
+}
+
+func (m *tuiModel) handleFilterKey(key string) {
+	switch key {
+	case "enter":
+		m.filtering = false
+	case "esc":
+		m.filtering = false
+		m.filter = ""
+	case "backspace":
+		if m.filter != "" {
+			_, size := utf8.DecodeLastRuneInString(m.filter)
+			m.filter = m.filter[:len(m.filter)-size]
+		}
+	case "up", "down":
+		m.filtering = false
+		m.handleKey(key)
+		return
+	default:
+		if utf8.RuneCountInString(key) != 1 {
+			return
+		}
+		m.filter += key
+	}
+	m.cursor, m.offset = 0, 0
+	m.applyFilter()
+	m.clampCursor()
+}
+
+func (m *tuiModel) handlePromptKey(key string) {
+	switch key {
+	case "esc":
+		m.prompt = nil
+	case "enter":
+		prompt := m.prompt
+		m.prompt = nil
+		m.edit(func(issue *types.Issue) (string, error) { return prompt.apply(issue, prompt.input) })
+	case "backspace":
+		if m.prompt.input != "" {
+			_, size := utf8.DecodeLastRuneInString(m.prompt.input)
+			m.prompt.input = m.prompt.input[:len(m.prompt.input)-size]
+		}
+	default:
+		if utf8.RuneCountInString(key) == 1 {
+			m.prompt.input += key
+		}
+	}
+}
+
+func (m *tuiModel) switchPane(pane int) {
+	m.pane = pane
+	m.detail = nil
+	m.cursor, m.offset = 0, 0
+	m.report(m.load())
+}
+
+func (m *tuiModel) move(delta int) {
+	if m.detail != nil {
+		m.detail.scroll += delta
+		if m.detail.scroll < 0 {
+			m.detail.scroll = 0
+		}
+		return
+	}
+	m.cursor += delta
+	m.clampCursor()
+}
+
+func (m *tuiModel) startPrompt(label, input string, apply func(*types.Issue, string) (string, error)) {
+	if m.selected() == nil {
+		return
+	}
+	m.prompt = &tuiPrompt{label: label, input: input, apply: apply}
+}
+
+// edit applies a change to the selected issue, then schedules a flush and reloads
+func (m *tuiModel) edit(change func(issue *types.Issue) (string, error)) {
+	issue := m.selected()
+	if issue == nil {
+		return
+	}
+	msg, err := change(issue)
+	if err != nil {
+		m.report(err)
+		return
+	}
+	markDirtyAndScheduleFlush()
+	if err := m.load(); err != nil {
+		m.report(err)
+		return
+	}
+	m.message = msg
+}
+
+func (m *tuiModel) report(err error) {
+	if err != nil {
+		m.message = "Error: " + err.Error()
+	}
+}
+
+// setStatus accepts a status or a prefix of one ("i" for in_progress). Closing goes
+// through CloseIssue, as 'bd close' does, and spawns the next occurrence of a
+// recurring issue.
+func (m *tuiModel) setStatus(issue *types.Issue, input string) (string, error) {
+	input = strings.TrimSpace(strings.ToLower(input))
+	var status types.Status
+	for _, s := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusClosed} {
+		if input != "" && strings.HasPrefix(string(s), input) {
+			status = s
+			break
+		}
+	}
+	if status == "" {
+		return "", fmt.Errorf("invalid status %q", input)
+	}
+	if status == issue.Status {
+		return fmt.Sprintf("%s is already %s", issue.ID, status), nil
+	}
+
+	if status == types.StatusClosed {
+		if err := store.CloseIssue(m.ctx, issue.ID, "Closed", actor); err != nil {
+			return "", err
+		}
+		spawned, err := store.SpawnNextOccurrence(m.ctx, issue.ID, actor)
+		if err != nil {
+			return "", fmt.Errorf("closed %s but failed to spawn its next occurrence: %w", issue.ID, err)
+		}
+		if spawned != nil {
+			return fmt.Sprintf("✓ Closed %s; next occurrence is %s", issue.ID, spawned.ID), nil
+		}
+		return fmt.Sprintf("✓ Closed %s", issue.ID), nil
+	}
+	if err := store.UpdateIssue(m.ctx, issue.ID, map[string]interface{}{"status": string(status)}, actor); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("✓ %s is now %s", issue.ID, status), nil
+}
+
+func (m *tuiModel) setPriority(issue *types.Issue, input string) (string, error) {
+	priority := parsePriority(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(input)), "P"))
+	if priority < 0 {
+		return "", fmt.Errorf("priority must be between 0 and 4")
+	}
+	if err := store.UpdateIssue(m.ctx, issue.ID, map[string]interface{}{"priority": priority}, actor); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("✓ %s is now P%d", issue.ID, priority), nil
+}
+
+func (m *tuiModel) setAssignee(issue *types.Issue, input string) (string, error) {
+	assignee := strings.TrimSpace(input)
+	if err := store.UpdateIssue(m.ctx, issue.ID, map[string]interface{}{"assignee": assignee}, actor); err != nil {
+		return "", err
+	}
+	if assignee == "" {
+		return fmt.Sprintf("✓ %s is unassigned", issue.ID), nil
+	}
+	return fmt.Sprintf("✓ %s is assigned to %s", issue.ID, assignee), nil
+}
+
+func (m *tuiModel) editLabels(issue *types.Issue, input string) (string, error) {
+	var added, removed []string
+	for _, token := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		if label, ok := strings.CutPrefix(token, "-"); ok {
+			if err := store.RemoveLabel(m.ctx, issue.ID, label, actor); err != nil {
+				return "", err
+			}
+			removed = append(removed, label)
+			continue
+		}
+		label := strings.TrimPrefix(token, "+")
+		if err := store.AddLabel(m.ctx, issue.ID, label, actor); err != nil {
+			return "", err
+		}
+		added = append(added, label)
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return "", fmt.Errorf("no labels given")
+	}
+	return fmt.Sprintf("✓ %s labels: +%d -%d", issue.ID, len(added), len(removed)), nil
+}
+
+func (m *tuiModel) editDependencies(issue *types.Issue, input string) (string, error) {
+	var changes []string
+	for _, token := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		if id, ok := strings.CutPrefix(token, "-"); ok {
+			if err := store.RemoveDependency(m.ctx, issue.ID, id, actor); err != nil {
+				return "", err
+			}
+			changes = append(changes, "-"+id)
+			continue
+		}
+		depType, id := types.DepBlocks, strings.TrimPrefix(token, "+")
+		if t, target, ok := strings.Cut(id, ":"); ok {
+			depType, id = types.DependencyType(t), target
+		}
+		if !depType.IsValid() {
+			return "", fmt.Errorf("invalid dependency type %q", depType)
+		}
+		dep := &types.Dependency{IssueID: issue.ID, DependsOnID: id, Type: depType}
+		if err := store.AddDependency(m.ctx, dep, actor); err != nil {
+			return "", err
+		}
+		changes = append(changes, "+"+id)
+	}
+	if len(changes) == 0 {
+		return "", fmt.Errorf("no dependencies given")
+	}
+	return fmt.Sprintf("✓ %s dependencies: %s", issue.ID, strings.Join(changes, " ")), nil
+}
+
+// render draws the screen as exactly height lines, each at most width columns
+// (ANSI styling aside)
+func (m *tuiModel) render() []string {
+	var header strings.Builder
+	header.WriteString(tuiBold + " bd " + tuiReset)
+	for i, name := range tuiPanes {
+		label := fmt.Sprintf(" %d %s ", i+1, name)
+		if i == m.pane {
+			header.WriteString(" " + tuiReverse + label + tuiReset)
+		} else {
+			header.WriteString(" " + label)
+		}
+	}
+	if m.filter != "" || m.filtering {
+		header.WriteString("   /" + m.filter)
+	}
+
+	lines := []string{header.String(), tuiDim + strings.Repeat("─", m.width) + tuiReset}
+	body := m.listHeight()
+	switch {
+	case m.help:
+		lines = append(lines, m.window(tuiHelp, 0, body)...)
+	case m.detail != nil:
+		detail := m.detailLines()
+		if max := len(detail) - body; m.detail.scroll > max && max >= 0 {
+			m.detail.scroll = max
+		}
+		lines = append(lines, m.window(detail, m.detail.scroll, body)...)
+	default:
+		lines = append(lines, m.listLines(body)...)
+	}
+	lines = append(lines, tuiDim+strings.Repeat("─", m.width)+tuiReset, m.footer())
+	return lines
+}
+
+// window returns body lines of content starting at offset, padded with blanks
+func (m *tuiModel) window(content []string, offset, body int) []string {
+	lines := make([]string, 0, body)
+	for i := offset; i < len(content) && len(lines) < body; i++ {
+		lines = append(lines, truncateRunes(content[i], m.width))
+	}
+	for len(lines) < body {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+func (m *tuiModel) listLines(body int) []string {
+	if len(m.visible) == 0 {
+		msg := "No issues"
+		if m.filter != "" {
+			msg = "No issues match the filter"
+		}
+		return m.window([]string{"  " + msg}, 0, body)
+	}
+
+	idWidth := 0
+	for _, issue := range m.visible {
+		if len(issue.ID) > idWidth {
+			idWidth = len(issue.ID)
+		}
+	}
+
+	lines := make([]string, 0, body)
+	for i := m.offset; i < len(m.visible) && len(lines) < body; i++ {
+		issue := m.visible[i]
+		row := fmt.Sprintf(" %-*s  P%d  %-7s  %-11s  %s", idWidth, issue.ID, issue.Priority, issue.IssueType, issue.Status, issue.Title)
+		if issue.Assignee != "" {
+			row += "  @" + issue.Assignee
+		}
+		if blockers := m.blockedBy[issue.ID]; len(blockers) > 0 {
+			row += "  ← " + strings.Join(blockers, ", ")
+		}
+		row = truncateRunes(row, m.width)
+
+		switch {
+		case i == m.cursor:
+			row = tuiReverse + padRunes(row, m.width) + tuiReset
+		case issue.Status == types.StatusClosed:
+			row = tuiDim + row + tuiReset
+		case issue.Status == types.StatusInProgress:
+			row = tuiYellow + row + tuiReset
+		case issue.Status == types.StatusBlocked:
+			row = tuiRed + row + tuiReset
+		}
+		lines = append(lines, row)
+	}
+	for len(lines) < body {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// detailLines renders the detail view, wrapped to the screen width
+func (m *tuiModel) detailLines() []string {
+	d := m.detail
+	issue := d.issue
+	var lines []string
+	add := func(s string) { lines = append(lines, s) }
+	section := func(title string) { add(""); add(tuiCyan + title + tuiReset) }
+
+	add(tuiBold + issue.ID + ": " + issue.Title + tuiReset)
+	meta := fmt.Sprintf("Status: %s   Priority: P%d   Type: %s", issue.Status, issue.Priority, issue.IssueType)
+	if issue.Assignee != "" {
+		meta += "   Assignee: " + issue.Assignee
+	}
+	add(meta)
+	if len(d.labels) > 0 {
+		add("Labels: " + strings.Join(d.labels, ", "))
+	}
+	if issue.DueAt != nil {
+		add("Due: " + formatDate(*issue.DueAt))
+	}
+	if issue.ExternalRef != nil {
+		add("External ref: " + *issue.ExternalRef)
+	}
+
+	for _, field := range []struct{ title, text string }{
+		{"Description", issue.Description},
+		{"Design", issue.Design},
+		{"Acceptance Criteria", issue.AcceptanceCriteria},
+		{"Notes", issue.Notes},
+	} {
+		if field.text == "" {
+			continue
+		}
+		section(field.title)
+		for _, line := range wrapText(field.text, m.width-2) {
+			add("  " + line)
+		}
+	}
+
+	if len(d.deps) > 0 {
+		section(fmt.Sprintf("Depends on (%d)", len(d.deps)))
+		for _, dep := range d.deps {
+			title, status := "", ""
+			if target, err := store.GetIssue(m.ctx, dep.DependsOnID); err == nil && target != nil {
+				title, status = target.Title, string(target.Status)
+			}
+			add(fmt.Sprintf("  → %s [%s] %s (%s)", dep.DependsOnID, dep.Type, title, status))
+		}
+	}
+	if len(d.dependents) > 0 {
+		section(fmt.Sprintf("Dependents (%d)", len(d.dependents)))
+		for _, dep := range d.dependents {
+			add(fmt.Sprintf("  ← %s %s (%s)", dep.ID, dep.Title, dep.Status))
+		}
+	}
+	if len(d.events) > 0 {
+		section("Events")
+		for _, e := range d.events {
+			line := fmt.Sprintf("  %s  %-10s %s", e.CreatedAt.Local().Format("2006-01-02 15:04"), e.Actor, e.EventType)
+			if e.OldValue != nil && e.NewValue != nil && len(*e.OldValue)+len(*e.NewValue) < 60 {
+				line += fmt.Sprintf(": %s → %s", *e.OldValue, *e.NewValue)
+			}
+			if e.Comment != nil {
+				line += ": " + strings.ReplaceAll(*e.Comment, "\n", " ")
+			}
+			add(line)
+		}
+	}
+	return lines
+}
+
+func (m *tuiModel) footer() string {
+	switch {
+	case m.prompt != nil:
+		return m.prompt.label + m.prompt.input + "█"
+	case m.filtering:
+		return "Filter: " + m.filter + "█"
+	case m.message != "":
+		return truncateRunes(m.message, m.width)
+	case m.help:
+		return ""
+	case m.detail != nil:
+		return tuiDim + "Esc back  j/k scroll  s status  c close  p priority  a assignee  l labels  d deps  ? help" + tuiReset
+	}
+	return tuiDim + truncateRunes(fmt.Sprintf("%d issues  Enter details  / filter  s status  c close  p priority  a assignee  l labels  d deps  ? help  q quit",
+		len(m.visible)), m.width) + tuiReset
+}
+
+// draw writes the rendered screen, repainting in place to avoid flicker
+func (m *tuiModel) draw(w io.Writer) {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("\x1b[H")
+	for i, line := range m.render() {
+		if i > 0 {
+			bw.WriteString("\r\n")
+		}
+		bw.WriteString(line + tuiReset + "\x1b[K")
+	}
+	bw.WriteString("\x1b[J")
+	_ = bw.Flush()
+}
+
+func truncateRunes(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+func padRunes(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// wrapText wraps text at word boundaries, keeping its line breaks
+func wrapText(text string, width int) []string {
+	if width < 10 {
+		width = 10
+	}
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			switch {
+			case line == "":
+				line = word
+			case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width:
+				lines = append(lines, line)
+				line = word
+			default:
+				line += " " + word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// tuiCSIKeys names the escape sequences of special keys, after "ESC [" or "ESC O"
+var tuiCSIKeys = map[string]string{
+	"A": "up", "B": "down", "C": "right", "D": "left",
+	"H": "home", "F": "end", "1~": "home", "4~": "end",
+	"5~": "pgup", "6~": "pgdown", "Z": "shift+tab",
+}
+
+// decodeTUIKeys splits raw terminal input into keys: printable characters as
+// themselves, special keys by name ("up", "enter", "esc", ...)
+func decodeTUIKeys(buf []byte) []string {
+	var keys []string
+	for len(buf) > 0 {
+		switch c := buf[0]; {
+		case c == 0x1b && len(buf) >= 3 && (buf[1] == '[' || buf[1] == 'O'):
+			end := 2
+			for end < len(buf) && (buf[end] < 0x40 || buf[end] > 0x7e) {
+				end++
+			}
+			if end == len(buf) {
+				keys = append(keys, "esc")
+				buf = buf[1:]
+				continue
+			}
+			if key := tuiCSIKeys[string(buf[2:end+1])]; key != "" {
+				keys = append(keys, key)
+			}
+			buf = buf[end+1:]
+		case c == 0x1b:
+			keys = append(keys, "esc")
+			buf = buf[1:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, "enter")
+			buf = buf[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, "backspace")
+			buf = buf[1:]
+		case c == '\t':
+			keys = append(keys, "tab")
+			buf = buf[1:]
+		case c == 0x03:
+			keys = append(keys, "ctrl+c")
+			buf = buf[1:]
+		case c < 0x20:
+			buf = buf[1:]
+		default:
+			r, size := utf8.DecodeRune(buf)
+			keys = append(keys, string(r))
+			buf = buf[size:]
+		}
+	}
+	return keys
+}
+
+// runTUI runs the interface until the user quits
+func runTUI(ctx context.Context) error {
+	term, err := openTUITerminal()
+	if err != nil {
+		return err
+	}
+	defer term.restore()
+
+	fmt.Print("\x1b[?1049h\x1b[?25l") // Alternate screen, hidden cursor
+	defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+	width, height := term.size()
+	m := newTUIModel(ctx, width, height)
+	if err := m.load(); err != nil {
+		return err
+	}
+
+	keys := make(chan []string)
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- decodeTUIKeys(buf[:n])
+		}
+	}()
+	resize := make(chan os.Signal, 1)
+	stop := notifyTUIResize(resize)
+	defer stop()
+
+	for !m.quit {
+		m.draw(os.Stdout)
+		select {
+		case batch, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, key := range batch {
+				m.handleKey(key)
+			}
+		case <-resize:
+			m.width, m.height = term.size()
+			m.clampCursor()
+		}
+	}
+	return nil
+}
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and triage issues in a full-screen terminal interface",
+	Long: `Browse and triage issues in a full-screen terminal interface.
+
+Panes list ready, blocked and all issues; / filters them with fuzzy matching
+and Enter shows an issue's details, dependencies and events. Keys change the
+selected issue's status, priority, assignee, labels and dependencies. Changes
+are saved immediately and exported like any other bd command's.
+
+Press ? inside the TUI for all keybindings.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runTUI(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
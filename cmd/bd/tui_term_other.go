@@ -0,0 +1,25 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// tuiTerminal is not implemented on this platform
+type tuiTerminal struct{}
+
+func openTUITerminal() (*tuiTerminal, error) {
+	return nil, fmt.Errorf("bd tui is not supported on this platform")
+}
+
+func (t *tuiTerminal) restore() {}
+
+func (t *tuiTerminal) size() (int, int) {
+	return 80, 24
+}
+
+func notifyTUIResize(ch chan<- os.Signal) func() {
+	return func() {}
+}
@@ -0,0 +1,61 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// tuiTerminal holds the terminal state to restore when the TUI exits
+type tuiTerminal struct {
+	fd    int
+	saved *unix.Termios
+}
+
+// openTUITerminal switches stdin to raw mode: no echo, no line buffering, and
+// no signals from Ctrl-C, which the TUI handles as a key
+func openTUITerminal() (*tuiTerminal, error) {
+	fd := int(os.Stdin.Fd())
+	saved, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return nil, fmt.Errorf("bd tui needs an interactive terminal: %w", err)
+	}
+
+	raw := *saved
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlWriteTermios, &raw); err != nil {
+		return nil, fmt.Errorf("failed to set raw mode: %w", err)
+	}
+	return &tuiTerminal{fd: fd, saved: saved}, nil
+}
+
+func (t *tuiTerminal) restore() {
+	_ = unix.IoctlSetTermios(t.fd, ioctlWriteTermios, t.saved)
+}
+
+// size returns the terminal's width and height, or 80x24 if unknown
+func (t *tuiTerminal) size() (int, int) {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil || ws.Col == 0 || ws.Row == 0 {
+		return 80, 24
+	}
+	return int(ws.Col), int(ws.Row)
+}
+
+// notifyTUIResize sends on ch when the terminal is resized. Call the returned
+// function to stop.
+func notifyTUIResize(ch chan<- os.Signal) func() {
+	signal.Notify(ch, syscall.SIGWINCH)
+	return func() { signal.Stop(ch) }
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDecodeTUIKeys(t *testing.T) {
+	input := []byte("j\x1b[A\x1b[6~\x1bOH\r\x7f\t\x1b[Z\x03é\x1b")
+	want := []string{"j", "up", "pgdown", "home", "enter", "backspace", "tab", "shift+tab", "ctrl+c", "é", "esc"}
+	if got := decodeTUIKeys(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeTUIKeys = %q, want %q", got, want)
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	if _, ok := fuzzyMatch("lgn", "Fix login bug"); !ok {
+		t.Error("Expected subsequence match")
+	}
+	if _, ok := fuzzyMatch("xyz", "Fix login bug"); ok {
+		t.Error("Expected no match")
+	}
+	word, _ := fuzzyMatch("log", "Fix login bug")
+	scattered, _ := fuzzyMatch("log", "lazy dog")
+	if word <= scattered {
+		t.Errorf("Expected contiguous word match to score higher (%d <= %d)", word, scattered)
+	}
+}
+
+func TestTUIModelEdits(t *testing.T) {
+	testStore, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer testStore.Close()
+
+	oldStore, oldAutoFlush := store, autoFlushEnabled
+	store, autoFlushEnabled = testStore, false
+	defer func() { store, autoFlushEnabled = oldStore, oldAutoFlush }()
+
+	ctx := context.Background()
+	for _, issue := range []*types.Issue{
+		{ID: "test-1", Title: "Fix login bug", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug},
+		{ID: "test-2", Title: "Write release notes", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	m := newTUIModel(ctx, 120, 20)
+	if err := m.load(); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(m.visible) != 2 {
+		t.Fatalf("Expected 2 ready issues, got %d", len(m.visible))
+	}
+	keys := func(ks ...string) {
+		for _, k := range ks {
+			m.handleKey(k)
+		}
+	}
+	typed := func(s string) []string { return strings.Split(s, "") }
+	selectIssue := func(id string) {
+		keys("g")
+		for i := 0; i < len(m.visible) && m.selected().ID != id; i++ {
+			keys("j")
+		}
+		if m.selected().ID != id {
+			t.Fatalf("%s is not in the current pane", id)
+		}
+	}
+
+	// Filter the All pane down to test-1 and edit it
+	keys("3", "/")
+	keys(typed("login")...)
+	keys("enter")
+	if len(m.visible) != 1 || m.selected().ID != "test-1" {
+		t.Fatalf("Expected filter to select test-1, got %d issues", len(m.visible))
+	}
+	keys("p", "1", "enter")
+	keys("s", "i", "enter")
+	keys("a")
+	keys(typed("alice")...)
+	keys("enter")
+	keys("l")
+	keys(typed("auth +ui")...)
+	keys("enter")
+
+	issue, _ := testStore.GetIssue(ctx, "test-1")
+	if issue.Priority != 1 || issue.Status != types.StatusInProgress || issue.Assignee != "alice" {
+		t.Errorf("Unexpected issue after edits: %+v", issue)
+	}
+	if labels, _ := testStore.GetLabels(ctx, "test-1"); strings.Join(labels, ",") != "auth,ui" {
+		t.Errorf("Labels = %v", labels)
+	}
+	keys("l", "-", "u", "i", "enter")
+	if labels, _ := testStore.GetLabels(ctx, "test-1"); strings.Join(labels, ",") != "auth" {
+		t.Errorf("Labels after removal = %v", labels)
+	}
+
+	// test-2 depends on test-1, so it moves to the blocked pane
+	keys("esc")
+	if len(m.visible) != 2 {
+		t.Fatalf("Expected Esc to clear the filter, got %d issues", len(m.visible))
+	}
+	selectIssue("test-2")
+	keys("d")
+	keys(typed("test-1")...)
+	keys("enter")
+	if !strings.Contains(m.message, "dependencies: +test-1") {
+		t.Errorf("Unexpected message %q", m.message)
+	}
+	keys("2")
+	if len(m.visible) != 1 || m.visible[0].ID != "test-2" || m.blockedBy["test-2"][0] != "test-1" {
+		t.Fatalf("Expected test-2 to be blocked by test-1, got %+v", m.blockedBy)
+	}
+	if screen := strings.Join(m.render(), "\n"); !strings.Contains(screen, "← test-1") {
+		t.Errorf("Blocked pane should show blockers:\n%s", screen)
+	}
+
+	// Detail view shows dependencies and events; closing the blocker unblocks test-2
+	keys("3")
+	if len(m.visible) != 2 {
+		t.Fatalf("Expected 2 issues in the All pane, got %d", len(m.visible))
+	}
+	selectIssue("test-1")
+	keys("enter")
+	screen := strings.Join(m.render(), "\n")
+	for _, want := range []string{"test-1: Fix login bug", "Dependents (1)", "test-2 Write release notes", "Events", "Assignee: alice"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Detail view missing %q:\n%s", want, screen)
+		}
+	}
+	keys("c")
+	if issue, _ := testStore.GetIssue(ctx, "test-1"); issue.Status != types.StatusClosed || issue.ClosedAt == nil {
+		t.Errorf("Expected test-1 to be closed: %+v", issue)
+	}
+	keys("q", "1")
+	if len(m.visible) != 1 || m.visible[0].ID != "test-2" {
+		t.Errorf("Expected test-2 to be ready after closing test-1")
+	}
+	if m.quit {
+		t.Error("q in the detail view should only close it")
+	}
+	keys("q")
+	if !m.quit {
+		t.Error("Expected q to quit from the list")
+	}
+
+	if lines := m.render(); len(lines) != 20 {
+		t.Errorf("Expected render to fill the 20-line screen, got %d lines", len(lines))
+	}
+}
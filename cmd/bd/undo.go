@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert recent changes using the audit trail",
+	Long: `Revert recent changes by applying the inverse of recorded events.
+
+Field updates are restored to their previous values, closed issues are reopened,
+and added/removed labels and dependencies are removed/re-added. All inverse
+operations run in one transaction and are themselves recorded as events.
+
+Undo refuses to run if a later change (not part of the undo) touched the same
+field, label or dependency. Creation, comments and compaction cannot be undone.
+
+Select events with one of:
+  --last N                 the N most recent reversible events (default: 1)
+  --event ID               specific event IDs (repeatable)
+  --actor NAME --since 1h  everything NAME did in the window
+
+Note: within this command --actor selects whose events to undo. The undo itself
+is recorded under $BD_ACTOR or $USER.
+
+Examples:
+  bd undo --dry-run
+  bd undo --last 5
+  bd undo --actor agent-7 --since 1h --dry-run
+  bd undo --event 1042 --event 1043`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		last, _ := cmd.Flags().GetInt("last")
+		eventIDs, _ := cmd.Flags().GetInt64Slice("event")
+		byActor, _ := cmd.Flags().GetString("actor")
+		sinceStr, _ := cmd.Flags().GetString("since")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: undo requires SQLite storage\n")
+			os.Exit(1)
+		}
+
+		if len(eventIDs) > 0 && (last > 0 || byActor != "" || sinceStr != "") {
+			fmt.Fprintf(os.Stderr, "Error: --event cannot be combined with --last, --actor or --since\n")
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		filter := types.EventFilter{IDs: eventIDs, Actor: byActor}
+		if sinceStr != "" {
+			d, err := parseRelativeDuration(sinceStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --since: %v\n", err)
+				os.Exit(1)
+			}
+			since := time.Now().Add(-d)
+			filter.Since = &since
+		}
+		if len(eventIDs) == 0 && byActor == "" && sinceStr == "" && last == 0 {
+			last = 1
+		}
+		// --last counts only events that can actually be reverted (comments etc. are ignored),
+		// so only those are fetched, newest first
+		if last > 0 {
+			filter.Types = sqlite.UndoableEventTypes
+			filter.Limit = last
+		}
+
+		events, err := store.SearchEvents(ctx, filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(eventIDs) > 0 && len(events) != len(eventIDs) {
+			found := make(map[int64]bool, len(events))
+			for _, e := range events {
+				found[e.ID] = true
+			}
+			for _, id := range eventIDs {
+				if !found[id] {
+					fmt.Fprintf(os.Stderr, "Error: event %d not found\n", id)
+				}
+			}
+			os.Exit(1)
+		}
+
+		if len(events) == 0 {
+			if jsonOutput {
+				outputJSON(&sqlite.UndoPlan{Operations: []*sqlite.UndoOperation{}})
+			} else {
+				fmt.Println("No events to undo")
+			}
+			return
+		}
+
+		plan, err := sqliteStore.PlanUndo(ctx, events)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput && (dryRun || len(plan.Conflicts) > 0) {
+			outputJSON(plan)
+			if len(plan.Conflicts) > 0 {
+				os.Exit(1)
+			}
+			return
+		}
+		if !jsonOutput {
+			printUndoPlan(plan, dryRun)
+		}
+
+		if len(plan.Conflicts) > 0 {
+			fmt.Fprintf(os.Stderr, "Error: refusing to undo because later changes conflict with the revert\n")
+			os.Exit(1)
+		}
+		if dryRun || len(plan.Operations) == 0 {
+			return
+		}
+
+		if err := sqliteStore.ApplyUndo(ctx, plan, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Schedule auto-flush
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(plan)
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Undid %d event(s)\n", green("✓"), len(plan.Operations))
+	},
+}
+
+// printUndoPlan shows the operations, skipped events and conflicts of an undo plan
+func printUndoPlan(plan *sqlite.UndoPlan, dryRun bool) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	if dryRun {
+		fmt.Printf("\n%s Dry run: would undo %d event(s)\n", yellow("⚠"), len(plan.Operations))
+	} else {
+		fmt.Printf("\nUndoing %d event(s):\n", len(plan.Operations))
+	}
+	for _, op := range plan.Operations {
+		fmt.Printf("  #%d %s %s by %s → %s\n", op.EventID, cyan(op.IssueID), op.EventType, op.EventActor, op.Description)
+	}
+
+	if len(plan.Skipped) > 0 {
+		fmt.Printf("\nSkipped %d event(s):\n", len(plan.Skipped))
+		for _, skip := range plan.Skipped {
+			fmt.Printf("  #%d %s %s: %s\n", skip.EventID, skip.IssueID, skip.EventType, skip.Reason)
+		}
+	}
+
+	if len(plan.Conflicts) > 0 {
+		fmt.Printf("\n%s %d conflict(s):\n", red("✗"), len(plan.Conflicts))
+		for _, c := range plan.Conflicts {
+			fmt.Printf("  #%d %s: %s (event #%d)\n", c.EventID, c.IssueID, c.Reason, c.ConflictingEventID)
+		}
+	}
+	fmt.Println()
+}
+
+// parseRelativeDuration parses durations like "90m", "1h", "3d" or "2w".
+// It extends time.ParseDuration with day (d) and week (w) units.
+func parseRelativeDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	unit := s[len(s)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.ParseFloat(s[:len(s)-1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		day := 24 * time.Hour
+		if unit == 'w' {
+			day *= 7
+		}
+		return time.Duration(n * float64(day)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (examples: 30m, 1h, 3d, 2w)", s)
+	}
+	return d, nil
+}
+
+func init() {
+	undoCmd.Flags().Int("last", 0, "Undo the N most recent reversible events")
+	undoCmd.Flags().Int64Slice("event", []int64{}, "Undo specific event IDs (repeatable)")
+	undoCmd.Flags().String("actor", "", "Only undo events recorded by this actor")
+	undoCmd.Flags().String("since", "", "Only undo events newer than this (e.g., 30m, 1h, 2d)")
+	undoCmd.Flags().Bool("dry-run", false, "Preview the inverse operations without applying them")
+	rootCmd.AddCommand(undoCmd)
+}
@@ -3,13 +3,15 @@ module github.com/steveyegge/beads
 go 1.23.0
 
 require (
+	github.com/anthropics/anthropic-sdk-go v1.14.0
 	github.com/fatih/color v1.18.0
 	github.com/spf13/cobra v1.10.1
+	golang.org/x/sys v0.34.0
 	modernc.org/sqlite v1.38.2
+	rsc.io/script v0.0.2
 )
 
 require (
-	github.com/anthropics/anthropic-sdk-go v1.14.0 // indirect
 	github.com/dustin/go-humanize v1.0.1 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
@@ -23,10 +25,8 @@ require (
 	github.com/tidwall/pretty v1.2.1 // indirect
 	github.com/tidwall/sjson v1.2.5 // indirect
 	golang.org/x/exp v0.0.0-20250620022241-b7579e27df2b // indirect
-	golang.org/x/sys v0.34.0 // indirect
 	golang.org/x/tools v0.34.0 // indirect
 	modernc.org/libc v1.66.3 // indirect
 	modernc.org/mathutil v1.7.1 // indirect
 	modernc.org/memory v1.11.0 // indirect
-	rsc.io/script v0.0.2 // indirect
 )
@@ -18,6 +18,8 @@ from .models import (
     ReopenIssueParams,
     ShowIssueParams,
     Stats,
+    StatsByParams,
+    StatsGroup,
     UpdateIssueParams,
 )
 
@@ -478,6 +480,25 @@ class BdClient:
 
         return Stats.model_validate(data)
 
+    async def stats_by(self, params: StatsByParams) -> list[StatsGroup]:
+        """Get statistics grouped by label, assignee, type or priority.
+
+        Args:
+            params: Grouping and optional window for closed issues
+
+        Returns:
+            List of statistics groups
+        """
+        args = ["stats", "--by", params.group_by]
+        if params.since:
+            args.extend(["--since", params.since])
+
+        data = await self._run_command(*args)
+        if not isinstance(data, list):
+            raise BdCommandError("Invalid response for stats --by")
+
+        return [StatsGroup.model_validate(group) for group in data]
+
     async def blocked(self) -> list[BlockedIssue]:
         """Get blocked issues.
 
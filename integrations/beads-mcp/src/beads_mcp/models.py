@@ -9,6 +9,7 @@ from pydantic import BaseModel, Field, field_validator
 IssueStatus = Literal["open", "in_progress", "blocked", "closed"]
 IssueType = Literal["bug", "feature", "task", "epic", "chore"]
 DependencyType = Literal["blocks", "related", "parent-child", "discovered-from"]
+StatsGroupBy = Literal["label", "assignee", "type", "priority"]
 
 
 class Issue(BaseModel):
@@ -137,6 +138,29 @@ class Stats(BaseModel):
     average_lead_time_hours: float
 
 
+class StatsGroup(BaseModel):
+    """Statistics for one label, assignee, type or priority ("P0"-"P4").
+
+    An empty key groups issues without a label or assignee.
+    """
+
+    key: str
+    total_issues: int
+    open_issues: int
+    in_progress_issues: int
+    blocked_issues: int
+    ready_issues: int
+    closed_issues: int
+    average_lead_time_hours: float
+
+
+class StatsByParams(BaseModel):
+    """Parameters for grouped statistics."""
+
+    group_by: StatsGroupBy
+    since: str | None = None
+
+
 class BlockedIssue(Issue):
     """Blocked issue with blocking information."""
 
@@ -4,7 +4,16 @@ import os
 
 from fastmcp import FastMCP
 
-from beads_mcp.models import BlockedIssue, DependencyType, Issue, IssueStatus, IssueType, Stats
+from beads_mcp.models import (
+    BlockedIssue,
+    DependencyType,
+    Issue,
+    IssueStatus,
+    IssueType,
+    Stats,
+    StatsGroup,
+    StatsGroupBy,
+)
 from beads_mcp.tools import (
     beads_add_dependency,
     beads_blocked,
@@ -17,6 +26,7 @@ from beads_mcp.tools import (
     beads_reopen_issue,
     beads_show_issue,
     beads_stats,
+    beads_stats_by,
     beads_update_issue,
 )
 
@@ -190,6 +200,15 @@ async def stats() -> Stats:
     return await beads_stats()
 
 
+@mcp.tool(
+    name="stats_by",
+    description="Get statistics grouped by label, assignee, type or priority: open, in_progress, blocked, ready, closed (optionally since a date) and average lead time per group.",
+)
+async def stats_by(group_by: StatsGroupBy, since: str | None = None) -> list[StatsGroup]:
+    """Get statistics grouped by label, assignee, type or priority."""
+    return await beads_stats_by(group_by=group_by, since=since)
+
+
 @mcp.tool(
     name="blocked",
     description="Get blocked issues showing what dependencies are blocking them from being worked on.",
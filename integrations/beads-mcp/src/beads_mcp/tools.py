@@ -18,6 +18,9 @@ from .models import (
     ReopenIssueParams,
     ShowIssueParams,
     Stats,
+    StatsByParams,
+    StatsGroup,
+    StatsGroupBy,
     UpdateIssueParams,
 )
 
@@ -242,6 +245,20 @@ async def beads_stats() -> Stats:
     return await client.stats()
 
 
+async def beads_stats_by(
+    group_by: Annotated[StatsGroupBy, "Group by label, assignee, type or priority"],
+    since: Annotated[str | None, "Only count issues closed since this date or window (e.g., 7d, 2025-11-01)"] = None,
+) -> list[StatsGroup]:
+    """Get statistics grouped by label, assignee, type or priority.
+
+    Each group has total, open, in_progress, blocked, ready and closed counts and
+    the average lead time in hours, e.g. to see what one agent has in flight.
+    """
+    client = await _get_client()
+    params = StatsByParams(group_by=group_by, since=since)
+    return await client.stats_by(params)
+
+
 async def beads_blocked() -> list[BlockedIssue]:
     """Get blocked issues.
 
@@ -14,6 +14,7 @@ from beads_mcp.models import (
     ReadyWorkParams,
     ReopenIssueParams,
     ShowIssueParams,
+    StatsByParams,
     UpdateIssueParams,
 )
 
@@ -647,6 +648,57 @@ async def test_stats_invalid_response(bd_client, mock_process):
         await bd_client.stats()
 
 
+@pytest.mark.asyncio
+async def test_stats_by(bd_client, mock_process):
+    """Test stats_by method."""
+    groups_data = [
+        {
+            "key": "alice",
+            "total_issues": 4,
+            "open_issues": 1,
+            "in_progress_issues": 2,
+            "closed_issues": 1,
+            "blocked_issues": 0,
+            "ready_issues": 1,
+            "average_lead_time_hours": 6.0,
+        },
+        {
+            "key": "",
+            "total_issues": 2,
+            "open_issues": 2,
+            "in_progress_issues": 0,
+            "closed_issues": 0,
+            "blocked_issues": 1,
+            "ready_issues": 1,
+            "average_lead_time_hours": 0,
+        },
+    ]
+    mock_process.communicate = AsyncMock(return_value=(json.dumps(groups_data).encode(), b""))
+
+    with patch("asyncio.create_subprocess_exec", return_value=mock_process) as mock_exec:
+        result = await bd_client.stats_by(StatsByParams(group_by="assignee", since="7d"))
+
+    assert len(result) == 2
+    assert result[0].key == "alice"
+    assert result[0].in_progress_issues == 2
+    assert result[1].key == ""
+    args = mock_exec.call_args[0]
+    assert "--by" in args and "assignee" in args
+    assert "--since" in args and "7d" in args
+
+
+@pytest.mark.asyncio
+async def test_stats_by_invalid_response(bd_client, mock_process):
+    """Test stats_by method with invalid response type."""
+    mock_process.communicate = AsyncMock(return_value=(json.dumps({"key": "x"}).encode(), b""))
+
+    with (
+        patch("asyncio.create_subprocess_exec", return_value=mock_process),
+        pytest.raises(BdCommandError, match="Invalid response for stats --by"),
+    ):
+        await bd_client.stats_by(StatsByParams(group_by="label"))
+
+
 @pytest.mark.asyncio
 async def test_blocked(bd_client, mock_process):
     """Test blocked method."""
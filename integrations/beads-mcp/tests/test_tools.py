@@ -4,7 +4,7 @@ from unittest.mock import AsyncMock, patch
 
 import pytest
 
-from beads_mcp.models import BlockedIssue, Issue, Stats
+from beads_mcp.models import BlockedIssue, Issue, Stats, StatsGroup
 from beads_mcp.tools import (
     beads_add_dependency,
     beads_blocked,
@@ -17,6 +17,7 @@ from beads_mcp.tools import (
     beads_reopen_issue,
     beads_show_issue,
     beads_stats,
+    beads_stats_by,
     beads_update_issue,
 )
 
@@ -383,6 +384,34 @@ async def test_beads_stats():
     mock_client.stats.assert_called_once()
 
 
+@pytest.mark.asyncio
+async def test_beads_stats_by():
+    """Test beads_stats_by tool."""
+    groups = [
+        StatsGroup(
+            key="bug",
+            total_issues=3,
+            open_issues=2,
+            in_progress_issues=0,
+            closed_issues=1,
+            blocked_issues=1,
+            ready_issues=1,
+            average_lead_time_hours=12.0,
+        )
+    ]
+    mock_client = AsyncMock()
+    mock_client.stats_by = AsyncMock(return_value=groups)
+
+    with patch("beads_mcp.tools._get_client", return_value=mock_client):
+        result = await beads_stats_by(group_by="type", since="2025-01-01")
+
+    assert len(result) == 1
+    assert result[0].key == "bug"
+    params = mock_client.stats_by.call_args[0][0]
+    assert params.group_by == "type"
+    assert params.since == "2025-01-01"
+
+
 @pytest.mark.asyncio
 async def test_beads_blocked():
     """Test beads_blocked tool."""
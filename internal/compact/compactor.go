@@ -2,26 +2,48 @@ package compact
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 const (
 	defaultConcurrency = 5
+	maxSummaryAttempts = 3 // The first summary plus two revisions
 )
 
 type CompactConfig struct {
 	APIKey      string
 	Concurrency int
 	DryRun      bool
+	Provider    string // ProviderAnthropic (default), ProviderOpenAI or ProviderOffline
+	Model       string // Overrides the provider's default model
+	BaseURL     string // OpenAI-compatible server URL, e.g. http://localhost:11434/v1
+	PromptDir   string // Directory of prompt template overrides, e.g. .beads/prompts
+}
+
+// ModelName returns the model compaction calls, or "" for the offline provider
+func (c *CompactConfig) ModelName() string {
+	switch {
+	case c.Provider == ProviderOffline:
+		return ""
+	case c.Model != "":
+		return c.Model
+	case c.Provider == "" || c.Provider == ProviderAnthropic:
+		return defaultModel
+	}
+	return ""
 }
 
 type Compactor struct {
-	store  *sqlite.SQLiteStorage
-	haiku  *HaikuClient
-	config *CompactConfig
+	store      *sqlite.SQLiteStorage
+	summarizer Summarizer
+	prompts    promptTemplates
+	config     *CompactConfig
 }
 
 func New(store *sqlite.SQLiteStorage, apiKey string, config *CompactConfig) (*Compactor, error) {
@@ -37,102 +59,105 @@ func New(store *sqlite.SQLiteStorage, apiKey string, config *CompactConfig) (*Co
 		config.APIKey = apiKey
 	}
 
-	var haikuClient *HaikuClient
-	var err error
+	prompts, err := loadPromptTemplates(config.PromptDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var summarizer Summarizer
 	if !config.DryRun {
-		haikuClient, err = NewHaikuClient(config.APIKey)
+		summarizer, err = newSummarizer(config, prompts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create Haiku client: %w", err)
+			return nil, fmt.Errorf("failed to create summarizer: %w", err)
 		}
 	}
 
 	return &Compactor{
-		store:  store,
-		haiku:  haikuClient,
-		config: config,
+		store:      store,
+		summarizer: summarizer,
+		prompts:    prompts,
+		config:     config,
 	}, nil
 }
 
 type CompactResult struct {
-	IssueID      string
-	OriginalSize int
+	IssueID       string
+	OriginalSize  int
 	CompactedSize int
-	Err          error
+	Usage         Usage // Tokens spent on summarizer calls, also when compaction was skipped
+	Err           error
+}
+
+// Model returns the model summaries come from, or "" when compacting offline.
+func (c *Compactor) Model() string {
+	return c.config.ModelName()
+}
+
+// RenderPrompt returns the prompt a model would be sent to compact issue at tier,
+// and the template file it came from ("built-in" without an override).
+func (c *Compactor) RenderPrompt(issue *types.Issue, tier int) (prompt string, source string, err error) {
+	_, source = c.prompts.lookup(tier, issue.IssueType)
+	if tier == 1 {
+		prompt, err = c.prompts.renderTier1Prompt(issue)
+	} else {
+		prompt, err = c.prompts.renderTier2Prompt(issue)
+	}
+	return prompt, source, err
 }
 
+// CompactTier1 summarizes a closed issue into its description and clears the
+// design, notes and acceptance criteria, keeping a snapshot of the original.
 func (c *Compactor) CompactTier1(ctx context.Context, issueID string) error {
+	return c.compactTier(ctx, issueID, 1)
+}
+
+// CompactTier2 reduces a Tier 1 summary to a single paragraph and archives the
+// issue's events into its snapshot.
+func (c *Compactor) CompactTier2(ctx context.Context, issueID string) error {
+	return c.compactTier(ctx, issueID, 2)
+}
+
+func (c *Compactor) compactTier(ctx context.Context, issueID string, tier int) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
 
-	eligible, reason, err := c.store.CheckEligibility(ctx, issueID, 1)
+	eligible, reason, err := c.store.CheckEligibility(ctx, issueID, tier)
 	if err != nil {
 		return fmt.Errorf("failed to verify eligibility: %w", err)
 	}
 
 	if !eligible {
 		if reason != "" {
-			return fmt.Errorf("issue %s is not eligible for Tier 1 compaction: %s", issueID, reason)
+			return fmt.Errorf("issue %s is not eligible for Tier %d compaction: %s", issueID, tier, reason)
 		}
-		return fmt.Errorf("issue %s is not eligible for Tier 1 compaction", issueID)
-	}
-
-	issue, err := c.store.GetIssue(ctx, issueID)
-	if err != nil {
-		return fmt.Errorf("failed to get issue: %w", err)
+		return fmt.Errorf("issue %s is not eligible for Tier %d compaction", issueID, tier)
 	}
 
-	originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
-
 	if c.config.DryRun {
-		return fmt.Errorf("dry-run: would compact %s (original size: %d bytes)", issueID, originalSize)
-	}
-
-	summary, err := c.haiku.SummarizeTier1(ctx, issue)
-	if err != nil {
-		return fmt.Errorf("failed to summarize with Haiku: %w", err)
-	}
-
-	compactedSize := len(summary)
-
-	if compactedSize >= originalSize {
-		warningMsg := fmt.Sprintf("Tier 1 compaction skipped: summary (%d bytes) not shorter than original (%d bytes)", compactedSize, originalSize)
-		if err := c.store.AddComment(ctx, issueID, "compactor", warningMsg); err != nil {
-			return fmt.Errorf("failed to record warning: %w", err)
+		issue, err := c.store.GetIssue(ctx, issueID)
+		if err != nil {
+			return fmt.Errorf("failed to get issue: %w", err)
 		}
-		return fmt.Errorf("compaction would increase size (%d → %d bytes), keeping original", originalSize, compactedSize)
-	}
-
-	updates := map[string]interface{}{
-		"description":         summary,
-		"design":              "",
-		"notes":               "",
-		"acceptance_criteria": "",
+		return fmt.Errorf("dry-run: would compact %s (original size: %d bytes)", issueID, contentSize(issue))
 	}
 
-	if err := c.store.UpdateIssue(ctx, issueID, updates, "compactor"); err != nil {
-		return fmt.Errorf("failed to update issue: %w", err)
-	}
-
-	commitHash := GetCurrentCommitHash()
-	if err := c.store.ApplyCompaction(ctx, issueID, 1, originalSize, compactedSize, commitHash); err != nil {
-		return fmt.Errorf("failed to set compaction level: %w", err)
-	}
-
-	savingBytes := originalSize - compactedSize
-	eventData := fmt.Sprintf("Tier 1 compaction: %d → %d bytes (saved %d)", originalSize, compactedSize, savingBytes)
-	if err := c.store.AddComment(ctx, issueID, "compactor", eventData); err != nil {
-		return fmt.Errorf("failed to record event: %w", err)
-	}
+	return c.compactSingleWithResult(ctx, issueID, tier, &CompactResult{IssueID: issueID})
+}
 
-	if err := c.store.MarkIssueDirty(ctx, issueID); err != nil {
-		return fmt.Errorf("failed to mark dirty: %w", err)
-	}
+// CompactTier1Batch runs Tier 1 compaction on the eligible issues concurrently.
+// Ineligible issues are reported as failed results.
+func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([]*CompactResult, error) {
+	return c.compactBatch(ctx, issueIDs, 1)
+}
 
-	return nil
+// CompactTier2Batch runs Tier 2 compaction on the eligible issues concurrently.
+// Ineligible issues are reported as failed results.
+func (c *Compactor) CompactTier2Batch(ctx context.Context, issueIDs []string) ([]*CompactResult, error) {
+	return c.compactBatch(ctx, issueIDs, 2)
 }
 
-func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([]*CompactResult, error) {
+func (c *Compactor) compactBatch(ctx context.Context, issueIDs []string, tier int) ([]*CompactResult, error) {
 	if len(issueIDs) == 0 {
 		return nil, nil
 	}
@@ -141,7 +166,7 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 	results := make([]*CompactResult, 0, len(issueIDs))
 
 	for _, id := range issueIDs {
-		eligible, reason, err := c.store.CheckEligibility(ctx, id, 1)
+		eligible, reason, err := c.store.CheckEligibility(ctx, id, tier)
 		if err != nil {
 			results = append(results, &CompactResult{
 				IssueID: id,
@@ -152,7 +177,7 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 		if !eligible {
 			results = append(results, &CompactResult{
 				IssueID: id,
-				Err:     fmt.Errorf("not eligible for Tier 1 compaction: %s", reason),
+				Err:     fmt.Errorf("not eligible for Tier %d compaction: %s", tier, reason),
 			})
 		} else {
 			eligibleIDs = append(eligibleIDs, id)
@@ -173,10 +198,9 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 				})
 				continue
 			}
-			originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
 			results = append(results, &CompactResult{
 				IssueID:      id,
-				OriginalSize: originalSize,
+				OriginalSize: contentSize(issue),
 				Err:          nil,
 			})
 		}
@@ -194,7 +218,7 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 			for issueID := range workCh {
 				result := &CompactResult{IssueID: issueID}
 
-				if err := c.compactSingleWithResult(ctx, issueID, result); err != nil {
+				if err := c.compactSingleWithResult(ctx, issueID, tier, result); err != nil {
 					result.Err = err
 				}
 
@@ -220,7 +244,7 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 	return results, nil
 }
 
-func (c *Compactor) compactSingleWithResult(ctx context.Context, issueID string, result *CompactResult) error {
+func (c *Compactor) compactSingleWithResult(ctx context.Context, issueID string, tier int, result *CompactResult) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
@@ -230,48 +254,126 @@ func (c *Compactor) compactSingleWithResult(ctx context.Context, issueID string,
 		return fmt.Errorf("failed to get issue: %w", err)
 	}
 
-	result.OriginalSize = len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
+	result.OriginalSize = contentSize(issue)
 
-	summary, err := c.haiku.SummarizeTier1(ctx, issue)
+	summaryCtx, usage := withUsageCounter(ctx)
+	summary, err := c.summarize(summaryCtx, issue, tier)
+	result.Usage = usage.total()
 	if err != nil {
-		return fmt.Errorf("failed to summarize with Haiku: %w", err)
+		if usageErr := c.recordUsage(ctx, result.Usage, 0); usageErr != nil {
+			return usageErr
+		}
+		var invalid *ValidationError
+		if errors.As(err, &invalid) {
+			warningMsg := fmt.Sprintf("Tier %d compaction skipped: %s", tier, strings.Join(invalid.Problems, "; "))
+			if err := c.recordSkip(ctx, issueID, warningMsg); err != nil {
+				return err
+			}
+			return fmt.Errorf("%w, keeping original", err)
+		}
+		return fmt.Errorf("failed to summarize: %w", err)
 	}
 
 	result.CompactedSize = len(summary)
 
-	if result.CompactedSize >= result.OriginalSize {
-		warningMsg := fmt.Sprintf("Tier 1 compaction skipped: summary (%d bytes) not shorter than original (%d bytes)", result.CompactedSize, result.OriginalSize)
-		if err := c.store.AddComment(ctx, issueID, "compactor", warningMsg); err != nil {
-			return fmt.Errorf("failed to record warning: %w", err)
+	// Tier 2 moves the audit trail into the snapshot, leaving only the compaction events
+	var archived []*types.Event
+	if tier == 2 {
+		archived, err = c.store.GetEvents(ctx, issueID, 0)
+		if err != nil {
+			return fmt.Errorf("failed to get events: %w", err)
 		}
-		return fmt.Errorf("compaction would increase size (%d → %d bytes), keeping original", result.OriginalSize, result.CompactedSize)
-	}
-
-	updates := map[string]interface{}{
-		"description":         summary,
-		"design":              "",
-		"notes":               "",
-		"acceptance_criteria": "",
-	}
-
-	if err := c.store.UpdateIssue(ctx, issueID, updates, "compactor"); err != nil {
-		return fmt.Errorf("failed to update issue: %w", err)
 	}
-
 	commitHash := GetCurrentCommitHash()
-	if err := c.store.ApplyCompaction(ctx, issueID, 1, result.OriginalSize, result.CompactedSize, commitHash); err != nil {
-		return fmt.Errorf("failed to set compaction level: %w", err)
+	if err := c.store.CompactIssue(ctx, issue, tier, summary, archived, commitHash, "compactor"); err != nil {
+		return fmt.Errorf("failed to apply compaction: %w", err)
 	}
 
 	savingBytes := result.OriginalSize - result.CompactedSize
-	eventData := fmt.Sprintf("Tier 1 compaction: %d → %d bytes (saved %d)", result.OriginalSize, result.CompactedSize, savingBytes)
+	eventData := fmt.Sprintf("Tier %d compaction: %d → %d bytes (saved %d)", tier, result.OriginalSize, result.CompactedSize, savingBytes)
+	if len(archived) > 0 {
+		eventData += fmt.Sprintf(", archived %d events", len(archived))
+	}
 	if err := c.store.AddComment(ctx, issueID, "compactor", eventData); err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
 
-	if err := c.store.MarkIssueDirty(ctx, issueID); err != nil {
-		return fmt.Errorf("failed to mark dirty: %w", err)
+	return c.recordUsage(ctx, result.Usage, savingBytes)
+}
+
+// recordSkip comments on an issue why compaction was skipped, unless the compactor's
+// latest comment already says the same, so re-running compaction on an issue whose
+// summaries keep failing validation doesn't add a comment (and a JSONL rewrite) each time
+func (c *Compactor) recordSkip(ctx context.Context, issueID, message string) error {
+	latest, err := c.store.SearchEvents(ctx, types.EventFilter{
+		IssueID: issueID,
+		Actor:   "compactor",
+		Types:   []types.EventType{types.EventCommented},
+		Limit:   1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get compactor comments: %w", err)
+	}
+	if len(latest) == 1 && latest[0].Comment != nil && *latest[0].Comment == message {
+		return nil
+	}
+	if err := c.store.AddComment(ctx, issueID, "compactor", message); err != nil {
+		return fmt.Errorf("failed to record warning: %w", err)
 	}
+	return nil
+}
 
+// recordUsage adds the tokens spent on one issue to the model's totals in metadata.
+// savedBytes is 0 when the issue wasn't compacted.
+func (c *Compactor) recordUsage(ctx context.Context, usage Usage, savedBytes int) error {
+	if usage.Calls == 0 {
+		return nil
+	}
+	record := sqlite.CompactionUsage{
+		Calls:        usage.Calls,
+		InputTokens:  usage.InputTokens,
+		OutputTokens: usage.OutputTokens,
+		BytesSaved:   savedBytes,
+	}
+	if savedBytes > 0 {
+		record.Issues = 1
+	}
+	if err := c.store.RecordCompactionUsage(ctx, c.config.ModelName(), record); err != nil {
+		return fmt.Errorf("failed to record usage: %w", err)
+	}
 	return nil
 }
+
+// summarize produces a summary that passes ValidateSummary. Summarizers that can
+// revise get up to maxSummaryAttempts tries, each told what was wrong with the last;
+// others fail on the first invalid summary.
+func (c *Compactor) summarize(ctx context.Context, issue *types.Issue, tier int) (string, error) {
+	var summary string
+	var err error
+	if tier == 1 {
+		summary, err = c.summarizer.SummarizeTier1(ctx, issue)
+	} else {
+		summary, err = c.summarizer.SummarizeTier2(ctx, issue)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	reviser, canRevise := c.summarizer.(Reviser)
+	for attempt := 1; ; attempt++ {
+		err := ValidateSummary(issue, summary, tier)
+		var invalid *ValidationError
+		if err == nil || !canRevise || attempt == maxSummaryAttempts || !errors.As(err, &invalid) {
+			return summary, err
+		}
+		summary, err = reviser.ReviseSummary(ctx, issue, tier, summary, invalid.Problems)
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// contentSize is the size of the text fields compaction summarizes
+func contentSize(issue *types.Issue) int {
+	return len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
+}
@@ -3,6 +3,7 @@ package compact
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -427,3 +428,232 @@ func TestBatchOperations_ErrorHandling(t *testing.T) {
 		t.Errorf("expected 2 errors, got %d", errorCount)
 	}
 }
+
+// stubSummarizer returns fixed summaries without calling a model
+type stubSummarizer struct {
+	tier1, tier2 string
+}
+
+func (s *stubSummarizer) SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error) {
+	return s.tier1, nil
+}
+
+func (s *stubSummarizer) SummarizeTier2(ctx context.Context, issue *types.Issue) (string, error) {
+	return s.tier2, nil
+}
+
+func TestCompactTier2(t *testing.T) {
+	store := setupTestStorage(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	for key, value := range map[string]string{"compact_tier2_days": "0", "compact_tier2_commits": "3"} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("failed to set config: %v", err)
+		}
+	}
+
+	closedAt := time.Now().Add(-48 * time.Hour)
+	var ids []string
+	for _, id := range []string{"test-t2-1", "test-t2-2", "test-t2-3"} {
+		issue := &types.Issue{
+			ID:          id,
+			Title:       "Add JWT authentication",
+			Description: strings.Repeat("Implemented JWT authentication with refresh token rotation. ", 8),
+			Design:      "Tokens in httpOnly cookies",
+			Status:      types.StatusClosed,
+			Priority:    2,
+			IssueType:   types.TypeTask,
+			CreatedAt:   closedAt.Add(-24 * time.Hour),
+			UpdatedAt:   closedAt,
+			ClosedAt:    &closedAt,
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	c, err := New(store, "", &CompactConfig{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("failed to create compactor: %v", err)
+	}
+	c.summarizer = &stubSummarizer{
+		tier1: "**Summary:** Added JWT authentication with refresh tokens.",
+		tier2: "Added JWT auth.",
+	}
+
+	if err := c.CompactTier2(ctx, ids[0]); err == nil || !strings.Contains(err.Error(), "must be at compaction level 1") {
+		t.Fatalf("expected tier 2 to require tier 1 first, got %v", err)
+	}
+
+	// Tier 1 snapshots the original; its events plus the compaction events make the issues tier 2 candidates
+	results, err := c.CompactTier1Batch(ctx, ids)
+	if err != nil {
+		t.Fatalf("tier 1 batch failed: %v", err)
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Fatalf("tier 1 compaction of %s failed: %v", r.IssueID, r.Err)
+		}
+	}
+
+	if err := c.CompactTier2(ctx, ids[0]); err != nil {
+		t.Fatalf("CompactTier2 failed: %v", err)
+	}
+	results, err = c.CompactTier2Batch(ctx, ids)
+	if err != nil {
+		t.Fatalf("tier 2 batch failed: %v", err)
+	}
+	var failed []string
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r.IssueID)
+		}
+	}
+	if len(results) != 3 || len(failed) != 1 || failed[0] != ids[0] {
+		t.Errorf("expected only the already compacted %s to fail, got failures %v", ids[0], failed)
+	}
+
+	for _, id := range ids {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to get issue: %v", err)
+		}
+		if issue.CompactionLevel != 2 || issue.Description != "Added JWT auth." {
+			t.Errorf("%s: expected tier 2 summary, got level %d %q", id, issue.CompactionLevel, issue.Description)
+		}
+
+		snapshots, err := store.GetSnapshots(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to get snapshots: %v", err)
+		}
+		if len(snapshots) != 2 || snapshots[0].Issue.Design != "Tokens in httpOnly cookies" {
+			t.Fatalf("%s: expected tier 1 snapshot of the original, got %d snapshots", id, len(snapshots))
+		}
+		if len(snapshots[1].ArchivedEvents) < 3 || snapshots[1].Issue.Description != "**Summary:** Added JWT authentication with refresh tokens." {
+			t.Errorf("%s: unexpected tier 2 snapshot with %d archived events", id, len(snapshots[1].ArchivedEvents))
+		}
+
+		events, err := store.GetEvents(ctx, id, 0)
+		if err != nil {
+			t.Fatalf("failed to get events: %v", err)
+		}
+		for _, e := range events {
+			if e.Actor != "compactor" {
+				t.Errorf("%s: expected only tier 2 compaction events to remain, found %s by %s", id, e.EventType, e.Actor)
+			}
+		}
+	}
+}
+
+// revisingStub returns summaries in order, recording the feedback it was given
+type revisingStub struct {
+	summaries []string
+	feedback  [][]string
+}
+
+func (s *revisingStub) next() string {
+	summary := s.summaries[0]
+	if len(s.summaries) > 1 {
+		s.summaries = s.summaries[1:]
+	}
+	return summary
+}
+
+func (s *revisingStub) SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error) {
+	return s.next(), nil
+}
+
+func (s *revisingStub) SummarizeTier2(ctx context.Context, issue *types.Issue) (string, error) {
+	return s.next(), nil
+}
+
+func (s *revisingStub) ReviseSummary(ctx context.Context, issue *types.Issue, tier int, rejected string, problems []string) (string, error) {
+	s.feedback = append(s.feedback, problems)
+	return s.next(), nil
+}
+
+func TestCompactTier1_ValidationRetriesWithFeedback(t *testing.T) {
+	store := setupTestStorage(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	closedAt := time.Now().Add(-48 * time.Hour)
+	for _, id := range []string{"test-v-1", "test-v-2"} {
+		issue := &types.Issue{
+			ID:          id,
+			Title:       "Fix login redirect",
+			Description: strings.Repeat("The login page redirected to a stale URL after the session expired. ", 5),
+			Notes:       "Follow-up in test-v-9, see https://example.com/runbook",
+			Status:      types.StatusClosed,
+			Priority:    2,
+			IssueType:   types.TypeBug,
+			CreatedAt:   closedAt.Add(-time.Hour),
+			UpdatedAt:   closedAt,
+			ClosedAt:    &closedAt,
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+	}
+
+	c, err := New(store, "", &CompactConfig{Provider: ProviderOffline})
+	if err != nil {
+		t.Fatalf("failed to create compactor: %v", err)
+	}
+
+	// The first summary drops the references; the revision keeps them
+	stub := &revisingStub{summaries: []string{
+		"**Summary:** Fixed the login redirect.",
+		"**Summary:** Fixed the login redirect. Follow-up in test-v-9, runbook https://example.com/runbook",
+	}}
+	c.summarizer = stub
+	if err := c.CompactTier1(ctx, "test-v-1"); err != nil {
+		t.Fatalf("expected the revised summary to be applied, got %v", err)
+	}
+	if len(stub.feedback) != 1 || !strings.Contains(strings.Join(stub.feedback[0], "; "), "missing references: test-v-9, https://example.com/runbook") {
+		t.Errorf("expected feedback about the missing references, got %v", stub.feedback)
+	}
+	issue, _ := store.GetIssue(ctx, "test-v-1")
+	if issue.CompactionLevel != 1 || !strings.Contains(issue.Description, "test-v-9") {
+		t.Errorf("expected the revised summary, got level %d: %q", issue.CompactionLevel, issue.Description)
+	}
+
+	// A summary that never validates is skipped after maxSummaryAttempts, leaving the issue alone
+	stub = &revisingStub{summaries: []string{"Fixed it."}}
+	c.summarizer = stub
+	err = c.CompactTier1(ctx, "test-v-2")
+	if err == nil || !strings.Contains(err.Error(), "keeping original") {
+		t.Fatalf("expected the invalid summary to be rejected, got %v", err)
+	}
+	if len(stub.feedback) != maxSummaryAttempts-1 {
+		t.Errorf("expected %d revisions, got %d", maxSummaryAttempts-1, len(stub.feedback))
+	}
+	issue, _ = store.GetIssue(ctx, "test-v-2")
+	if issue.CompactionLevel != 0 || issue.Notes == "" {
+		t.Errorf("expected the issue to stay uncompacted, got level %d", issue.CompactionLevel)
+	}
+	if snapshots, _ := store.GetSnapshots(ctx, "test-v-2"); len(snapshots) != 0 {
+		t.Errorf("expected no snapshot for a skipped issue, got %d", len(snapshots))
+	}
+	events, _ := store.GetEvents(ctx, "test-v-2", 0)
+	var warned bool
+	for _, e := range events {
+		warned = warned || (e.Comment != nil && strings.HasPrefix(*e.Comment, "Tier 1 compaction skipped: missing references"))
+	}
+	if !warned {
+		t.Error("expected a comment explaining why compaction was skipped")
+	}
+
+	// Compacting again fails the same way without adding another comment
+	c.summarizer = &revisingStub{summaries: []string{"Fixed it."}}
+	if err := c.CompactTier1(ctx, "test-v-2"); err == nil {
+		t.Fatal("expected the invalid summary to be rejected again")
+	}
+	again, _ := store.GetEvents(ctx, "test-v-2", 0)
+	if len(again) != len(events) {
+		t.Errorf("expected no new events on a repeated skip, got %d after %d", len(again), len(events))
+	}
+}
@@ -0,0 +1,215 @@
+package compact
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const (
+	maxExtractParagraph = 400 // Characters kept from each extracted paragraph
+	maxExtractNotes     = 200 // Characters kept from the notes
+	maxExtractTier2     = 150 // Words kept in a tier 2 summary
+)
+
+var (
+	urlRegex           = regexp.MustCompile(`https?://[^\s)>\]"']+`)
+	markdownLabelRegex = regexp.MustCompile(`\*\*[^*]+:\*\*\s*`)
+	bulletRegex        = regexp.MustCompile(`^\s*([-*+]|\d+[.)])\s+`)
+)
+
+// ExtractiveSummarizer compacts issues without a model by keeping the parts of the
+// text that carry the most context: headings, the first and last paragraphs,
+// acceptance criteria bullets, the start of the notes, and every referenced issue ID
+// and URL. The output depends only on the input, so repeated runs are reproducible.
+type ExtractiveSummarizer struct{}
+
+// NewExtractiveSummarizer creates an offline summarizer.
+func NewExtractiveSummarizer() *ExtractiveSummarizer {
+	return &ExtractiveSummarizer{}
+}
+
+// SummarizeTier1 extracts a structured summary of the issue's text fields.
+func (e *ExtractiveSummarizer) SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	var sections []string
+	paragraphs := splitParagraphs(issue.Description)
+	if len(paragraphs) > 0 {
+		sections = append(sections, "**Summary:** "+truncateText(paragraphs[0], maxExtractParagraph))
+	}
+
+	var headings []string
+	for _, text := range []string{issue.Description, issue.Design} {
+		for _, line := range strings.Split(text, "\n") {
+			if line = strings.TrimSpace(line); strings.HasPrefix(line, "#") {
+				headings = append(headings, line)
+			}
+		}
+	}
+	if len(headings) > 0 {
+		sections = append(sections, strings.Join(headings, "\n"))
+	}
+	if len(paragraphs) > 1 {
+		sections = append(sections, truncateText(paragraphs[len(paragraphs)-1], maxExtractParagraph))
+	}
+
+	if criteria := extractBullets(issue.AcceptanceCriteria); criteria != "" {
+		sections = append(sections, "**Acceptance Criteria:**\n"+criteria)
+	}
+	if notes := strings.Join(strings.Fields(issue.Notes), " "); notes != "" {
+		sections = append(sections, "**Notes:** "+truncateText(notes, maxExtractNotes))
+	}
+
+	summary := strings.Join(sections, "\n\n")
+	return appendReferences(summary, issue, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes), nil
+}
+
+// SummarizeTier2 reduces the current description to a single plain paragraph.
+func (e *ExtractiveSummarizer) SummarizeTier2(ctx context.Context, issue *types.Issue) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(issue.Description, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "**References:**") {
+			continue
+		}
+		lines = append(lines, bulletRegex.ReplaceAllString(markdownLabelRegex.ReplaceAllString(line, ""), ""))
+	}
+
+	words := strings.Fields(strings.Join(lines, " "))
+	if len(words) > maxExtractTier2 {
+		words = append(words[:maxExtractTier2], "…")
+	}
+	return appendReferences(strings.Join(words, " "), issue, issue.Description), nil
+}
+
+// splitParagraphs returns the non-empty blank-line separated paragraphs of text,
+// each joined onto one line. Headings are left out since they are kept separately.
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+	for _, block := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		var lines []string
+		for _, line := range strings.Split(block, "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 {
+			paragraphs = append(paragraphs, strings.Join(lines, " "))
+		}
+	}
+	return paragraphs
+}
+
+// extractBullets keeps the list items of text, or its first paragraph if it has none
+func extractBullets(text string) string {
+	var bullets []string
+	for _, line := range strings.Split(text, "\n") {
+		if bulletRegex.MatchString(line) {
+			bullets = append(bullets, "- "+strings.TrimSpace(bulletRegex.ReplaceAllString(line, "")))
+		}
+	}
+	if len(bullets) > 0 {
+		return strings.Join(bullets, "\n")
+	}
+	if paragraphs := splitParagraphs(text); len(paragraphs) > 0 {
+		return truncateText(paragraphs[0], maxExtractParagraph)
+	}
+	return ""
+}
+
+// truncateText cuts text to at most limit bytes at a word boundary, adding an ellipsis
+func truncateText(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	cut := strings.LastIndex(text[:limit], " ")
+	if cut <= 0 {
+		cut = limit
+		for cut > 0 && !isRuneStart(text[cut]) {
+			cut--
+		}
+	}
+	return strings.TrimRight(text[:cut], " ,;:") + "…"
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// appendReferences adds a References line with the issue IDs and URLs found in
+// sources that summary doesn't already mention
+func appendReferences(summary string, issue *types.Issue, sources ...string) string {
+	var refs []string
+	seen := map[string]bool{}
+	for _, ref := range extractReferences(issue.ID, sources...) {
+		if !seen[ref] && !mentions(summary, ref) {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == 0 {
+		return summary
+	}
+	if summary != "" {
+		summary += "\n\n"
+	}
+	return summary + "**References:** " + strings.Join(refs, ", ")
+}
+
+// mentions reports whether text contains ref as a whole token, so that bd-1 isn't
+// taken as mentioned by bd-12
+func mentions(text, ref string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], ref)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(ref)
+		if (start == 0 || !isWordByte(text[start-1])) && (end == len(text) || !isWordByte(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b == '-' || b == '/' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+// extractReferences returns the issue IDs sharing issueID's prefix and the URLs
+// mentioned in texts, in order of first appearance
+func extractReferences(issueID string, texts ...string) []string {
+	var idRegex *regexp.Regexp
+	if i := strings.LastIndex(issueID, "-"); i > 0 {
+		idRegex = regexp.MustCompile(`\b` + regexp.QuoteMeta(issueID[:i]) + `-\d+\b`)
+	}
+
+	var refs []string
+	seen := map[string]bool{issueID: true}
+	for _, text := range texts {
+		var found [][]int
+		if idRegex != nil {
+			found = append(found, idRegex.FindAllStringIndex(text, -1)...)
+		}
+		found = append(found, urlRegex.FindAllStringIndex(text, -1)...)
+		sort.Slice(found, func(i, j int) bool { return found[i][0] < found[j][0] })
+		for _, loc := range found {
+			ref := strings.TrimRight(text[loc[0]:loc[1]], ".,;:")
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,173 @@
+package compact
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func extractiveTestIssue() *types.Issue {
+	return &types.Issue{
+		ID:    "bd-10",
+		Title: "Rework session handling",
+		Description: `Sessions expired early for users behind proxies.
+
+## Investigation
+
+The proxy rewrote the Cookie header and dropped the SameSite attribute, which
+made the browser treat the session cookie as third-party. See bd-4 for the proxy setup.
+
+We tried pinning the cookie domain but that broke the mobile app.
+
+Sessions now survive proxies and the fix is covered by integration tests.`,
+		Design: `### Cookie changes
+Set SameSite=Lax explicitly and stop relying on defaults.
+Spec: https://datatracker.ietf.org/doc/html/rfc6265.`,
+		AcceptanceCriteria: `Must hold for all clients:
+- Sessions last 24h behind the corporate proxy
+* Mobile app login unaffected
+1. No regression in bd-12 load tests`,
+		Notes: strings.Repeat("Long debugging log entry with timings and stack traces. ", 20) + "Final note mentions bd-1.",
+	}
+}
+
+func TestExtractiveSummarizer_Tier1(t *testing.T) {
+	issue := extractiveTestIssue()
+	s := NewExtractiveSummarizer()
+
+	summary, err := s.SummarizeTier1(context.Background(), issue)
+	if err != nil {
+		t.Fatalf("SummarizeTier1 failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"**Summary:** Sessions expired early for users behind proxies.",
+		"## Investigation\n### Cookie changes",
+		"Sessions now survive proxies and the fix is covered by integration tests.",
+		"**Acceptance Criteria:**\n- Sessions last 24h behind the corporate proxy\n- Mobile app login unaffected\n- No regression in bd-12 load tests",
+		"**Notes:** Long debugging log entry",
+		"**References:** bd-4, https://datatracker.ietf.org/doc/html/rfc6265, bd-1",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+	if strings.Contains(summary, "pinning the cookie domain") {
+		t.Errorf("middle paragraphs should be dropped:\n%s", summary)
+	}
+	if strings.Count(summary, "bd-12") != 1 {
+		t.Errorf("bd-12 is already kept in the criteria and shouldn't be repeated:\n%s", summary)
+	}
+	if original := contentSize(issue); len(summary) >= original {
+		t.Errorf("summary (%d bytes) should be shorter than the original (%d bytes)", len(summary), original)
+	}
+
+	again, _ := s.SummarizeTier1(context.Background(), issue)
+	if again != summary {
+		t.Error("extractive summaries should be reproducible")
+	}
+}
+
+func TestExtractiveSummarizer_Tier2(t *testing.T) {
+	s := NewExtractiveSummarizer()
+	tier1, _ := s.SummarizeTier1(context.Background(), extractiveTestIssue())
+
+	summary, err := s.SummarizeTier2(context.Background(), &types.Issue{ID: "bd-10", Description: tier1})
+	if err != nil {
+		t.Fatalf("SummarizeTier2 failed: %v", err)
+	}
+
+	paragraph, refs, _ := strings.Cut(summary, "\n\n")
+	if strings.Contains(paragraph, "\n") || strings.Contains(paragraph, "**") || strings.Contains(paragraph, "##") {
+		t.Errorf("expected a single plain paragraph, got:\n%s", paragraph)
+	}
+	if words := len(strings.Fields(paragraph)); words > maxExtractTier2+1 {
+		t.Errorf("expected at most %d words, got %d", maxExtractTier2, words)
+	}
+	if !strings.HasPrefix(paragraph, "Sessions expired early for users behind proxies.") {
+		t.Errorf("expected the summary sentence first, got %q", paragraph)
+	}
+	for _, ref := range []string{"bd-4", "bd-1", "https://datatracker.ietf.org/doc/html/rfc6265"} {
+		if !mentions(summary, ref) {
+			t.Errorf("tier 2 summary lost reference %s:\n%s", ref, refs)
+		}
+	}
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		text, ref string
+		want      bool
+	}{
+		{"see bd-1.", "bd-1", true},
+		{"see bd-12", "bd-1", false},
+		{"bd-12 and bd-1", "bd-1", true},
+		{"xbd-1", "bd-1", false},
+		{"(https://example.com/a)", "https://example.com/a", true},
+		{"https://example.com/ab", "https://example.com/a", false},
+	}
+	for _, tt := range tests {
+		if got := mentions(tt.text, tt.ref); got != tt.want {
+			t.Errorf("mentions(%q, %q) = %v, want %v", tt.text, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	if got := truncateText("short", 10); got != "short" {
+		t.Errorf("got %q", got)
+	}
+	if got := truncateText("one two three four", 12); got != "one two…" {
+		t.Errorf("got %q", got)
+	}
+	if got := truncateText("日本語日本語", 7); got != "日本…" {
+		t.Errorf("expected cut at a rune boundary, got %q", got)
+	}
+}
+
+func TestCompactTier1_Offline(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	store := setupTestStorage(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	closedAt := time.Now().Add(-48 * time.Hour)
+	issue := extractiveTestIssue()
+	issue.Status = types.StatusClosed
+	issue.Priority = 2
+	issue.IssueType = types.TypeBug
+	issue.CreatedAt = closedAt.Add(-time.Hour)
+	issue.UpdatedAt = closedAt
+	issue.ClosedAt = &closedAt
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	c, err := New(store, "", &CompactConfig{Provider: ProviderOffline})
+	if err != nil {
+		t.Fatalf("offline compactor should not need an API key: %v", err)
+	}
+	if err := c.CompactTier1(ctx, issue.ID); err != nil {
+		t.Fatalf("CompactTier1 failed: %v", err)
+	}
+
+	after, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	want, _ := NewExtractiveSummarizer().SummarizeTier1(ctx, issue)
+	if after.CompactionLevel != 1 || after.Description != want || after.Notes != "" {
+		t.Errorf("unexpected issue after offline compaction: level %d\n%s", after.CompactionLevel, after.Description)
+	}
+
+	snapshots, err := store.GetSnapshots(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("failed to get snapshots: %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Issue.Notes != issue.Notes || snapshots[0].Issue.Design != issue.Design {
+		t.Errorf("expected a snapshot of the original content, got %d snapshots", len(snapshots))
+	}
+}
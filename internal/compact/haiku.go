@@ -1,4 +1,5 @@
-// Package compact provides AI-powered issue compaction using Claude Haiku.
+// Package compact provides AI-powered issue compaction using Claude Haiku
+// or any OpenAI-compatible model server.
 package compact
 
 import (
@@ -8,7 +9,6 @@ import (
 	"math"
 	"net"
 	"os"
-	"text/template"
 	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
@@ -24,10 +24,9 @@ const (
 
 // HaikuClient wraps the Anthropic API for issue summarization.
 type HaikuClient struct {
+	promptTemplates
 	client         anthropic.Client
 	model          anthropic.Model
-	tier1Template  *template.Template
-	tier2Template  *template.Template
 	maxRetries     int
 	initialBackoff time.Duration
 }
@@ -44,23 +43,17 @@ func NewHaikuClient(apiKey string) (*HaikuClient, error) {
 
 	client := anthropic.NewClient(option.WithAPIKey(apiKey))
 
-	tier1Tmpl, err := template.New("tier1").Parse(tier1PromptTemplate)
+	templates, err := newPromptTemplates()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse tier1 template: %w", err)
-	}
-
-	tier2Tmpl, err := template.New("tier2").Parse(tier2PromptTemplate)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse tier2 template: %w", err)
+		return nil, err
 	}
 
 	return &HaikuClient{
-		client:         client,
-		model:          defaultModel,
-		tier1Template:  tier1Tmpl,
-		tier2Template:  tier2Tmpl,
-		maxRetries:     maxRetries,
-		initialBackoff: initialBackoff,
+		promptTemplates: templates,
+		client:          client,
+		model:           defaultModel,
+		maxRetries:      maxRetries,
+		initialBackoff:  initialBackoff,
 	}, nil
 }
 
@@ -84,37 +77,57 @@ func (h *HaikuClient) SummarizeTier2(ctx context.Context, issue *types.Issue) (s
 	return h.callWithRetry(ctx, prompt)
 }
 
+// ReviseSummary asks for a new summary, explaining why the rejected one failed validation.
+func (h *HaikuClient) ReviseSummary(ctx context.Context, issue *types.Issue, tier int, rejected string, problems []string) (string, error) {
+	prompt, err := h.renderRevisionPrompt(issue, tier, rejected, problems)
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+	return h.callWithRetry(ctx, prompt)
+}
+
 func (h *HaikuClient) callWithRetry(ctx context.Context, prompt string) (string, error) {
-	var lastErr error
 	params := anthropic.MessageNewParams{
 		Model:     h.model,
-		MaxTokens: 1024,
+		MaxTokens: maxOutputTokens,
 		Messages: []anthropic.MessageParam{
 			anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)),
 		},
 	}
 
-	for attempt := 0; attempt <= h.maxRetries; attempt++ {
+	return withRetry(ctx, h.maxRetries, h.initialBackoff, func() (string, error) {
+		message, err := h.client.Messages.New(ctx, params)
+		if err != nil {
+			return "", err
+		}
+		reportUsage(ctx, int(message.Usage.InputTokens), int(message.Usage.OutputTokens))
+		if len(message.Content) > 0 {
+			content := message.Content[0]
+			if content.Type == "text" {
+				return content.Text, nil
+			}
+			return "", fmt.Errorf("unexpected response format: not a text block (type=%s)", content.Type)
+		}
+		return "", fmt.Errorf("unexpected response format: no content blocks")
+	})
+}
+
+// withRetry runs call, retrying rate limits, server errors and timeouts with exponential backoff.
+func withRetry(ctx context.Context, retries int, backoff time.Duration, call func() (string, error)) (string, error) {
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
 		if attempt > 0 {
-			backoff := h.initialBackoff * time.Duration(math.Pow(2, float64(attempt-1)))
+			delay := backoff * time.Duration(math.Pow(2, float64(attempt-1)))
 			select {
-			case <-time.After(backoff):
+			case <-time.After(delay):
 			case <-ctx.Done():
 				return "", ctx.Err()
 			}
 		}
 
-		message, err := h.client.Messages.New(ctx, params)
-
+		text, err := call()
 		if err == nil {
-			if len(message.Content) > 0 {
-				content := message.Content[0]
-				if content.Type == "text" {
-					return content.Text, nil
-				}
-				return "", fmt.Errorf("unexpected response format: not a text block (type=%s)", content.Type)
-			}
-			return "", fmt.Errorf("unexpected response format: no content blocks")
+			return text, nil
 		}
 
 		lastErr = err
@@ -128,7 +141,7 @@ func (h *HaikuClient) callWithRetry(ctx context.Context, prompt string) (string,
 		}
 	}
 
-	return "", fmt.Errorf("failed after %d retries: %w", h.maxRetries+1, lastErr)
+	return "", fmt.Errorf("failed after %d retries: %w", retries+1, lastErr)
 }
 
 func isRetryable(err error) bool {
@@ -154,103 +167,10 @@ func isRetryable(err error) bool {
 		return false
 	}
 
-	return false
-}
-
-type tier1Data struct {
-	Title              string
-	Description        string
-	Design             string
-	AcceptanceCriteria string
-	Notes              string
-}
-
-func (h *HaikuClient) renderTier1Prompt(issue *types.Issue) (string, error) {
-	var buf []byte
-	w := &bytesWriter{buf: buf}
-
-	data := tier1Data{
-		Title:              issue.Title,
-		Description:        issue.Description,
-		Design:             issue.Design,
-		AcceptanceCriteria: issue.AcceptanceCriteria,
-		Notes:              issue.Notes,
-	}
-
-	if err := h.tier1Template.Execute(w, data); err != nil {
-		return "", err
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == 429 || statusErr.StatusCode >= 500
 	}
-	return string(w.buf), nil
-}
-
-type tier2Data struct {
-	Title              string
-	CurrentDescription string
-}
-
-func (h *HaikuClient) renderTier2Prompt(issue *types.Issue) (string, error) {
-	var buf []byte
-	w := &bytesWriter{buf: buf}
-
-	data := tier2Data{
-		Title:              issue.Title,
-		CurrentDescription: issue.Description,
-	}
-
-	if err := h.tier2Template.Execute(w, data); err != nil {
-		return "", err
-	}
-	return string(w.buf), nil
-}
-
-type bytesWriter struct {
-	buf []byte
-}
 
-func (w *bytesWriter) Write(p []byte) (n int, err error) {
-	w.buf = append(w.buf, p...)
-	return len(p), nil
+	return false
 }
-
-const tier1PromptTemplate = `You are summarizing a closed software issue for long-term storage. Your goal is to COMPRESS the content - the output MUST be significantly shorter than the input while preserving key technical decisions and outcomes.
-
-**Title:** {{.Title}}
-
-**Description:**
-{{.Description}}
-
-{{if .Design}}**Design:**
-{{.Design}}
-{{end}}
-
-{{if .AcceptanceCriteria}}**Acceptance Criteria:**
-{{.AcceptanceCriteria}}
-{{end}}
-
-{{if .Notes}}**Notes:**
-{{.Notes}}
-{{end}}
-
-IMPORTANT: Your summary must be shorter than the original. Be concise and eliminate redundancy.
-
-Provide a summary in this exact format:
-
-**Summary:** [2-3 concise sentences covering what was done and why]
-
-**Key Decisions:** [Brief bullet points of only the most important technical choices]
-
-**Resolution:** [One sentence on final outcome and lasting impact]`
-
-const tier2PromptTemplate = `You are performing ultra-compression on a closed software issue. The issue has already been summarized once. Your task is to create a single concise paragraph (≤150 words) that captures the essence.
-
-**Title:** {{.Title}}
-
-**Current Summary:**
-{{.CurrentDescription}}
-
-Provide a single paragraph that covers:
-- What was built/fixed
-- Why it mattered
-- Any lasting impact or decisions
-
-Keep it under 150 words while retaining the most important context.`
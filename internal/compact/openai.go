@@ -0,0 +1,174 @@
+package compact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultOpenAIBaseURL is used when no base URL is configured for the openai provider
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIClient summarizes issues with any server implementing the OpenAI chat
+// completions API, such as OpenAI itself or a local llama.cpp, Ollama or vLLM server.
+type OpenAIClient struct {
+	promptTemplates
+	httpClient     *http.Client
+	baseURL        string
+	apiKey         string
+	model          string
+	maxRetries     int
+	initialBackoff time.Duration
+}
+
+// NewOpenAIClient creates a client for the chat completions endpoint under baseURL
+// (DefaultOpenAIBaseURL if empty). Env var OPENAI_API_KEY takes precedence over explicit
+// apiKey; the key is optional since local servers usually don't need one.
+func NewOpenAIClient(baseURL, apiKey, model string) (*OpenAIClient, error) {
+	if model == "" {
+		return nil, fmt.Errorf("model required: set compact_model for the %s provider", ProviderOpenAI)
+	}
+	if envKey := os.Getenv("OPENAI_API_KEY"); envKey != "" {
+		apiKey = envKey
+	}
+	if baseURL == "" {
+		baseURL = DefaultOpenAIBaseURL
+	}
+
+	templates, err := newPromptTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAIClient{
+		promptTemplates: templates,
+		httpClient:      &http.Client{Timeout: 5 * time.Minute},
+		baseURL:         strings.TrimRight(baseURL, "/"),
+		apiKey:          apiKey,
+		model:           model,
+		maxRetries:      maxRetries,
+		initialBackoff:  initialBackoff,
+	}, nil
+}
+
+// SummarizeTier1 creates a structured summary of an issue (Summary, Key Decisions, Resolution).
+func (o *OpenAIClient) SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error) {
+	prompt, err := o.renderTier1Prompt(issue)
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+
+	return withRetry(ctx, o.maxRetries, o.initialBackoff, func() (string, error) {
+		return o.complete(ctx, prompt)
+	})
+}
+
+// SummarizeTier2 creates an ultra-compressed single-paragraph summary (≤150 words).
+func (o *OpenAIClient) SummarizeTier2(ctx context.Context, issue *types.Issue) (string, error) {
+	prompt, err := o.renderTier2Prompt(issue)
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+
+	return withRetry(ctx, o.maxRetries, o.initialBackoff, func() (string, error) {
+		return o.complete(ctx, prompt)
+	})
+}
+
+// ReviseSummary asks for a new summary, explaining why the rejected one failed validation.
+func (o *OpenAIClient) ReviseSummary(ctx context.Context, issue *types.Issue, tier int, rejected string, problems []string) (string, error) {
+	prompt, err := o.renderRevisionPrompt(issue, tier, rejected, problems)
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+
+	return withRetry(ctx, o.maxRetries, o.initialBackoff, func() (string, error) {
+		return o.complete(ctx, prompt)
+	})
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model     string        `json:"model"`
+	Messages  []chatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"` // Not sent by every server
+}
+
+// httpStatusError is a non-2xx response from an OpenAI-compatible server
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("server returned %d: %s", e.StatusCode, e.Body)
+}
+
+// complete sends a single chat completion request and returns the reply text
+func (o *OpenAIClient) complete(ctx context.Context, prompt string) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model:     o.model,
+		Messages:  []chatMessage{{Role: "user", Content: prompt}},
+		MaxTokens: maxOutputTokens,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", &httpStatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return "", fmt.Errorf("unexpected response format: %w", err)
+	}
+	if parsed.Usage != nil {
+		reportUsage(ctx, parsed.Usage.PromptTokens, parsed.Usage.CompletionTokens)
+	}
+	if len(parsed.Choices) == 0 || parsed.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("unexpected response format: no message content")
+	}
+	return strings.TrimSpace(parsed.Choices[0].Message.Content), nil
+}
@@ -0,0 +1,193 @@
+package compact
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// newChatServer starts a stand-in OpenAI-compatible server that answers every
+// chat completion with reply, after failing the first failures requests with 503.
+func newChatServer(t *testing.T, reply string, failures int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if r.URL.Path != "/v1/chat/completions" || r.Method != http.MethodPost {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Model != "llama3" || len(req.Messages) != 1 || !strings.Contains(req.Messages[0].Content, "Fix authentication bug") {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		if n <= failures {
+			http.Error(w, "loading model", http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": reply}},
+			},
+			"usage": map[string]int{"prompt_tokens": 120, "completion_tokens": 30},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestOpenAIClient_Summarize(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	server, calls := newChatServer(t, "  **Summary:** Added OAuth error handling.\n", 2)
+
+	client, err := NewOpenAIClient(server.URL+"/v1/", "", "llama3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.initialBackoff = time.Millisecond
+
+	issue := &types.Issue{ID: "bd-1", Title: "Fix authentication bug", Description: "Users can't log in"}
+	summary, err := client.SummarizeTier1(context.Background(), issue)
+	if err != nil {
+		t.Fatalf("SummarizeTier1 failed: %v", err)
+	}
+	if summary != "**Summary:** Added OAuth error handling." {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 2 retries after 503, got %d calls", calls.Load())
+	}
+
+	if _, err := client.SummarizeTier2(context.Background(), issue); err != nil {
+		t.Errorf("SummarizeTier2 failed: %v", err)
+	}
+}
+
+func TestOpenAIClient_NonRetryableError(t *testing.T) {
+	server, calls := newChatServer(t, "unused", 0)
+
+	client, err := NewOpenAIClient(server.URL+"/v1", "", "other-model")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.initialBackoff = time.Millisecond
+
+	_, err = client.SummarizeTier1(context.Background(), &types.Issue{Title: "Fix authentication bug"})
+	if err == nil || !strings.Contains(err.Error(), "non-retryable error: server returned 400") {
+		t.Errorf("expected non-retryable 400 error, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected a single call, got %d", calls.Load())
+	}
+}
+
+func TestOpenAIClient_SendsAPIKey(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-env")
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewOpenAIClient(server.URL, "sk-explicit", "gpt-4o-mini")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.SummarizeTier2(context.Background(), &types.Issue{Title: "x"}); err != nil {
+		t.Fatalf("SummarizeTier2 failed: %v", err)
+	}
+	if auth != "Bearer sk-env" {
+		t.Errorf("expected env key to take precedence, got %q", auth)
+	}
+}
+
+func TestNewSummarizer(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+
+	s, err := NewSummarizer(&CompactConfig{Model: "claude-custom"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if haiku, ok := s.(*HaikuClient); !ok || haiku.model != "claude-custom" {
+		t.Errorf("expected Haiku client with model override, got %#v", s)
+	}
+
+	s, err = NewSummarizer(&CompactConfig{Provider: ProviderOpenAI, Model: "llama3", BaseURL: "http://localhost:8080/v1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client, ok := s.(*OpenAIClient); !ok || client.baseURL != "http://localhost:8080/v1" {
+		t.Errorf("expected OpenAI client, got %#v", s)
+	}
+
+	if _, err := NewSummarizer(&CompactConfig{Provider: ProviderOpenAI}); err == nil || !strings.Contains(err.Error(), "model required") {
+		t.Errorf("expected model required error, got %v", err)
+	}
+	if _, err := NewSummarizer(&CompactConfig{Provider: "bard"}); err == nil || !strings.Contains(err.Error(), "unknown compaction provider") {
+		t.Errorf("expected unknown provider error, got %v", err)
+	}
+}
+
+func TestCompactTier1_OpenAIProvider(t *testing.T) {
+	store := setupTestStorage(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	closedAt := time.Now().Add(-48 * time.Hour)
+	issue := &types.Issue{
+		ID:          "test-local",
+		Title:       "Fix authentication bug",
+		Description: strings.Repeat("Implemented JWT authentication with refresh tokens and rate limiting. ", 10),
+		Design:      "JWT in httpOnly cookies, bcrypt cost 12",
+		Status:      types.StatusClosed,
+		Priority:    2,
+		IssueType:   types.TypeTask,
+		CreatedAt:   closedAt.Add(-24 * time.Hour),
+		UpdatedAt:   closedAt,
+		ClosedAt:    &closedAt,
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	summary := "**Summary:** Built JWT authentication with refresh tokens and rate limiting."
+	server, _ := newChatServer(t, summary, 0)
+	c, err := New(store, "", &CompactConfig{Provider: ProviderOpenAI, Model: "llama3", BaseURL: server.URL + "/v1"})
+	if err != nil {
+		t.Fatalf("failed to create compactor: %v", err)
+	}
+
+	if err := c.CompactTier1(ctx, issue.ID); err != nil {
+		t.Fatalf("failed to compact: %v", err)
+	}
+	after, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if after.Description != summary || after.Design != "" || after.CompactionLevel != 1 {
+		t.Errorf("unexpected issue after compaction: level=%d description=%q", after.CompactionLevel, after.Description)
+	}
+
+	usage, err := store.GetCompactionUsage(ctx)
+	if err != nil {
+		t.Fatalf("failed to get usage: %v", err)
+	}
+	u := usage["llama3"]
+	saved := len(issue.Description) + len(issue.Design) - len(summary)
+	if u == nil || u.Calls != 1 || u.InputTokens != 120 || u.OutputTokens != 30 || u.Issues != 1 || u.BytesSaved != saved {
+		t.Errorf("expected the server's token counts recorded for llama3, got %+v", u)
+	}
+}
@@ -0,0 +1,265 @@
+package compact
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// PromptFileExt is the extension of prompt template files in a workspace's prompt directory
+const PromptFileExt = ".tmpl"
+
+// promptFileRegex matches prompt override file names: tier1.tmpl, tier2.bug.tmpl, ...
+var promptFileRegex = regexp.MustCompile(`^tier([12])(?:\.([a-z]+))?` + regexp.QuoteMeta(PromptFileExt) + `$`)
+
+// promptKey identifies a prompt template by tier and issue type ("" for any type)
+type promptKey struct {
+	tier      int
+	issueType types.IssueType
+}
+
+// promptTemplates renders the tier 1 and tier 2 prompts shared by all summarizers.
+// Templates loaded from a workspace replace the built-in ones, either for every
+// issue or, for tier<N>.<type>.tmpl files, just for issues of that type.
+type promptTemplates struct {
+	templates map[promptKey]*template.Template
+	sources   map[promptKey]string // File each override was loaded from
+}
+
+func newPromptTemplates() (promptTemplates, error) {
+	tier1Tmpl, err := template.New("tier1").Parse(tier1PromptTemplate)
+	if err != nil {
+		return promptTemplates{}, fmt.Errorf("failed to parse tier1 template: %w", err)
+	}
+
+	tier2Tmpl, err := template.New("tier2").Parse(tier2PromptTemplate)
+	if err != nil {
+		return promptTemplates{}, fmt.Errorf("failed to parse tier2 template: %w", err)
+	}
+
+	return promptTemplates{
+		templates: map[promptKey]*template.Template{{tier: 1}: tier1Tmpl, {tier: 2}: tier2Tmpl},
+		sources:   map[promptKey]string{},
+	}, nil
+}
+
+// loadPromptTemplates returns the built-in templates overridden by the tier<N>.tmpl
+// and tier<N>.<type>.tmpl files in dir. A missing or empty dir means no overrides.
+// Every override is checked by rendering a sample issue, so mistakes surface when
+// compaction starts rather than halfway through a batch.
+func loadPromptTemplates(dir string) (promptTemplates, error) {
+	prompts, err := newPromptTemplates()
+	if err != nil || dir == "" {
+		return prompts, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return prompts, nil
+		}
+		return promptTemplates{}, fmt.Errorf("failed to read prompt directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, PromptFileExt) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		match := promptFileRegex.FindStringSubmatch(name)
+		if match == nil {
+			return promptTemplates{}, fmt.Errorf("unrecognized prompt file %s: expected tier1%s, tier2%s or tier<N>.<type>%s",
+				path, PromptFileExt, PromptFileExt, PromptFileExt)
+		}
+		key := promptKey{tier: int(match[1][0] - '0'), issueType: types.IssueType(match[2])}
+		if key.issueType != "" && !key.issueType.IsValid() {
+			return promptTemplates{}, fmt.Errorf("prompt file %s: unknown issue type %q", path, key.issueType)
+		}
+
+		// #nosec G304 - path is confined to the prompt directory
+		text, err := os.ReadFile(path)
+		if err != nil {
+			return promptTemplates{}, fmt.Errorf("failed to read prompt file: %w", err)
+		}
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(text))
+		if err != nil {
+			return promptTemplates{}, fmt.Errorf("invalid prompt file %s: %w", path, err)
+		}
+		if err := checkPromptTemplate(tmpl, key.tier); err != nil {
+			return promptTemplates{}, fmt.Errorf("invalid prompt file %s: %w", path, err)
+		}
+		prompts.templates[key] = tmpl
+		prompts.sources[key] = path
+	}
+	return prompts, nil
+}
+
+// checkPromptTemplate renders tmpl for a sample issue, making sure it only uses
+// fields that exist and that the text to summarize reaches the prompt
+func checkPromptTemplate(tmpl *template.Template, tier int) error {
+	const sample = "sample text to summarize"
+	var data interface{}
+	field := ".Description"
+	if tier == 1 {
+		data = tier1Data{ID: "bd-1", Type: "bug", Title: "Sample", Description: sample, Design: "design", AcceptanceCriteria: "criteria", Notes: "notes"}
+	} else {
+		data = tier2Data{ID: "bd-1", Type: "bug", Title: "Sample", CurrentDescription: sample}
+		field = ".CurrentDescription"
+	}
+
+	w := &bytesWriter{}
+	if err := tmpl.Execute(w, data); err != nil {
+		return err
+	}
+	if !strings.Contains(string(w.buf), sample) {
+		return fmt.Errorf("template must include {{%s}}", field)
+	}
+	return nil
+}
+
+// lookup returns the template for issues of issueType at tier, and where it came from
+func (p promptTemplates) lookup(tier int, issueType types.IssueType) (*template.Template, string) {
+	for _, key := range []promptKey{{tier, issueType}, {tier: tier}} {
+		if tmpl, ok := p.templates[key]; ok {
+			if source, ok := p.sources[key]; ok {
+				return tmpl, source
+			}
+			return tmpl, "built-in"
+		}
+	}
+	return nil, ""
+}
+
+func (p promptTemplates) render(tier int, issue *types.Issue, data interface{}) (string, error) {
+	tmpl, _ := p.lookup(tier, issue.IssueType)
+	if tmpl == nil {
+		return "", fmt.Errorf("no tier %d prompt template", tier)
+	}
+
+	w := &bytesWriter{}
+	if err := tmpl.Execute(w, data); err != nil {
+		return "", err
+	}
+	return string(w.buf), nil
+}
+
+type tier1Data struct {
+	ID                 string
+	Type               string
+	Title              string
+	Description        string
+	Design             string
+	AcceptanceCriteria string
+	Notes              string
+}
+
+func (p promptTemplates) renderTier1Prompt(issue *types.Issue) (string, error) {
+	return p.render(1, issue, tier1Data{
+		ID:                 issue.ID,
+		Type:               string(issue.IssueType),
+		Title:              issue.Title,
+		Description:        issue.Description,
+		Design:             issue.Design,
+		AcceptanceCriteria: issue.AcceptanceCriteria,
+		Notes:              issue.Notes,
+	})
+}
+
+type tier2Data struct {
+	ID                 string
+	Type               string
+	Title              string
+	CurrentDescription string
+}
+
+func (p promptTemplates) renderTier2Prompt(issue *types.Issue) (string, error) {
+	return p.render(2, issue, tier2Data{
+		ID:                 issue.ID,
+		Type:               string(issue.IssueType),
+		Title:              issue.Title,
+		CurrentDescription: issue.Description,
+	})
+}
+
+// renderRevisionPrompt asks for a new summary after rejected failed validation,
+// repeating the original tier prompt followed by the problems to fix.
+func (p promptTemplates) renderRevisionPrompt(issue *types.Issue, tier int, rejected string, problems []string) (string, error) {
+	var prompt string
+	var err error
+	if tier == 1 {
+		prompt, err = p.renderTier1Prompt(issue)
+	} else {
+		prompt, err = p.renderTier2Prompt(issue)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(prompt)
+	b.WriteString("\n\nA previous summary was rejected:\n\n")
+	b.WriteString(rejected)
+	b.WriteString("\n\nFix these problems and reply with only the corrected summary:\n")
+	for _, problem := range problems {
+		b.WriteString("- " + problem + "\n")
+	}
+	return b.String(), nil
+}
+
+type bytesWriter struct {
+	buf []byte
+}
+
+func (w *bytesWriter) Write(p []byte) (n int, err error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+const tier1PromptTemplate = `You are summarizing a closed software issue for long-term storage. Your goal is to COMPRESS the content - the output MUST be significantly shorter than the input while preserving key technical decisions and outcomes.
+
+**Title:** {{.Title}}
+
+**Description:**
+{{.Description}}
+
+{{if .Design}}**Design:**
+{{.Design}}
+{{end}}
+
+{{if .AcceptanceCriteria}}**Acceptance Criteria:**
+{{.AcceptanceCriteria}}
+{{end}}
+
+{{if .Notes}}**Notes:**
+{{.Notes}}
+{{end}}
+
+IMPORTANT: Your summary must be shorter than the original. Be concise and eliminate redundancy.
+
+Provide a summary in this exact format:
+
+**Summary:** [2-3 concise sentences covering what was done and why]
+
+**Key Decisions:** [Brief bullet points of only the most important technical choices]
+
+**Resolution:** [One sentence on final outcome and lasting impact]`
+
+const tier2PromptTemplate = `You are performing ultra-compression on a closed software issue. The issue has already been summarized once. Your task is to create a single concise paragraph (≤150 words) that captures the essence.
+
+**Title:** {{.Title}}
+
+**Current Summary:**
+{{.CurrentDescription}}
+
+Provide a single paragraph that covers:
+- What was built/fixed
+- Why it mattered
+- Any lasting impact or decisions
+
+Keep it under 150 words while retaining the most important context.`
@@ -0,0 +1,103 @@
+package compact
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func writePromptFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, text := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoadPromptTemplates_Overrides(t *testing.T) {
+	dir := writePromptFiles(t, map[string]string{
+		"tier1.tmpl":     "Summarize {{.ID}}: {{.Description}}",
+		"tier1.bug.tmpl": "Keep the reproduction steps of {{.Title}}.\n{{.Description}}\n{{.Notes}}",
+		"README.md":      "Not a template, ignored",
+	})
+	prompts, err := loadPromptTemplates(dir)
+	if err != nil {
+		t.Fatalf("loadPromptTemplates failed: %v", err)
+	}
+
+	bug := &types.Issue{ID: "bd-3", Title: "Crash on save", Description: "Steps: open, save", Notes: "Only on Windows", IssueType: types.TypeBug}
+	prompt, err := prompts.renderTier1Prompt(bug)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if prompt != "Keep the reproduction steps of Crash on save.\nSteps: open, save\nOnly on Windows" {
+		t.Errorf("expected the bug variant, got %q", prompt)
+	}
+	if _, source := prompts.lookup(1, types.TypeBug); source != filepath.Join(dir, "tier1.bug.tmpl") {
+		t.Errorf("unexpected source %q", source)
+	}
+
+	task := &types.Issue{ID: "bd-4", Description: "Refactor", IssueType: types.TypeTask}
+	if prompt, _ := prompts.renderTier1Prompt(task); prompt != "Summarize bd-4: Refactor" {
+		t.Errorf("expected the workspace default for other types, got %q", prompt)
+	}
+
+	// Tier 2 has no override, so the built-in prompt is used
+	prompt, _ = prompts.renderTier2Prompt(task)
+	if !strings.Contains(prompt, "ultra-compression") {
+		t.Errorf("expected the built-in tier 2 prompt, got %q", prompt)
+	}
+	if _, source := prompts.lookup(2, types.TypeTask); source != "built-in" {
+		t.Errorf("unexpected source %q", source)
+	}
+
+	if prompts, err := loadPromptTemplates(filepath.Join(dir, "missing")); err != nil || len(prompts.sources) != 0 {
+		t.Errorf("a missing directory should mean no overrides, got %v", err)
+	}
+}
+
+func TestLoadPromptTemplates_Invalid(t *testing.T) {
+	tests := []struct {
+		name, file, text, want string
+	}{
+		{"parse error", "tier1.tmpl", "{{.Description", "unclosed action"},
+		{"unknown field", "tier1.tmpl", "{{.Descripton}}", "can't evaluate field Descripton"},
+		{"tier 1 field in tier 2", "tier2.tmpl", "{{.Description}}", "can't evaluate field Description"},
+		{"no content", "tier2.tmpl", "Summarize {{.Title}}", "must include {{.CurrentDescription}}"},
+		{"unknown type", "tier1.bgu.tmpl", "{{.Description}}", `unknown issue type "bgu"`},
+		{"bad name", "tier3.tmpl", "{{.Description}}", "unrecognized prompt file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writePromptFiles(t, map[string]string{tt.file: tt.text})
+			_, err := loadPromptTemplates(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), tt.file) {
+				t.Errorf("expected an error about %s mentioning %q, got %v", tt.file, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCompactor_RenderPrompt(t *testing.T) {
+	dir := writePromptFiles(t, map[string]string{"tier2.epic.tmpl": "One line for epic {{.ID}}: {{.CurrentDescription}}"})
+	c, err := New(nil, "", &CompactConfig{DryRun: true, PromptDir: dir})
+	if err != nil {
+		t.Fatalf("failed to create compactor: %v", err)
+	}
+
+	epic := &types.Issue{ID: "bd-9", Description: "**Summary:** Shipped v2", IssueType: types.TypeEpic}
+	prompt, source, err := c.RenderPrompt(epic, 2)
+	if err != nil || prompt != "One line for epic bd-9: **Summary:** Shipped v2" || source != filepath.Join(dir, "tier2.epic.tmpl") {
+		t.Errorf("unexpected preview %q from %q (%v)", prompt, source, err)
+	}
+
+	if _, err := New(nil, "", &CompactConfig{DryRun: true, PromptDir: writePromptFiles(t, map[string]string{"tier1.tmpl": "{{.Nope}}"})}); err == nil {
+		t.Error("expected an invalid template to fail when the compactor is created")
+	}
+}
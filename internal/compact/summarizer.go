@@ -0,0 +1,75 @@
+package compact
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Summarizer produces compacted descriptions of closed issues.
+type Summarizer interface {
+	// SummarizeTier1 creates a structured summary (Summary, Key Decisions, Resolution).
+	SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error)
+	// SummarizeTier2 creates an ultra-compressed single-paragraph summary.
+	SummarizeTier2(ctx context.Context, issue *types.Issue) (string, error)
+}
+
+// Reviser is implemented by summarizers that can rewrite a summary that failed
+// ValidateSummary, given the problems found.
+type Reviser interface {
+	ReviseSummary(ctx context.Context, issue *types.Issue, tier int, rejected string, problems []string) (string, error)
+}
+
+// Summarization providers selectable via the compact_provider config
+const (
+	ProviderAnthropic = "anthropic"
+	ProviderOpenAI    = "openai"
+	ProviderOffline   = "offline" // Extractive, no model or network needed
+)
+
+var (
+	_ Summarizer = (*HaikuClient)(nil)
+	_ Summarizer = (*OpenAIClient)(nil)
+	_ Summarizer = (*ExtractiveSummarizer)(nil)
+	_ Reviser    = (*HaikuClient)(nil)
+	_ Reviser    = (*OpenAIClient)(nil)
+)
+
+// NewSummarizer creates the summarizer for config.Provider (Anthropic if empty),
+// using config.Model when set instead of the provider default and the prompt
+// templates in config.PromptDir when present.
+func NewSummarizer(config *CompactConfig) (Summarizer, error) {
+	prompts, err := loadPromptTemplates(config.PromptDir)
+	if err != nil {
+		return nil, err
+	}
+	return newSummarizer(config, prompts)
+}
+
+func newSummarizer(config *CompactConfig, prompts promptTemplates) (Summarizer, error) {
+	switch config.Provider {
+	case "", ProviderAnthropic:
+		client, err := NewHaikuClient(config.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		if config.Model != "" {
+			client.model = anthropic.Model(config.Model)
+		}
+		client.promptTemplates = prompts
+		return client, nil
+	case ProviderOpenAI:
+		client, err := NewOpenAIClient(config.BaseURL, config.APIKey, config.Model)
+		if err != nil {
+			return nil, err
+		}
+		client.promptTemplates = prompts
+		return client, nil
+	case ProviderOffline:
+		return NewExtractiveSummarizer(), nil
+	default:
+		return nil, fmt.Errorf("unknown compaction provider %q (want %s, %s or %s)", config.Provider, ProviderAnthropic, ProviderOpenAI, ProviderOffline)
+	}
+}
@@ -0,0 +1,115 @@
+package compact
+
+import (
+	"context"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const (
+	maxOutputTokens      = 1024 // Reply limit sent with every summarizer request
+	bytesPerToken        = 4    // Rough size of a token in English text and code
+	tier2EstimatedTokens = 200  // About 150 words, the Tier 2 prompt's limit
+)
+
+// Usage counts the summarizer calls and tokens spent compacting one or more issues
+type Usage struct {
+	Calls        int `json:"calls"`
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Add adds other's calls and tokens to u
+func (u *Usage) Add(other Usage) {
+	u.Calls += other.Calls
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+}
+
+// ModelPrice is the price of a model in dollars per million tokens
+type ModelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// modelPrices lists published list prices for the default and common models.
+// Local models and anything else unlisted have no known price.
+var modelPrices = map[string]ModelPrice{
+	defaultModel:                {Input: 0.80, Output: 4.00},
+	"claude-3-5-haiku-latest":   {Input: 0.80, Output: 4.00},
+	"claude-haiku-4-5":          {Input: 1.00, Output: 5.00},
+	"claude-haiku-4-5-20251001": {Input: 1.00, Output: 5.00},
+	"claude-sonnet-4-5":         {Input: 3.00, Output: 15.00},
+	"gpt-4o-mini":               {Input: 0.15, Output: 0.60},
+	"gpt-4o":                    {Input: 2.50, Output: 10.00},
+	"gpt-4.1-mini":              {Input: 0.40, Output: 1.60},
+	"gpt-4.1-nano":              {Input: 0.10, Output: 0.40},
+}
+
+// Cost returns the dollar cost of usage on model, and false if the model's price is unknown
+func Cost(model string, usage Usage) (float64, bool) {
+	price, ok := modelPrices[model]
+	if !ok {
+		return 0, false
+	}
+	return (float64(usage.InputTokens)*price.Input + float64(usage.OutputTokens)*price.Output) / 1e6, true
+}
+
+// EstimateTokens approximates the number of tokens in text from its size
+func EstimateTokens(text string) int {
+	return (len(text) + bytesPerToken - 1) / bytesPerToken
+}
+
+// usageKey is the context key of the *usageCounter summarizers report to
+type usageKey struct{}
+
+type usageCounter struct {
+	mu    sync.Mutex
+	usage Usage
+}
+
+// withUsageCounter returns a context that collects the usage of summarizer calls made with it
+func withUsageCounter(ctx context.Context) (context.Context, *usageCounter) {
+	counter := &usageCounter{}
+	return context.WithValue(ctx, usageKey{}, counter), counter
+}
+
+func (c *usageCounter) total() Usage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.usage
+}
+
+// reportUsage adds the tokens of one API response to the context's counter, if any
+func reportUsage(ctx context.Context, inputTokens, outputTokens int) {
+	counter, ok := ctx.Value(usageKey{}).(*usageCounter)
+	if !ok {
+		return
+	}
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	counter.usage.Add(Usage{Calls: 1, InputTokens: inputTokens, OutputTokens: outputTokens})
+}
+
+// EstimateUsage predicts the tokens one summarizer call would use to compact issue
+// at tier: the rendered prompt as input, and the summary the tier aims for as
+// output. Revisions after a failed validation are not included. The offline
+// provider makes no calls, so its estimate is zero.
+func (c *Compactor) EstimateUsage(issue *types.Issue, tier int) (Usage, error) {
+	if c.config.Provider == ProviderOffline {
+		return Usage{}, nil
+	}
+
+	prompt, _, err := c.RenderPrompt(issue, tier)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	content := EstimateTokens(issue.Description + issue.Design + issue.Notes + issue.AcceptanceCriteria)
+	output := content * 3 / 10 // Tier 1 aims for a 70% reduction
+	if tier == 2 {
+		output = min(content, tier2EstimatedTokens)
+	}
+	return Usage{Calls: 1, InputTokens: EstimateTokens(prompt), OutputTokens: min(output, maxOutputTokens)}, nil
+}
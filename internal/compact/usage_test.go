@@ -0,0 +1,58 @@
+package compact
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEstimateUsage(t *testing.T) {
+	issue := &types.Issue{
+		ID:          "bd-1",
+		Title:       "Speed up imports",
+		Description: strings.Repeat("a", 4000),
+		Notes:       strings.Repeat("b", 4000),
+	}
+
+	c, err := New(nil, "", &CompactConfig{DryRun: true})
+	if err != nil {
+		t.Fatalf("failed to create compactor: %v", err)
+	}
+	if c.Model() != defaultModel {
+		t.Errorf("expected the default model, got %q", c.Model())
+	}
+
+	tier1, err := c.EstimateUsage(issue, 1)
+	if err != nil {
+		t.Fatalf("EstimateUsage failed: %v", err)
+	}
+	prompt, _ := c.prompts.renderTier1Prompt(issue)
+	if tier1.Calls != 1 || tier1.InputTokens != EstimateTokens(prompt) || tier1.InputTokens <= 2000 {
+		t.Errorf("expected the rendered prompt's tokens as input, got %+v", tier1)
+	}
+	if tier1.OutputTokens != 600 {
+		t.Errorf("expected 30%% of the 2000 content tokens as output, got %d", tier1.OutputTokens)
+	}
+
+	tier2, _ := c.EstimateUsage(issue, 2)
+	if tier2.OutputTokens != tier2EstimatedTokens || tier2.InputTokens >= tier1.InputTokens {
+		t.Errorf("expected a short Tier 2 reply from the description alone, got %+v", tier2)
+	}
+
+	offline, _ := New(nil, "", &CompactConfig{Provider: ProviderOffline})
+	if usage, _ := offline.EstimateUsage(issue, 1); usage != (Usage{}) || offline.Model() != "" {
+		t.Errorf("offline compaction makes no calls, got %+v for %q", usage, offline.Model())
+	}
+}
+
+func TestCost(t *testing.T) {
+	cost, ok := Cost(defaultModel, Usage{InputTokens: 1_000_000, OutputTokens: 500_000})
+	if !ok || math.Abs(cost-2.80) > 1e-9 {
+		t.Errorf("expected $2.80, got %v (%v)", cost, ok)
+	}
+	if _, ok := Cost("llama3", Usage{InputTokens: 100}); ok {
+		t.Error("local models have no known price")
+	}
+}
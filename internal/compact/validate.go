@@ -0,0 +1,71 @@
+package compact
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var headingRegex = regexp.MustCompile(`(?m)^#{1,6}\s+\S`)
+
+// ValidationError lists why a summary was rejected
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid summary: " + strings.Join(e.Problems, "; ")
+}
+
+// ValidateSummary checks a summary before it replaces the original's text fields.
+// The summary must be shorter than the original, mention every issue ID and URL
+// the original mentions, and be well-formed markdown: a Tier 1 summary needs
+// structure (labels, headings or bullets), a Tier 2 summary just has to be
+// non-empty. Returns a *ValidationError listing every problem found.
+func ValidateSummary(original *types.Issue, summary string, tier int) error {
+	var problems []string
+
+	trimmed := strings.TrimSpace(summary)
+	if trimmed == "" {
+		problems = append(problems, "summary is empty")
+	}
+
+	if size := contentSize(original); len(summary) >= size {
+		problems = append(problems, fmt.Sprintf("summary (%d bytes) is not shorter than the original (%d bytes)", len(summary), size))
+	}
+
+	var missing []string
+	for _, ref := range extractReferences(original.ID, original.Description, original.Design, original.AcceptanceCriteria, original.Notes) {
+		if !mentions(summary, ref) {
+			missing = append(missing, ref)
+		}
+	}
+	if len(missing) > 0 {
+		problems = append(problems, "missing references: "+strings.Join(missing, ", "))
+	}
+
+	if trimmed != "" {
+		if tier == 1 && !markdownLabelRegex.MatchString(summary) && !headingRegex.MatchString(summary) && !hasBullets(summary) {
+			problems = append(problems, "summary has no markdown structure (labels, headings or bullets)")
+		}
+		if strings.Count(summary, "```")%2 != 0 {
+			problems = append(problems, "summary has an unclosed code block")
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func hasBullets(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if bulletRegex.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
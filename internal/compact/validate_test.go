@@ -0,0 +1,74 @@
+package compact
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestValidateSummary(t *testing.T) {
+	original := &types.Issue{
+		ID:          "bd-7",
+		Description: strings.Repeat("Cache invalidation missed writes from the bulk importer. ", 4) + "Related to bd-3.",
+		Design:      "Invalidate by key prefix, see https://example.com/design.",
+		Notes:       "Benchmarks in bd-30",
+	}
+
+	tests := []struct {
+		name    string
+		summary string
+		tier    int
+		want    []string // Problems expected, by substring
+	}{
+		{"valid tier 1", "**Summary:** Fixed invalidation for bulk imports (bd-3, bd-30).\n\n**References:** https://example.com/design", 1, nil},
+		{"valid tier 2", "Fixed cache invalidation for bulk imports; see bd-3, bd-30 and https://example.com/design.", 2, nil},
+		{"empty", "  \n", 1, []string{"summary is empty", "missing references"}},
+		{"missing refs", "**Summary:** Fixed invalidation. See bd-3.", 1, []string{"missing references: https://example.com/design, bd-30"}},
+		{"prefix is not the ref", "**Summary:** bd-300 bd-3 https://example.com/design", 1, []string{"missing references: bd-30"}},
+		{"unstructured tier 1", "Fixed bd-3 bd-30 https://example.com/design", 1, []string{"no markdown structure"}},
+		{"unclosed code block", "- bd-3 bd-30 https://example.com/design\n```go\nx := 1", 1, []string{"unclosed code block"}},
+		{"too long", strings.Repeat("- bd-3 bd-30 https://example.com/design\n", 20), 1, []string{"not shorter than the original"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSummary(original, tt.summary, tt.tier)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("expected a valid summary, got %v", err)
+				}
+				return
+			}
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+			if len(invalid.Problems) != len(tt.want) {
+				t.Errorf("expected %d problems, got %v", len(tt.want), invalid.Problems)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected %q in %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSummary_ExtractiveOutputPasses(t *testing.T) {
+	issue := extractiveTestIssue()
+	s := NewExtractiveSummarizer()
+
+	tier1, _ := s.SummarizeTier1(context.Background(), issue)
+	if err := ValidateSummary(issue, tier1, 1); err != nil {
+		t.Errorf("extractive tier 1 summary should validate: %v", err)
+	}
+
+	compacted := &types.Issue{ID: issue.ID, Description: tier1}
+	tier2, _ := s.SummarizeTier2(context.Background(), compacted)
+	if err := ValidateSummary(compacted, tier2, 2); err != nil {
+		t.Errorf("extractive tier 2 summary should validate: %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+// Package github is a minimal client for the GitHub Issues REST API, used to bridge
+// GitHub issues and bd issues.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the public GitHub REST API
+const DefaultBaseURL = "https://api.github.com"
+
+// Issue is a GitHub issue as returned by the REST API
+type Issue struct {
+	ID                  int64                `json:"id"`
+	Number              int                  `json:"number"`
+	Title               string               `json:"title"`
+	Body                string               `json:"body"`
+	State               string               `json:"state"` // "open" or "closed"
+	Labels              []Label              `json:"labels"`
+	Assignee            *User                `json:"assignee"`
+	HTMLURL             string               `json:"html_url"`
+	CreatedAt           time.Time            `json:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at"`
+	ClosedAt            *time.Time           `json:"closed_at"`
+	PullRequest         *json.RawMessage     `json:"pull_request,omitempty"` // Set when the issue is a pull request
+	SubIssuesSummary    *SubIssuesSummary    `json:"sub_issues_summary,omitempty"`
+	DependenciesSummary *DependenciesSummary `json:"issue_dependencies_summary,omitempty"`
+}
+
+// Label is a GitHub issue label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// User is a GitHub user
+type User struct {
+	Login string `json:"login"`
+}
+
+// SubIssuesSummary counts an issue's sub-issues
+type SubIssuesSummary struct {
+	Total int `json:"total"`
+}
+
+// DependenciesSummary counts the issues blocking an issue
+type DependenciesSummary struct {
+	BlockedBy int `json:"blocked_by"`
+}
+
+// LabelNames returns the names of the issue's labels
+func (i *Issue) LabelNames() []string {
+	names := make([]string, 0, len(i.Labels))
+	for _, l := range i.Labels {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+// AssigneeLogin returns the login of the issue's assignee, or ""
+func (i *Issue) AssigneeLogin() string {
+	if i.Assignee == nil {
+		return ""
+	}
+	return i.Assignee.Login
+}
+
+// IssueRequest creates or edits an issue. Nil fields are left unchanged.
+type IssueRequest struct {
+	Title     *string   `json:"title,omitempty"`
+	Body      *string   `json:"body,omitempty"`
+	State     *string   `json:"state,omitempty"`
+	Labels    *[]string `json:"labels,omitempty"`
+	Assignees *[]string `json:"assignees,omitempty"`
+}
+
+// APIError is a non-2xx response from the API
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("GitHub API error %d: %s", e.StatusCode, e.Message)
+}
+
+// Client talks to one repository
+type Client struct {
+	baseURL    string
+	owner      string
+	repo       string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for repo ("owner/name"). baseURL defaults to
+// DefaultBaseURL; token may be empty for public repositories (read-only).
+func NewClient(baseURL, repo, token string) (*Client, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid repository %q (expected owner/name)", repo)
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		owner:      owner,
+		repo:       name,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Repo returns the repository as "owner/name"
+func (c *Client) Repo() string {
+	return c.owner + "/" + c.repo
+}
+
+// ListIssues returns all issues (open and closed, excluding pull requests) updated
+// at or after since. A zero since lists every issue.
+func (c *Client) ListIssues(ctx context.Context, since time.Time) ([]*Issue, error) {
+	query := url.Values{}
+	query.Set("state", "all")
+	query.Set("per_page", "100")
+	query.Set("sort", "updated")
+	query.Set("direction", "asc")
+	if !since.IsZero() {
+		query.Set("since", since.UTC().Format(time.RFC3339))
+	}
+
+	all, err := c.listPages(ctx, c.repoPath("issues")+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]*Issue, 0, len(all))
+	for _, issue := range all {
+		if issue.PullRequest == nil {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// GetIssue fetches one issue by number
+func (c *Client) GetIssue(ctx context.Context, number int) (*Issue, error) {
+	var issue Issue
+	if err := c.do(ctx, http.MethodGet, c.repoPath(fmt.Sprintf("issues/%d", number)), nil, &issue, nil); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// CreateIssue opens a new issue
+func (c *Client) CreateIssue(ctx context.Context, req *IssueRequest) (*Issue, error) {
+	var issue Issue
+	if err := c.do(ctx, http.MethodPost, c.repoPath("issues"), req, &issue, nil); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// UpdateIssue edits an issue
+func (c *Client) UpdateIssue(ctx context.Context, number int, req *IssueRequest) (*Issue, error) {
+	var issue Issue
+	if err := c.do(ctx, http.MethodPatch, c.repoPath(fmt.Sprintf("issues/%d", number)), req, &issue, nil); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// ListSubIssues returns the sub-issues of an issue
+func (c *Client) ListSubIssues(ctx context.Context, number int) ([]*Issue, error) {
+	return c.listPages(ctx, c.repoPath(fmt.Sprintf("issues/%d/sub_issues?per_page=100", number)))
+}
+
+// AddSubIssue makes the issue with the given ID (not number) a sub-issue of number
+func (c *Client) AddSubIssue(ctx context.Context, number int, subIssueID int64) error {
+	body := map[string]int64{"sub_issue_id": subIssueID}
+	return c.do(ctx, http.MethodPost, c.repoPath(fmt.Sprintf("issues/%d/sub_issues", number)), body, nil, nil)
+}
+
+// ListBlockedBy returns the issues blocking an issue
+func (c *Client) ListBlockedBy(ctx context.Context, number int) ([]*Issue, error) {
+	return c.listPages(ctx, c.repoPath(fmt.Sprintf("issues/%d/dependencies/blocked_by?per_page=100", number)))
+}
+
+// AddBlockedBy marks an issue as blocked by the issue with the given ID (not number)
+func (c *Client) AddBlockedBy(ctx context.Context, number int, blockingID int64) error {
+	body := map[string]int64{"issue_id": blockingID}
+	return c.do(ctx, http.MethodPost, c.repoPath(fmt.Sprintf("issues/%d/dependencies/blocked_by", number)), body, nil, nil)
+}
+
+func (c *Client) repoPath(path string) string {
+	return fmt.Sprintf("/repos/%s/%s/%s", url.PathEscape(c.owner), url.PathEscape(c.repo), path)
+}
+
+// linkNextRegex finds the next page in a Link header
+var linkNextRegex = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// listPages follows Link headers to collect every page of a list endpoint
+func (c *Client) listPages(ctx context.Context, path string) ([]*Issue, error) {
+	var all []*Issue
+	for path != "" {
+		var page []*Issue
+		var header http.Header
+		if err := c.do(ctx, http.MethodGet, path, nil, &page, &header); err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+
+		path = ""
+		if m := linkNextRegex.FindStringSubmatch(header.Get("Link")); m != nil {
+			path = m[1]
+		}
+	}
+	return all, nil
+}
+
+// do sends a request and decodes the JSON response into out. path is relative to
+// the base URL, or absolute (as in Link headers).
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}, header *http.Header) error {
+	target := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		target = c.baseURL + path
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		msg := strings.TrimSpace(string(data))
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			msg = apiErr.Message
+		}
+		return &APIError{StatusCode: resp.StatusCode, Message: msg}
+	}
+
+	if header != nil {
+		*header = resp.Header
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListIssuesPaginatesAndSkipsPullRequests(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/widgets/issues" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if r.URL.Query().Get("state") != "all" {
+			t.Errorf("Expected state=all, got %q", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/acme/widgets/issues?state=all&page=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"number":1,"title":"One","state":"open"},{"number":2,"title":"A PR","state":"open","pull_request":{}}]`)
+		case "2":
+			fmt.Fprint(w, `[{"number":3,"title":"Three","state":"closed","labels":[{"name":"bug"}],"assignee":{"login":"alice"}}]`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "acme/widgets", "secret")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	issues, err := client.ListIssues(context.Background(), time.Time{})
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Number != 1 || issues[1].Number != 3 {
+		t.Fatalf("Expected issues 1 and 3, got %+v", issues)
+	}
+	if names := issues[1].LabelNames(); len(names) != 1 || names[0] != "bug" {
+		t.Errorf("LabelNames = %v", names)
+	}
+	if issues[1].AssigneeLogin() != "alice" || issues[0].AssigneeLogin() != "" {
+		t.Errorf("Unexpected assignees: %q, %q", issues[1].AssigneeLogin(), issues[0].AssigneeLogin())
+	}
+}
+
+func TestUpdateIssueSendsOnlySetFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/repos/acme/widgets/issues/7" {
+			http.NotFound(w, r)
+			return
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if _, ok := body["title"]; ok {
+			t.Error("Expected title to be omitted")
+		}
+		if labels, ok := body["labels"].([]interface{}); !ok || len(labels) != 0 {
+			t.Errorf("Expected empty labels list to be sent, got %v", body["labels"])
+		}
+		fmt.Fprint(w, `{"number":7,"state":"closed"}`)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "acme/widgets", "")
+	state := "closed"
+	labels := []string{}
+	issue, err := client.UpdateIssue(context.Background(), 7, &IssueRequest{State: &state, Labels: &labels})
+	if err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if issue.State != "closed" {
+		t.Errorf("State = %q", issue.State)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "acme/widgets", "")
+	_, err := client.GetIssue(context.Background(), 99)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 || apiErr.Message != "Not Found" {
+		t.Errorf("Expected 404 APIError, got %v", err)
+	}
+}
+
+func TestNewClientInvalidRepo(t *testing.T) {
+	for _, repo := range []string{"", "acme", "acme/", "/widgets", "a/b/c"} {
+		if _, err := NewClient("", repo, ""); err == nil {
+			t.Errorf("NewClient(%q): expected error", repo)
+		}
+	}
+}
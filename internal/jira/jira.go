@@ -0,0 +1,356 @@
+// Package jira reads Jira's offline issue exports (the XML/RSS "Export XML" view and
+// the CSV export) into a common form for importing into bd.
+package jira
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Issue is one exported Jira issue. Field values are Jira's display names (such as
+// "Story" or "In Progress"); mapping them to bd values is left to the caller.
+type Issue struct {
+	Key         string
+	Summary     string
+	Description string
+	Type        string
+	Priority    string
+	Status      string
+	Resolution  string
+	Assignee    string
+	Labels      []string
+	Parent      string // Key of the parent issue, for sub-tasks
+	EpicLink    string // Key of the epic the issue belongs to
+	Links       []Link
+	Comments    []Comment
+	Resolved    *time.Time
+}
+
+// Link is an issue link. From is the outward side: for the "Blocks" type, From
+// blocks To.
+type Link struct {
+	Type string
+	From string
+	To   string
+}
+
+// Comment is a comment on an issue
+type Comment struct {
+	Author string
+	Body   string
+}
+
+// IsResolved reports whether Jira considers the issue done, whatever its status
+func (i *Issue) IsResolved() bool {
+	return i.Resolved != nil || (i.Resolution != "" && !strings.EqualFold(i.Resolution, "Unresolved"))
+}
+
+type xmlRSS struct {
+	Items []xmlItem `xml:"channel>item"`
+}
+
+type xmlItem struct {
+	Key          string           `xml:"key"`
+	Summary      string           `xml:"summary"`
+	Description  string           `xml:"description"`
+	Type         string           `xml:"type"`
+	Priority     string           `xml:"priority"`
+	Status       string           `xml:"status"`
+	Resolution   string           `xml:"resolution"`
+	Assignee     xmlUser          `xml:"assignee"`
+	Labels       []string         `xml:"labels>label"`
+	Resolved     string           `xml:"resolved"`
+	Parent       string           `xml:"parent"`
+	LinkTypes    []xmlLinkType    `xml:"issuelinks>issuelinktype"`
+	Comments     []xmlComment     `xml:"comments>comment"`
+	CustomFields []xmlCustomField `xml:"customfields>customfield"`
+}
+
+type xmlUser struct {
+	Username string `xml:"username,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type xmlLinkType struct {
+	Name    string   `xml:"name"`
+	Outward []string `xml:"outwardlinks>issuelink>issuekey"`
+	Inward  []string `xml:"inwardlinks>issuelink>issuekey"`
+}
+
+type xmlComment struct {
+	Author string `xml:"author,attr"`
+	Body   string `xml:",chardata"`
+}
+
+type xmlCustomField struct {
+	Key    string   `xml:"key,attr"`
+	Name   string   `xml:"customfieldname"`
+	Values []string `xml:"customfieldvalues>customfieldvalue"`
+}
+
+// ParseXML reads a Jira XML (RSS) export
+func ParseXML(r io.Reader) ([]*Issue, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var rss xmlRSS
+	if err := decoder.Decode(&rss); err != nil {
+		return nil, fmt.Errorf("failed to parse Jira XML: %w", err)
+	}
+
+	issues := make([]*Issue, 0, len(rss.Items))
+	for i, item := range rss.Items {
+		key := strings.TrimSpace(item.Key)
+		if key == "" {
+			return nil, fmt.Errorf("item %d has no issue key", i+1)
+		}
+		issue := &Issue{
+			Key:         key,
+			Summary:     strings.TrimSpace(item.Summary),
+			Description: htmlToText(item.Description),
+			Type:        strings.TrimSpace(item.Type),
+			Priority:    strings.TrimSpace(item.Priority),
+			Status:      strings.TrimSpace(item.Status),
+			Resolution:  strings.TrimSpace(item.Resolution),
+			Assignee:    xmlAssignee(item.Assignee),
+			Parent:      strings.TrimSpace(item.Parent),
+			Resolved:    parseDate(item.Resolved),
+		}
+		for _, label := range item.Labels {
+			if label = strings.TrimSpace(label); label != "" {
+				issue.Labels = append(issue.Labels, label)
+			}
+		}
+		for _, lt := range item.LinkTypes {
+			name := strings.TrimSpace(lt.Name)
+			for _, to := range lt.Outward {
+				issue.Links = append(issue.Links, Link{Type: name, From: key, To: strings.TrimSpace(to)})
+			}
+			for _, from := range lt.Inward {
+				issue.Links = append(issue.Links, Link{Type: name, From: strings.TrimSpace(from), To: key})
+			}
+		}
+		for _, c := range item.Comments {
+			if body := htmlToText(c.Body); body != "" {
+				issue.Comments = append(issue.Comments, Comment{Author: strings.TrimSpace(c.Author), Body: body})
+			}
+		}
+		for _, cf := range item.CustomFields {
+			if isEpicLinkField(cf.Key, cf.Name) && len(cf.Values) > 0 {
+				issue.EpicLink = strings.TrimSpace(cf.Values[0])
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// xmlAssignee prefers the username over the display name; unassigned issues
+// export as "Unassigned" with username -1
+func xmlAssignee(u xmlUser) string {
+	if u.Username == "-1" {
+		return ""
+	}
+	if u.Username != "" {
+		return strings.TrimSpace(u.Username)
+	}
+	name := strings.TrimSpace(u.Name)
+	if strings.EqualFold(name, "Unassigned") {
+		return ""
+	}
+	return name
+}
+
+func isEpicLinkField(key, name string) bool {
+	return strings.HasSuffix(key, ":gh-epic-link") || strings.EqualFold(strings.TrimSpace(name), "Epic Link")
+}
+
+// linkColumnRegex matches CSV issue link columns such as "Outward issue link (Blocks)"
+var linkColumnRegex = regexp.MustCompile(`(?i)^(inward|outward) issue link \((.+)\)$`)
+
+// ParseCSV reads a Jira CSV export. Jira repeats columns for multi-valued fields
+// (labels, comments, links), and refers to parents by issue id rather than key.
+func ParseCSV(r io.Reader) ([]*Issue, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Jira CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\uFEFF")
+	}
+	columns := make(map[string][]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		columns[name] = append(columns[name], i)
+	}
+	if len(columns["issue key"]) == 0 {
+		return nil, fmt.Errorf("no \"Issue key\" column in Jira CSV")
+	}
+
+	rows := records[1:]
+	get := func(row []string, name string) string {
+		for _, i := range columns[name] {
+			if i < len(row) && strings.TrimSpace(row[i]) != "" {
+				return strings.TrimSpace(row[i])
+			}
+		}
+		return ""
+	}
+	all := func(row []string, name string) []string {
+		var values []string
+		for _, i := range columns[name] {
+			if i < len(row) && strings.TrimSpace(row[i]) != "" {
+				values = append(values, strings.TrimSpace(row[i]))
+			}
+		}
+		return values
+	}
+
+	type linkColumn struct {
+		column   string
+		linkType string
+		outward  bool
+	}
+	var linkColumns []linkColumn
+	seen := make(map[string]bool)
+	for _, name := range header {
+		m := linkColumnRegex.FindStringSubmatch(strings.TrimSpace(name))
+		column := strings.ToLower(strings.TrimSpace(name))
+		if m == nil || seen[column] {
+			continue
+		}
+		seen[column] = true
+		linkColumns = append(linkColumns, linkColumn{column: column, linkType: m[2], outward: strings.EqualFold(m[1], "outward")})
+	}
+
+	// Parents and epics may be given as numeric issue ids
+	keysByID := make(map[string]string)
+	for _, row := range rows {
+		if id, key := get(row, "issue id"), get(row, "issue key"); id != "" && key != "" {
+			keysByID[id] = key
+		}
+	}
+	resolveKey := func(ref string) string {
+		if key, ok := keysByID[ref]; ok {
+			return key
+		}
+		return ref
+	}
+
+	issues := make([]*Issue, 0, len(rows))
+	for n, row := range rows {
+		key := get(row, "issue key")
+		if key == "" {
+			if strings.TrimSpace(strings.Join(row, "")) == "" {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: missing issue key", n+2)
+		}
+		issue := &Issue{
+			Key:         key,
+			Summary:     get(row, "summary"),
+			Description: get(row, "description"),
+			Type:        get(row, "issue type"),
+			Priority:    get(row, "priority"),
+			Status:      get(row, "status"),
+			Resolution:  get(row, "resolution"),
+			Assignee:    get(row, "assignee"),
+			Labels:      all(row, "labels"),
+			Resolved:    parseDate(get(row, "resolved")),
+		}
+		if parent := get(row, "parent id"); parent != "" {
+			issue.Parent = resolveKey(parent)
+		} else if parent := get(row, "parent"); parent != "" {
+			issue.Parent = resolveKey(parent)
+		}
+		if epic := get(row, "custom field (epic link)"); epic != "" {
+			issue.EpicLink = resolveKey(epic)
+		}
+		for _, value := range all(row, "comment") {
+			issue.Comments = append(issue.Comments, parseCSVComment(value))
+		}
+		for _, lc := range linkColumns {
+			for _, other := range all(row, lc.column) {
+				if lc.outward {
+					issue.Links = append(issue.Links, Link{Type: lc.linkType, From: key, To: other})
+				} else {
+					issue.Links = append(issue.Links, Link{Type: lc.linkType, From: other, To: key})
+				}
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// parseCSVComment splits a CSV comment cell ("date;author;body") into its parts
+func parseCSVComment(value string) Comment {
+	parts := strings.SplitN(value, ";", 3)
+	if len(parts) == 3 && parseDate(parts[0]) != nil {
+		return Comment{Author: strings.TrimSpace(parts[1]), Body: strings.TrimSpace(parts[2])}
+	}
+	return Comment{Body: value}
+}
+
+// dateLayouts are the formats Jira uses for dates in XML and (depending on locale
+// settings) CSV exports
+var dateLayouts = []string{
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"02/Jan/06 3:04 PM",
+	"2/Jan/06 3:04 PM",
+	"02/Jan/06 15:04",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+}
+
+// parseDate parses a Jira date, returning nil for empty or unrecognized values
+func parseDate(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+var (
+	htmlBreakRegex = regexp.MustCompile(`(?i)<br\s*/?>\n?|</(p|div|li|h[1-6]|tr)>\n?`)
+	htmlItemRegex  = regexp.MustCompile(`(?i)<li[^>]*>`)
+	htmlTagRegex   = regexp.MustCompile(`<[^>]*>`)
+	blankRunRegex  = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText reduces the rendered HTML Jira puts in XML exports to plain text
+func htmlToText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = htmlBreakRegex.ReplaceAllString(s, "\n")
+	s = htmlItemRegex.ReplaceAllString(s, "- ")
+	s = htmlTagRegex.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(blankRunRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
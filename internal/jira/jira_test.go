@@ -0,0 +1,146 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleXML = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="0.92">
+<channel>
+  <title>Jira</title>
+  <item>
+    <title>[ABC-1] Checkout epic</title>
+    <key id="10001">ABC-1</key>
+    <summary>Checkout epic</summary>
+    <description>&lt;p&gt;First line&lt;br/&gt;
+second &amp;amp; last&lt;/p&gt;</description>
+    <type id="10000">Epic</type>
+    <priority id="2">High</priority>
+    <status id="1">To Do</status>
+    <resolution id="-1">Unresolved</resolution>
+    <assignee username="-1">Unassigned</assignee>
+    <labels><label>payments</label></labels>
+  </item>
+  <item>
+    <key id="10002">ABC-2</key>
+    <summary>Card form</summary>
+    <type id="10001">Story</type>
+    <priority id="3">Medium</priority>
+    <status id="10001">Done</status>
+    <resolution id="1">Done</resolution>
+    <assignee username="jdoe">Jane Doe</assignee>
+    <resolved>Tue, 3 Jan 2023 09:30:00 +0000</resolved>
+    <issuelinks>
+      <issuelinktype id="10000">
+        <name>Blocks</name>
+        <outwardlinks description="blocks">
+          <issuelink><issuekey id="10003">ABC-3</issuekey></issuelink>
+        </outwardlinks>
+      </issuelinktype>
+    </issuelinks>
+    <comments>
+      <comment id="1" author="jdoe" created="Tue, 3 Jan 2023 09:00:00 +0000">&lt;p&gt;Looks good&lt;/p&gt;</comment>
+    </comments>
+    <customfields>
+      <customfield id="customfield_10008" key="com.pyxis.greenhopper.jira:gh-epic-link">
+        <customfieldname>Epic Link</customfieldname>
+        <customfieldvalues><customfieldvalue>ABC-1</customfieldvalue></customfieldvalues>
+      </customfield>
+    </customfields>
+  </item>
+  <item>
+    <key id="10003">ABC-3</key>
+    <summary>Validate card</summary>
+    <type id="10002">Sub-task</type>
+    <status id="1">In Progress</status>
+    <parent id="10002">ABC-2</parent>
+    <issuelinks>
+      <issuelinktype id="10000">
+        <name>Blocks</name>
+        <inwardlinks description="is blocked by">
+          <issuelink><issuekey id="10002">ABC-2</issuekey></issuelink>
+        </inwardlinks>
+      </issuelinktype>
+    </issuelinks>
+  </item>
+</channel>
+</rss>`
+
+func TestParseXML(t *testing.T) {
+	issues, err := ParseXML(strings.NewReader(sampleXML))
+	if err != nil {
+		t.Fatalf("ParseXML failed: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 issues, got %d", len(issues))
+	}
+
+	epic, story, sub := issues[0], issues[1], issues[2]
+	if epic.Description != "First line\nsecond & last" {
+		t.Errorf("Description = %q", epic.Description)
+	}
+	if epic.Assignee != "" || epic.IsResolved() {
+		t.Errorf("Expected an unassigned, unresolved epic: %+v", epic)
+	}
+	if len(epic.Labels) != 1 || epic.Labels[0] != "payments" {
+		t.Errorf("Labels = %v", epic.Labels)
+	}
+	if story.Assignee != "jdoe" || story.EpicLink != "ABC-1" || !story.IsResolved() || story.Resolved == nil {
+		t.Errorf("Unexpected story: %+v", story)
+	}
+	if len(story.Comments) != 1 || story.Comments[0] != (Comment{Author: "jdoe", Body: "Looks good"}) {
+		t.Errorf("Comments = %+v", story.Comments)
+	}
+	want := Link{Type: "Blocks", From: "ABC-2", To: "ABC-3"}
+	if len(story.Links) != 1 || story.Links[0] != want {
+		t.Errorf("Story links = %+v", story.Links)
+	}
+	if len(sub.Links) != 1 || sub.Links[0] != want {
+		t.Errorf("Inward link should read the same from both ends, got %+v", sub.Links)
+	}
+	if sub.Parent != "ABC-2" {
+		t.Errorf("Parent = %q", sub.Parent)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	input := "\uFEFFSummary,Issue key,Issue id,Issue Type,Status,Priority,Resolution,Assignee,Labels,Labels,Parent id,Custom field (Epic Link),Outward issue link (Blocks),Inward issue link (Relates),Comment,Comment\n" +
+		"Checkout epic,ABC-1,10001,Epic,To Do,High,,,payments,,,,,,,\n" +
+		"Card form,ABC-2,10002,Story,Done,Medium,Done,jdoe,ui,web,,ABC-1,ABC-3,ABC-1,\"03/Jan/23 9:00 AM;jdoe;Looks good; ship it\",plain note\n" +
+		"Validate card,ABC-3,10003,Sub-task,In Progress,,,,,,10002,,,,,\n"
+
+	issues, err := ParseCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 issues, got %d", len(issues))
+	}
+
+	story := issues[1]
+	if story.Type != "Story" || story.Status != "Done" || !story.IsResolved() || story.Assignee != "jdoe" {
+		t.Errorf("Unexpected story: %+v", story)
+	}
+	if strings.Join(story.Labels, ",") != "ui,web" {
+		t.Errorf("Labels = %v", story.Labels)
+	}
+	if story.EpicLink != "ABC-1" {
+		t.Errorf("EpicLink = %q", story.EpicLink)
+	}
+	wantLinks := []Link{{Type: "Blocks", From: "ABC-2", To: "ABC-3"}, {Type: "Relates", From: "ABC-1", To: "ABC-2"}}
+	if len(story.Links) != 2 || story.Links[0] != wantLinks[0] || story.Links[1] != wantLinks[1] {
+		t.Errorf("Links = %+v", story.Links)
+	}
+	wantComments := []Comment{{Author: "jdoe", Body: "Looks good; ship it"}, {Body: "plain note"}}
+	if len(story.Comments) != 2 || story.Comments[0] != wantComments[0] || story.Comments[1] != wantComments[1] {
+		t.Errorf("Comments = %+v", story.Comments)
+	}
+	if issues[2].Parent != "ABC-2" {
+		t.Errorf("Expected parent id 10002 to resolve to ABC-2, got %q", issues[2].Parent)
+	}
+
+	if _, err := ParseCSV(strings.NewReader("Summary,Status\nx,Open\n")); err == nil {
+		t.Error("Expected error for CSV without an issue key column")
+	}
+}
@@ -0,0 +1,244 @@
+// Package recur parses recurrence rules for repeating issues and computes their
+// occurrences.
+//
+// Supported rules:
+//
+//	daily, weekly, monthly, yearly
+//	every 3d, every 2w, every 12h      (fixed interval)
+//	0 9 * * 1                          (cron: minute hour day-of-month month day-of-week)
+//
+// Named and interval rules keep the time of day of the occurrence they follow.
+// Cron rules are evaluated in the time zone of the time passed to Next.
+package recur
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes occurrences of a recurrence rule
+type Schedule interface {
+	// Next returns the first occurrence strictly after t
+	Next(t time.Time) time.Time
+}
+
+// Parse parses a recurrence rule
+func Parse(rule string) (Schedule, error) {
+	rule = strings.TrimSpace(strings.ToLower(rule))
+	switch rule {
+	case "":
+		return nil, fmt.Errorf("empty recurrence rule")
+	case "daily":
+		return calendarSchedule{days: 1}, nil
+	case "weekly":
+		return calendarSchedule{days: 7}, nil
+	case "monthly":
+		return calendarSchedule{months: 1}, nil
+	case "yearly":
+		return calendarSchedule{years: 1}, nil
+	}
+
+	if rest, ok := strings.CutPrefix(rule, "every "); ok {
+		return parseInterval(strings.TrimSpace(rest))
+	}
+
+	if len(strings.Fields(rule)) == 5 {
+		return parseCron(rule)
+	}
+
+	return nil, fmt.Errorf("invalid recurrence rule %q (examples: daily, weekly, monthly, \"every 2w\", \"0 9 * * 1\")", rule)
+}
+
+// Upcoming returns the next n occurrences after t
+func Upcoming(s Schedule, t time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+	for i := 0; i < n; i++ {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// calendarSchedule advances by whole days, months or years, so occurrences keep
+// their wall-clock time across DST changes
+type calendarSchedule struct {
+	years, months, days int
+}
+
+func (c calendarSchedule) Next(t time.Time) time.Time {
+	return t.AddDate(c.years, c.months, c.days)
+}
+
+// intervalSchedule advances by a fixed duration
+type intervalSchedule struct {
+	every time.Duration
+}
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.every)
+}
+
+// parseInterval parses "3d", "2w", "12h" or "90m"
+func parseInterval(s string) (Schedule, error) {
+	if s == "" {
+		return nil, fmt.Errorf("missing interval after \"every\"")
+	}
+	unit := s[len(s)-1]
+	n, err := strconv.Atoi(strings.TrimSpace(s[:len(s)-1]))
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("invalid interval %q (expected e.g. 3d, 2w, 12h)", s)
+	}
+	switch unit {
+	case 'd':
+		return calendarSchedule{days: n}, nil
+	case 'w':
+		return calendarSchedule{days: 7 * n}, nil
+	case 'h':
+		return intervalSchedule{every: time.Duration(n) * time.Hour}, nil
+	case 'm':
+		return intervalSchedule{every: time.Duration(n) * time.Minute}, nil
+	}
+	return nil, fmt.Errorf("invalid interval unit in %q (use m, h, d or w)", s)
+}
+
+// cronSchedule matches times against the five standard cron fields
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64 // bit sets
+	domAny, dowAny                bool
+}
+
+// cronField describes the valid range of a cron field
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day-of-month", 1, 31},
+	{"month", 1, 12},
+	{"day-of-week", 0, 7},
+}
+
+// parseCron parses a five-field cron expression
+func parseCron(rule string) (Schedule, error) {
+	fields := strings.Fields(rule)
+	sets := make([]uint64, len(fields))
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	c := &cronSchedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	// Sunday can be written as 0 or 7
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return c, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges (a-b) and steps
+// (*/n, a-b/n) into a bit set
+func parseCronField(field string, f cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in cron %s field", stepPart, f.name)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		if rangePart != "*" {
+			loStr, hiStr, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = strconv.Atoi(loStr); err != nil {
+				return 0, fmt.Errorf("invalid value %q in cron %s field", part, f.name)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return 0, fmt.Errorf("invalid value %q in cron %s field", part, f.name)
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+		}
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, fmt.Errorf("value %q out of range %d-%d in cron %s field", part, f.min, f.max, f.name)
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// maxCronSearch bounds the search for an occurrence, so impossible rules such as
+// "0 0 31 2 *" terminate
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	limit := t.Add(maxCronSearch)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's day rule: when both day-of-month and day-of-week are
+// restricted, a day matching either one matches
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowMatch
+	case c.dowAny:
+		return domMatch
+	}
+	return domMatch || dowMatch
+}
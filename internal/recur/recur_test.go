@@ -0,0 +1,81 @@
+package recur
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	for _, rule := range []string{"", "fortnightly", "every", "every 0d", "every 3y", "60 * * * *", "* * * *", "*/0 * * * *", "5-1 * * * *"} {
+		if _, err := Parse(rule); err == nil {
+			t.Errorf("Parse(%q): expected error", rule)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// Wednesday
+	base := time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule string
+		want time.Time
+	}{
+		{"daily", time.Date(2025, 1, 16, 9, 30, 0, 0, time.UTC)},
+		{"Weekly", time.Date(2025, 1, 22, 9, 30, 0, 0, time.UTC)},
+		{"monthly", time.Date(2025, 2, 15, 9, 30, 0, 0, time.UTC)},
+		{"every 3d", time.Date(2025, 1, 18, 9, 30, 0, 0, time.UTC)},
+		{"every 2w", time.Date(2025, 1, 29, 9, 30, 0, 0, time.UTC)},
+		{"every 12h", time.Date(2025, 1, 15, 21, 30, 0, 0, time.UTC)},
+		// Mondays at 09:00
+		{"0 9 * * 1", time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)},
+		// Later the same day
+		{"0 17 * * *", time.Date(2025, 1, 15, 17, 0, 0, 0, time.UTC)},
+		// Every 15 minutes
+		{"*/15 * * * *", time.Date(2025, 1, 15, 9, 45, 0, 0, time.UTC)},
+		// First of the month or Fridays: Friday comes first
+		{"0 0 1 * 5", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+		// Sunday written as 7
+		{"30 8 * * 7", time.Date(2025, 1, 19, 8, 30, 0, 0, time.UTC)},
+		// Quarterly on the 1st
+		{"0 6 1 1,4,7,10 *", time.Date(2025, 4, 1, 6, 0, 0, 0, time.UTC)},
+		// Weekdays range
+		{"0 9 * * 1-5", time.Date(2025, 1, 16, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.rule)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tt.rule, err)
+			continue
+		}
+		if got := s.Next(base); !got.Equal(tt.want) {
+			t.Errorf("%q: Next(%v) = %v, want %v", tt.rule, base, got, tt.want)
+		}
+	}
+}
+
+func TestNextImpossibleCron(t *testing.T) {
+	s, err := Parse("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := s.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Expected no occurrence for Feb 31, got %v", got)
+	}
+	if got := Upcoming(s, time.Now(), 3); len(got) != 0 {
+		t.Errorf("Expected no upcoming occurrences, got %v", got)
+	}
+}
+
+func TestUpcoming(t *testing.T) {
+	s, _ := Parse("weekly")
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	got := Upcoming(s, base, 3)
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 occurrences, got %d", len(got))
+	}
+	if !got[2].Equal(time.Date(2025, 1, 22, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected third occurrence %v", got[2])
+	}
+}
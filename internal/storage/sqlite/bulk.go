@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// UpdateIssues applies the same field updates and label changes to multiple issues
+// atomically in a single transaction.
+//
+// Each issue receives its own audit trail events (one updated/status event for the
+// field changes plus one event per label actually added or removed), and all issues
+// are marked dirty once at the end. If any issue is missing or any update fails
+// validation, the whole batch is rolled back.
+func (s *SQLiteStorage) UpdateIssues(ctx context.Context, ids []string, updates map[string]interface{}, addLabels, removeLabels []string, actor string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// Get old issues for events (also verifies that every issue exists)
+	oldIssues := make([]*types.Issue, 0, len(ids))
+	for _, id := range ids {
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return err
+		}
+		if issue == nil {
+			return fmt.Errorf("issue %s not found", id)
+		}
+		oldIssues = append(oldIssues, issue)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, oldIssue := range oldIssues {
+		if len(updates) > 0 {
+			// updateIssueTx may add closed_at to the map, so give each issue its own copy
+			issueUpdates := make(map[string]interface{}, len(updates))
+			for k, v := range updates {
+				issueUpdates[k] = v
+			}
+			if err := updateIssueTx(ctx, tx, oldIssue, issueUpdates, actor); err != nil {
+				return fmt.Errorf("failed to update %s: %w", oldIssue.ID, err)
+			}
+		}
+
+		for _, label := range addLabels {
+			if _, err := addLabelTx(ctx, tx, oldIssue.ID, label, actor); err != nil {
+				return err
+			}
+		}
+
+		for _, label := range removeLabels {
+			if _, err := removeLabelTx(ctx, tx, oldIssue.ID, label, actor); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Mark all issues dirty for incremental export in one pass
+	if err := markIssuesDirtyTx(ctx, tx, ids); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
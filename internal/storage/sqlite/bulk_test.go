@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestUpdateIssues(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"First", "Second", "Third"} {
+		issue := &types.Issue{
+			Title:     title,
+			Status:    types.StatusOpen,
+			Priority:  2,
+			IssueType: types.TypeTask,
+		}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if err := store.AddLabel(ctx, issue.ID, "legacy", "test-user"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := store.ClearDirtyIssues(ctx); err != nil {
+		t.Fatalf("ClearDirtyIssues failed: %v", err)
+	}
+
+	updates := map[string]interface{}{
+		"priority": 3,
+		"assignee": "bob",
+	}
+	err := store.UpdateIssues(ctx, ids[:2], updates, []string{"cleanup"}, []string{"legacy"}, "bulk-user")
+	if err != nil {
+		t.Fatalf("UpdateIssues failed: %v", err)
+	}
+
+	for _, id := range ids[:2] {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if issue.Priority != 3 || issue.Assignee != "bob" {
+			t.Errorf("%s: expected priority 3 and assignee bob, got %d and %q", id, issue.Priority, issue.Assignee)
+		}
+
+		labels, _ := store.GetLabels(ctx, id)
+		if len(labels) != 1 || labels[0] != "cleanup" {
+			t.Errorf("%s: expected labels [cleanup], got %v", id, labels)
+		}
+
+		events, _ := store.GetEvents(ctx, id, 0)
+		var updated, added, removed int
+		for _, e := range events {
+			if e.Actor != "bulk-user" {
+				continue
+			}
+			switch e.EventType {
+			case types.EventUpdated:
+				updated++
+			case types.EventLabelAdded:
+				added++
+			case types.EventLabelRemoved:
+				removed++
+			}
+		}
+		if updated != 1 || added != 1 || removed != 1 {
+			t.Errorf("%s: expected 1 updated/label_added/label_removed event, got %d/%d/%d", id, updated, added, removed)
+		}
+	}
+
+	// Untouched issue keeps its values
+	third, _ := store.GetIssue(ctx, ids[2])
+	if third.Priority != 2 || third.Assignee != "" {
+		t.Errorf("third issue should be unchanged, got priority %d assignee %q", third.Priority, third.Assignee)
+	}
+
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if len(dirty) != 2 {
+		t.Errorf("expected 2 dirty issues, got %v", dirty)
+	}
+}
+
+func TestUpdateIssuesClosesWithClosedAt(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		issue := &types.Issue{Title: "Closable", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	if err := store.UpdateIssues(ctx, ids, map[string]interface{}{"status": "closed"}, nil, nil, "test-user"); err != nil {
+		t.Fatalf("UpdateIssues failed: %v", err)
+	}
+
+	for _, id := range ids {
+		issue, _ := store.GetIssue(ctx, id)
+		if issue.Status != types.StatusClosed || issue.ClosedAt == nil {
+			t.Errorf("%s: expected closed with closed_at, got %s (closed_at=%v)", id, issue.Status, issue.ClosedAt)
+		}
+	}
+}
+
+func TestUpdateIssuesRollsBackOnError(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Keep me", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Missing issue aborts the whole batch
+	err := store.UpdateIssues(ctx, []string{issue.ID, "bd-999"}, map[string]interface{}{"priority": 0}, nil, nil, "test-user")
+	if err == nil {
+		t.Fatal("expected error for missing issue")
+	}
+
+	// Invalid value aborts the whole batch
+	err = store.UpdateIssues(ctx, []string{issue.ID}, map[string]interface{}{"priority": 9}, []string{"x"}, nil, "test-user")
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Priority != 2 {
+		t.Errorf("expected priority unchanged, got %d", got.Priority)
+	}
+	labels, _ := store.GetLabels(ctx, issue.ID)
+	if len(labels) != 0 {
+		t.Errorf("expected no labels after rollback, got %v", labels)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -121,9 +122,31 @@ func compareIssues(existing, incoming *types.Issue) []string {
 		conflicts = append(conflicts, "external_ref")
 	}
 
+	// Compare dates (handle nil cases)
+	if !equalTimePtr(existing.DueAt, incoming.DueAt) {
+		conflicts = append(conflicts, "due_at")
+	}
+	if !equalTimePtr(existing.DeferUntil, incoming.DeferUntil) {
+		conflicts = append(conflicts, "defer_until")
+	}
+	if existing.Recurrence != incoming.Recurrence {
+		conflicts = append(conflicts, "recurrence")
+	}
+
 	return conflicts
 }
 
+// equalTimePtr compares two *time.Time pointers for equality (same instant)
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Equal(*b)
+}
+
 // equalIntPtr compares two *int pointers for equality
 func equalIntPtr(a, b *int) bool {
 	if a == nil && b == nil {
@@ -3,7 +3,10 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -281,53 +284,425 @@ func (s *SQLiteStorage) CheckEligibility(ctx context.Context, issueID string, ti
 // ApplyCompaction updates the compaction metadata for an issue after successfully compacting it.
 // This sets compaction_level, compacted_at, compacted_at_commit, and original_size fields.
 func (s *SQLiteStorage) ApplyCompaction(ctx context.Context, issueID string, level int, originalSize int, compressedSize int, commitHash string) error {
-	now := time.Now().UTC()
-	
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
-	
+
+	if err := applyCompactionTx(ctx, tx, issueID, level, originalSize, compressedSize, commitHash); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// applyCompactionTx sets the compaction metadata and records the compacted event
+// within an existing transaction. A later tier keeps the commit and size recorded by
+// the first: by then the JSONL at HEAD holds the Tier 1 summary, not the original.
+func applyCompactionTx(ctx context.Context, tx execer, issueID string, level int, originalSize int, compressedSize int, commitHash string) error {
+	now := time.Now().UTC()
+
 	var commitHashPtr *string
 	if commitHash != "" {
 		commitHashPtr = &commitHash
 	}
-	
-	_, err = tx.ExecContext(ctx, `
+
+	_, err := tx.ExecContext(ctx, `
 		UPDATE issues
 		SET compaction_level = ?,
 		    compacted_at = ?,
-		    compacted_at_commit = ?,
-		    original_size = ?,
+		    compacted_at_commit = COALESCE(compacted_at_commit, ?),
+		    original_size = COALESCE(original_size, ?),
 		    updated_at = ?
 		WHERE id = ?
 	`, level, now, commitHashPtr, originalSize, now, issueID)
-	
+
 	if err != nil {
 		return fmt.Errorf("failed to apply compaction metadata: %w", err)
 	}
-	
+
 	reductionPct := 0.0
 	if originalSize > 0 {
 		reductionPct = (1.0 - float64(compressedSize)/float64(originalSize)) * 100
 	}
-	
+
 	eventData := fmt.Sprintf(`{"tier":%d,"original_size":%d,"compressed_size":%d,"reduction_pct":%.1f}`,
 		level, originalSize, compressedSize, reductionPct)
-	
+
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO events (issue_id, event_type, actor, comment)
 		VALUES (?, ?, 'compactor', ?)
 	`, issueID, types.EventCompacted, eventData)
-	
+
 	if err != nil {
 		return fmt.Errorf("failed to record compaction event: %w", err)
 	}
-	
+
+	return nil
+}
+
+// Snapshot is an issue's content saved just before a compaction pass
+type Snapshot struct {
+	ID              int64
+	IssueID         string
+	SnapshotTime    time.Time
+	CompactionLevel int            // Level the issue was compacted to
+	OriginalSize    int            // Content size before this pass
+	CompressedSize  int            // Content size after this pass
+	Issue           *types.Issue   // Full issue as it was before this pass
+	ArchivedEvents  []*types.Event // Audit trail moved out of the events table (tier 2)
+}
+
+// SaveSnapshot records an issue's content before compacting it to level.
+// archived events are stored with the snapshot and removed from the audit trail.
+func (s *SQLiteStorage) SaveSnapshot(ctx context.Context, issue *types.Issue, level int, compressedSize int, archived []*types.Event) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := saveSnapshotTx(ctx, tx, issue, level, compressedSize, archived); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CompactIssue replaces an issue's text fields with summary and records the
+// compaction in one transaction: the snapshot of issue, the archiving of the
+// archived events, the content update and the new compaction level either all
+// happen or none do.
+func (s *SQLiteStorage) CompactIssue(ctx context.Context, issue *types.Issue, level int, summary string, archived []*types.Event, commitHash string, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := saveSnapshotTx(ctx, tx, issue, level, len(summary), archived); err != nil {
+		return err
+	}
+	updates := map[string]interface{}{
+		"description":         summary,
+		"design":              "",
+		"notes":               "",
+		"acceptance_criteria": "",
+	}
+	if err := updateIssueTx(ctx, tx, issue, updates, actor); err != nil {
+		return err
+	}
+	originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
+	if err := applyCompactionTx(ctx, tx, issue.ID, level, originalSize, len(summary), commitHash); err != nil {
+		return err
+	}
+	if err := markIssuesDirtyTx(ctx, tx, []string{issue.ID}); err != nil {
+		return err
+	}
+	// Compacting a restored issue again ends its exemption from automatic compaction
+	if _, err := tx.ExecContext(ctx, `DELETE FROM metadata WHERE key = ?`, compactionRestoredPrefix+issue.ID); err != nil {
+		return fmt.Errorf("failed to clear restore marker: %w", err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
-	
 	return nil
 }
+
+// saveSnapshotTx inserts a snapshot and removes the archived events within an
+// existing transaction
+func saveSnapshotTx(ctx context.Context, tx execer, issue *types.Issue, level int, compressedSize int, archived []*types.Event) error {
+	content, err := json.Marshal(issue)
+	if err != nil {
+		return fmt.Errorf("failed to encode issue: %w", err)
+	}
+	var archivedJSON *string
+	if len(archived) > 0 {
+		data, err := json.Marshal(archived)
+		if err != nil {
+			return fmt.Errorf("failed to encode events: %w", err)
+		}
+		encoded := string(data)
+		archivedJSON = &encoded
+	}
+	originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO issue_snapshots (issue_id, snapshot_time, compaction_level, original_size, compressed_size, original_content, archived_events)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, issue.ID, time.Now().UTC(), level, originalSize, compressedSize, string(content), archivedJSON)
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	if len(archived) > 0 {
+		placeholders := make([]string, len(archived))
+		args := make([]interface{}, len(archived))
+		for i, event := range archived {
+			placeholders[i] = "?"
+			args[i] = event.ID
+		}
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM events WHERE id IN (%s)`, strings.Join(placeholders, ",")), args...)
+		if err != nil {
+			return fmt.Errorf("failed to archive events: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetSnapshots returns the compaction snapshots of an issue, oldest first
+func (s *SQLiteStorage) GetSnapshots(ctx context.Context, issueID string) ([]*Snapshot, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, snapshot_time, compaction_level, original_size, compressed_size, original_content, archived_events
+		FROM issue_snapshots
+		WHERE issue_id = ?
+		ORDER BY compaction_level, id
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*Snapshot
+	for rows.Next() {
+		var snap Snapshot
+		var content string
+		var archived sql.NullString
+		if err := rows.Scan(&snap.ID, &snap.IssueID, &snap.SnapshotTime, &snap.CompactionLevel,
+			&snap.OriginalSize, &snap.CompressedSize, &content, &archived); err != nil {
+			return nil, fmt.Errorf("failed to scan snapshot: %w", err)
+		}
+		if err := json.Unmarshal([]byte(content), &snap.Issue); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot %d: %w", snap.ID, err)
+		}
+		if archived.Valid {
+			if err := json.Unmarshal([]byte(archived.String), &snap.ArchivedEvents); err != nil {
+				return nil, fmt.Errorf("failed to decode archived events of snapshot %d: %w", snap.ID, err)
+			}
+		}
+		snapshots = append(snapshots, &snap)
+	}
+	return snapshots, rows.Err()
+}
+
+// GetCompactedIssueIDs returns the IDs of all compacted issues in ID order
+func (s *SQLiteStorage) GetCompactedIssueIDs(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM issues
+		WHERE COALESCE(compaction_level, 0) > 0
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compacted issues: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan issue id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// CompactionTierStats summarizes the compaction passes made at one tier
+type CompactionTierStats struct {
+	Issues         int `json:"issues"`
+	OriginalSize   int `json:"original_size"`
+	CompressedSize int `json:"compressed_size"`
+	ArchivedEvents int `json:"archived_events"`
+}
+
+// GetCompactionStats returns per-tier totals of the snapshots taken during compaction
+func (s *SQLiteStorage) GetCompactionStats(ctx context.Context) (map[int]*CompactionTierStats, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT compaction_level, COUNT(DISTINCT issue_id), SUM(original_size), SUM(compressed_size),
+		       SUM(CASE WHEN archived_events IS NULL THEN 0 ELSE json_array_length(archived_events) END)
+		FROM issue_snapshots
+		GROUP BY compaction_level
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compaction stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make(map[int]*CompactionTierStats)
+	for rows.Next() {
+		var level int
+		var tier CompactionTierStats
+		if err := rows.Scan(&level, &tier.Issues, &tier.OriginalSize, &tier.CompressedSize, &tier.ArchivedEvents); err != nil {
+			return nil, fmt.Errorf("failed to scan compaction stats: %w", err)
+		}
+		stats[level] = &tier
+	}
+	return stats, rows.Err()
+}
+
+// compactionUsagePrefix starts the metadata keys holding per-model compaction usage
+const compactionUsagePrefix = "compact_usage:"
+
+// CompactionUsage totals the summarizer calls made with one model and what they saved
+type CompactionUsage struct {
+	Calls        int `json:"calls"`
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+	Issues       int `json:"issues"`      // Issues compacted; calls for skipped issues cost tokens too
+	BytesSaved   int `json:"bytes_saved"` // Bytes saved by the compacted issues
+}
+
+// RecordCompactionUsage adds usage to the totals kept in metadata for model.
+// The update is a single statement so concurrent compactions don't lose counts.
+func (s *SQLiteStorage) RecordCompactionUsage(ctx context.Context, model string, usage CompactionUsage) error {
+	value, err := json.Marshal(usage)
+	if err != nil {
+		return fmt.Errorf("failed to encode usage: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO metadata (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = json_object(
+			'calls', COALESCE(json_extract(value, '$.calls'), 0) + json_extract(excluded.value, '$.calls'),
+			'input_tokens', COALESCE(json_extract(value, '$.input_tokens'), 0) + json_extract(excluded.value, '$.input_tokens'),
+			'output_tokens', COALESCE(json_extract(value, '$.output_tokens'), 0) + json_extract(excluded.value, '$.output_tokens'),
+			'issues', COALESCE(json_extract(value, '$.issues'), 0) + json_extract(excluded.value, '$.issues'),
+			'bytes_saved', COALESCE(json_extract(value, '$.bytes_saved'), 0) + json_extract(excluded.value, '$.bytes_saved')
+		)
+	`, compactionUsagePrefix+model, string(value))
+	if err != nil {
+		return fmt.Errorf("failed to record compaction usage: %w", err)
+	}
+	return nil
+}
+
+// GetCompactionUsage returns the recorded compaction usage by model
+func (s *SQLiteStorage) GetCompactionUsage(ctx context.Context) (map[string]*CompactionUsage, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM metadata WHERE substr(key, 1, length(?1)) = ?1 ORDER BY key`, compactionUsagePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compaction usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := make(map[string]*CompactionUsage)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan compaction usage: %w", err)
+		}
+		var u CompactionUsage
+		if err := json.Unmarshal([]byte(value), &u); err != nil {
+			return nil, fmt.Errorf("invalid compaction usage for %s: %w", key, err)
+		}
+		usage[strings.TrimPrefix(key, compactionUsagePrefix)] = &u
+	}
+	return usage, rows.Err()
+}
+
+// compactionRestoredPrefix keys the metadata marking issues restored from
+// compaction, which automatic compaction leaves alone
+const compactionRestoredPrefix = "compact_restored:"
+
+// GetRestoredIssueIDs returns the issues restored from compaction and not compacted since
+func (s *SQLiteStorage) GetRestoredIssueIDs(ctx context.Context) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT key FROM metadata WHERE key LIKE ?`, compactionRestoredPrefix+"%")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restored issues: %w", err)
+	}
+	defer rows.Close()
+
+	restored := make(map[string]bool)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan restored issue: %w", err)
+		}
+		restored[strings.TrimPrefix(key, compactionRestoredPrefix)] = true
+	}
+	return restored, rows.Err()
+}
+
+// RestoreCompaction undoes the compaction of an issue. The text fields are set back
+// to original's, archived events return to the audit trail with their original times,
+// the compaction metadata is cleared and the now redundant snapshots are removed.
+func (s *SQLiteStorage) RestoreCompaction(ctx context.Context, original *types.Issue, archived []*types.Event, actor string) error {
+	events := make([]*types.Event, len(archived))
+	copy(events, archived)
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		}
+		return events[i].ID < events[j].ID
+	})
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE issues
+		SET description = ?, design = ?, notes = ?, acceptance_criteria = ?,
+		    compaction_level = 0, compacted_at = NULL, compacted_at_commit = NULL, original_size = NULL,
+		    updated_at = ?
+		WHERE id = ?
+	`, original.Description, original.Design, original.Notes, original.AcceptanceCriteria, now, original.ID)
+	if err != nil {
+		return fmt.Errorf("failed to restore issue: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("issue %s not found", original.ID)
+	}
+
+	for _, event := range events {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, original.ID, event.EventType, event.Actor, event.OldValue, event.NewValue, event.Comment, event.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore event: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM issue_snapshots WHERE issue_id = ?`, original.ID); err != nil {
+		return fmt.Errorf("failed to remove snapshots: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO metadata (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value
+	`, compactionRestoredPrefix+original.ID, now.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to mark issue restored: %w", err)
+	}
+
+	comment := "Restored from compaction"
+	if len(events) > 0 {
+		comment += fmt.Sprintf(" with %d archived events", len(events))
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, original.ID, types.EventCommented, actor, comment)
+	if err != nil {
+		return fmt.Errorf("failed to record restore: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, original.ID, now)
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"sync"
 	"testing"
 	"time"
 
@@ -364,6 +365,173 @@ func TestApplyCompaction(t *testing.T) {
 	}
 }
 
+func TestSaveSnapshot(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		ID:          "bd-1",
+		Title:       "Test",
+		Description: "Tier 1 summary of the original issue",
+		Status:      "closed",
+		Priority:    2,
+		IssueType:   "task",
+		ClosedAt:    timePtr(time.Now()),
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "alice", "Root cause was a stale cache"); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+
+	if err := store.SaveSnapshot(ctx, issue, 1, 20, nil); err != nil {
+		t.Fatalf("SaveSnapshot tier 1 failed: %v", err)
+	}
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected tier 1 snapshot to keep 2 events, got %d", len(events))
+	}
+
+	if err := store.SaveSnapshot(ctx, issue, 2, 10, events); err != nil {
+		t.Fatalf("SaveSnapshot tier 2 failed: %v", err)
+	}
+	remaining, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("Expected archived events to be removed, got %d", len(remaining))
+	}
+
+	snapshots, err := store.GetSnapshots(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetSnapshots failed: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+	}
+	tier2 := snapshots[1]
+	if tier2.CompactionLevel != 2 || tier2.Issue.Description != issue.Description || tier2.OriginalSize != len(issue.Description) {
+		t.Errorf("Unexpected tier 2 snapshot: %+v", tier2)
+	}
+	var archivedComment bool
+	for _, e := range tier2.ArchivedEvents {
+		archivedComment = archivedComment || (e.Comment != nil && *e.Comment == "Root cause was a stale cache")
+	}
+	if len(tier2.ArchivedEvents) != 2 || !archivedComment {
+		t.Errorf("Expected the comment among 2 archived events, got %d", len(tier2.ArchivedEvents))
+	}
+
+	stats, err := store.GetCompactionStats(ctx)
+	if err != nil {
+		t.Fatalf("GetCompactionStats failed: %v", err)
+	}
+	if stats[1] == nil || stats[1].Issues != 1 || stats[1].CompressedSize != 20 || stats[1].ArchivedEvents != 0 {
+		t.Errorf("Unexpected tier 1 stats: %+v", stats[1])
+	}
+	if stats[2] == nil || stats[2].Issues != 1 || stats[2].CompressedSize != 10 || stats[2].ArchivedEvents != 2 {
+		t.Errorf("Unexpected tier 2 stats: %+v", stats[2])
+	}
+}
+
+func TestRecordCompactionUsage(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := store.RecordCompactionUsage(ctx, "claude-3-5-haiku-20241022", CompactionUsage{Calls: 1, InputTokens: 100, OutputTokens: 20, Issues: 1, BytesSaved: 300}); err != nil {
+				t.Errorf("RecordCompactionUsage failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := store.RecordCompactionUsage(ctx, "llama3", CompactionUsage{Calls: 2, InputTokens: 50, OutputTokens: 10}); err != nil {
+		t.Fatalf("RecordCompactionUsage failed: %v", err)
+	}
+
+	usage, err := store.GetCompactionUsage(ctx)
+	if err != nil {
+		t.Fatalf("GetCompactionUsage failed: %v", err)
+	}
+	want := CompactionUsage{Calls: 10, InputTokens: 1000, OutputTokens: 200, Issues: 10, BytesSaved: 3000}
+	if len(usage) != 2 || *usage["claude-3-5-haiku-20241022"] != want {
+		t.Errorf("expected concurrent records to add up to %+v, got %+v", want, usage["claude-3-5-haiku-20241022"])
+	}
+	if got := *usage["llama3"]; got != (CompactionUsage{Calls: 2, InputTokens: 50, OutputTokens: 10}) {
+		t.Errorf("unexpected llama3 usage %+v", got)
+	}
+}
+
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestCompactIssueIsAtomic(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		ID:          "bd-1",
+		Title:       "Test",
+		Description: "The original, much longer description of the issue",
+		Status:      "closed",
+		Priority:    2,
+		IssueType:   "task",
+		ClosedAt:    timePtr(time.Now()),
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+
+	// Fail the last step, setting the compaction level
+	if _, err := store.db.Exec(`CREATE TRIGGER fail_compaction BEFORE UPDATE OF compaction_level ON issues
+		BEGIN SELECT RAISE(ABORT, 'compaction failed'); END`); err != nil {
+		t.Fatalf("Failed to create trigger: %v", err)
+	}
+	if err := store.CompactIssue(ctx, issue, 2, "Summary", events, "", "compactor"); err == nil {
+		t.Fatal("Expected CompactIssue to fail")
+	}
+
+	after, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if after.Description != issue.Description || after.CompactionLevel != 0 {
+		t.Errorf("Expected the issue to be untouched, got level %d and %q", after.CompactionLevel, after.Description)
+	}
+	if remaining, _ := store.GetEvents(ctx, issue.ID, 0); len(remaining) != len(events) {
+		t.Errorf("Expected %d events to remain, got %d", len(events), len(remaining))
+	}
+	if snapshots, _ := store.GetSnapshots(ctx, issue.ID); len(snapshots) != 0 {
+		t.Errorf("Expected no snapshot, got %d", len(snapshots))
+	}
+
+	if _, err := store.db.Exec(`DROP TRIGGER fail_compaction`); err != nil {
+		t.Fatalf("Failed to drop trigger: %v", err)
+	}
+	if err := store.CompactIssue(ctx, issue, 2, "Summary", events, "", "compactor"); err != nil {
+		t.Fatalf("CompactIssue failed: %v", err)
+	}
+	after, _ = store.GetIssue(ctx, issue.ID)
+	if after.Description != "Summary" || after.CompactionLevel != 2 {
+		t.Errorf("Expected the issue compacted to level 2, got level %d and %q", after.CompactionLevel, after.Description)
+	}
+	if snapshots, _ := store.GetSnapshots(ctx, issue.ID); len(snapshots) != 1 || len(snapshots[0].ArchivedEvents) != len(events) {
+		t.Errorf("Expected one snapshot holding the archived events, got %+v", snapshots)
+	}
+}
@@ -4,7 +4,9 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -91,39 +93,8 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 	//
 	// The traversal is depth-limited to maxDependencyDepth (100) to prevent infinite loops
 	// and excessive query cost. We check before inserting to avoid unnecessary write on failure.
-	var cycleExists bool
-	err = tx.QueryRowContext(ctx, `
-		WITH RECURSIVE paths AS (
-			SELECT
-				issue_id,
-				depends_on_id,
-				1 as depth
-			FROM dependencies
-			WHERE issue_id = ?
-
-			UNION ALL
-
-			SELECT
-				d.issue_id,
-				d.depends_on_id,
-				p.depth + 1
-			FROM dependencies d
-			JOIN paths p ON d.issue_id = p.depends_on_id
-			WHERE p.depth < ?
-		)
-		SELECT EXISTS(
-			SELECT 1 FROM paths
-			WHERE depends_on_id = ?
-		)
-	`, dep.DependsOnID, maxDependencyDepth, dep.IssueID).Scan(&cycleExists)
-
-	if err != nil {
-		return fmt.Errorf("failed to check for cycles: %w", err)
-	}
-
-	if cycleExists {
-		return fmt.Errorf("cannot add dependency: would create a cycle (%s → %s → ... → %s)",
-			dep.IssueID, dep.DependsOnID, dep.IssueID)
+	if err := checkDependencyCycleTx(ctx, tx, dep); err != nil {
+		return err
 	}
 
 	// Insert dependency
@@ -136,13 +107,8 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 	}
 
 	// Record event
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, dep.IssueID, types.EventDependencyAdded, actor,
-		fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID))
-	if err != nil {
-		return fmt.Errorf("failed to record event: %w", err)
+	if err := recordDependencyEventTx(ctx, tx, types.EventDependencyAdded, dep, actor); err != nil {
+		return err
 	}
 
 	// Mark both issues as dirty for incremental export
@@ -201,8 +167,37 @@ func (s *SQLiteStorage) addDependencyUnchecked(ctx context.Context, dep *types.D
 	defer tx.Rollback()
 
 	// Cycle detection (same as AddDependency)
+	if err := checkDependencyCycleTx(ctx, tx, dep); err != nil {
+		return err
+	}
+
+	// Insert dependency
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+		VALUES (?, ?, ?, ?, ?)
+	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	// Record event
+	if err := recordDependencyEventTx(ctx, tx, types.EventDependencyAdded, dep, actor); err != nil {
+		return err
+	}
+
+	// Mark both issues as dirty
+	if err := markIssuesDirtyTx(ctx, tx, []string{dep.IssueID, dep.DependsOnID}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// checkDependencyCycleTx returns an error if adding dep would create a cycle.
+// Cycles are rejected across all dependency types; see AddDependency for rationale.
+func checkDependencyCycleTx(ctx context.Context, tx queryRower, dep *types.Dependency) error {
 	var cycleExists bool
-	err = tx.QueryRowContext(ctx, `
+	err := tx.QueryRowContext(ctx, `
 		WITH RECURSIVE paths AS (
 			SELECT
 				issue_id,
@@ -236,31 +231,41 @@ func (s *SQLiteStorage) addDependencyUnchecked(ctx context.Context, dep *types.D
 			dep.IssueID, dep.DependsOnID, dep.IssueID)
 	}
 
-	// Insert dependency
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
-		VALUES (?, ?, ?, ?, ?)
-	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+	return nil
+}
+
+// recordDependencyEventTx records a dependency_added or dependency_removed event.
+// The dependency record is stored as JSON (new_value for additions, old_value for
+// removals) so the change can be reversed by bd undo.
+func recordDependencyEventTx(ctx context.Context, tx execer, eventType types.EventType, dep *types.Dependency, actor string) error {
+	depData, err := json.Marshal(map[string]string{
+		"issue_id":      dep.IssueID,
+		"depends_on_id": dep.DependsOnID,
+		"type":          string(dep.Type),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to add dependency: %w", err)
+		return fmt.Errorf("failed to encode dependency: %w", err)
+	}
+	depDataStr := string(depData)
+
+	var comment string
+	var oldValue, newValue *string
+	if eventType == types.EventDependencyAdded {
+		comment = fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID)
+		newValue = &depDataStr
+	} else {
+		comment = fmt.Sprintf("Removed dependency on %s", dep.DependsOnID)
+		oldValue = &depDataStr
 	}
 
-	// Record event
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, dep.IssueID, types.EventDependencyAdded, actor,
-		fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID))
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, dep.IssueID, eventType, actor, oldValue, newValue, comment)
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
-
-	// Mark both issues as dirty
-	if err := markIssuesDirtyTx(ctx, tx, []string{dep.IssueID, dep.DependsOnID}); err != nil {
-		return err
-	}
-
-	return tx.Commit()
+	return nil
 }
 
 // RemoveDependency removes a dependency
@@ -271,6 +276,15 @@ func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 	}
 	defer tx.Rollback()
 
+	// Capture the dependency type so the removal can be undone later
+	var depType types.DependencyType
+	err = tx.QueryRowContext(ctx, `
+		SELECT type FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
+	`, issueID, dependsOnID).Scan(&depType)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to look up dependency: %w", err)
+	}
+
 	result, err := tx.ExecContext(ctx, `
 		DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
 	`, issueID, dependsOnID)
@@ -287,13 +301,9 @@ func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 		return fmt.Errorf("dependency from %s to %s does not exist", issueID, dependsOnID)
 	}
 
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, issueID, types.EventDependencyRemoved, actor,
-		fmt.Sprintf("Removed dependency on %s", dependsOnID))
-	if err != nil {
-		return fmt.Errorf("failed to record event: %w", err)
+	removed := &types.Dependency{IssueID: issueID, DependsOnID: dependsOnID, Type: depType}
+	if err := recordDependencyEventTx(ctx, tx, types.EventDependencyRemoved, removed, actor); err != nil {
+		return err
 	}
 
 	// Mark both issues as dirty for incremental export
@@ -313,6 +323,15 @@ func (s *SQLiteStorage) removeDependencyIfExists(ctx context.Context, issueID, d
 	}
 	defer tx.Rollback()
 
+	// Capture the dependency type so the removal can be undone later
+	var depType types.DependencyType
+	err = tx.QueryRowContext(ctx, `
+		SELECT type FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
+	`, issueID, dependsOnID).Scan(&depType)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to look up dependency: %w", err)
+	}
+
 	result, err := tx.ExecContext(ctx, `
 		DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
 	`, issueID, dependsOnID)
@@ -330,13 +349,9 @@ func (s *SQLiteStorage) removeDependencyIfExists(ctx context.Context, issueID, d
 		return nil
 	}
 
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, issueID, types.EventDependencyRemoved, actor,
-		fmt.Sprintf("Removed dependency on %s", dependsOnID))
-	if err != nil {
-		return fmt.Errorf("failed to record event: %w", err)
+	removed := &types.Dependency{IssueID: issueID, DependsOnID: dependsOnID, Type: depType}
+	if err := recordDependencyEventTx(ctx, tx, types.EventDependencyRemoved, removed, actor); err != nil {
+		return err
 	}
 
 	// Mark both issues as dirty for incremental export
@@ -352,7 +367,8 @@ func (s *SQLiteStorage) GetDependencies(ctx context.Context, issueID string) ([]
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref,
+		       i.due_at, i.defer_until, i.recurrence
 		FROM issues i
 		JOIN dependencies d ON i.id = d.depends_on_id
 		WHERE d.issue_id = ?
@@ -371,7 +387,8 @@ func (s *SQLiteStorage) GetDependents(ctx context.Context, issueID string) ([]*t
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref,
+		       i.due_at, i.defer_until, i.recurrence
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		WHERE d.depends_on_id = ?
@@ -466,7 +483,7 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 				i.id, i.title, i.status, i.priority, i.description, i.design,
 				i.acceptance_criteria, i.notes, i.issue_type, i.assignee,
 				i.estimated_minutes, i.created_at, i.updated_at, i.closed_at,
-				i.external_ref,
+				i.external_ref, i.due_at, i.defer_until, i.recurrence,
 				0 as depth,
 				i.id as path,
 				i.id as parent_id
@@ -479,7 +496,7 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 				i.id, i.title, i.status, i.priority, i.description, i.design,
 				i.acceptance_criteria, i.notes, i.issue_type, i.assignee,
 				i.estimated_minutes, i.created_at, i.updated_at, i.closed_at,
-				i.external_ref,
+				i.external_ref, i.due_at, i.defer_until, i.recurrence,
 				t.depth + 1,
 				t.path || '→' || i.id,
 				t.id
@@ -492,7 +509,7 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 		SELECT id, title, status, priority, description, design,
 		       acceptance_criteria, notes, issue_type, assignee,
 		       estimated_minutes, created_at, updated_at, closed_at,
-		       external_ref, depth, parent_id
+		       external_ref, due_at, defer_until, recurrence, depth, parent_id
 		FROM tree
 		ORDER BY depth, priority, id
 	`, issueID, maxDepth)
@@ -512,6 +529,7 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 		var estimatedMinutes sql.NullInt64
 		var assignee sql.NullString
 		var externalRef sql.NullString
+		var dueAt, deferUntil sql.NullTime
 		var parentID string // Currently unused, but available for future parent relationship display
 
 		err := rows.Scan(
@@ -519,7 +537,7 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 			&node.Description, &node.Design, &node.AcceptanceCriteria,
 			&node.Notes, &node.IssueType, &assignee, &estimatedMinutes,
 			&node.CreatedAt, &node.UpdatedAt, &closedAt, &externalRef,
-			&node.Depth, &parentID,
+			&dueAt, &deferUntil, &node.Recurrence, &node.Depth, &parentID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan tree node: %w", err)
@@ -539,6 +557,12 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 		if externalRef.Valid {
 			node.ExternalRef = &externalRef.String
 		}
+		if dueAt.Valid {
+			node.DueAt = &dueAt.Time
+		}
+		if deferUntil.Valid {
+			node.DeferUntil = &deferUntil.Time
+		}
 
 		node.Truncated = node.Depth == maxDepth
 
@@ -559,6 +583,97 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 	return nodes, nil
 }
 
+// GetDescendants returns all issues below issueID in the parent-child hierarchy
+// (children, grandchildren, ...). This walks dependencies in the opposite direction
+// from GetDependencyTree: children depend on their parent, so descendants are found
+// by following parent-child dependents. Each issue appears once at its shallowest depth.
+func (s *SQLiteStorage) GetDescendants(ctx context.Context, issueID string, maxDepth int) ([]*types.TreeNode, error) {
+	if maxDepth <= 0 {
+		maxDepth = 50
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		WITH RECURSIVE tree AS (
+			SELECT issue_id AS id, 1 AS depth
+			FROM dependencies
+			WHERE depends_on_id = ? AND type = 'parent-child'
+
+			UNION
+
+			SELECT d.issue_id, t.depth + 1
+			FROM dependencies d
+			JOIN tree t ON d.depends_on_id = t.id
+			WHERE d.type = 'parent-child'
+			  AND t.depth < ?
+		)
+		SELECT id, MIN(depth) FROM tree GROUP BY id
+	`, issueID, maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get descendants: %w", err)
+	}
+
+	depths := make(map[string]int)
+	var ids []interface{}
+	var placeholders []string
+	for rows.Next() {
+		var id string
+		var depth int
+		if err := rows.Scan(&id, &depth); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan descendant: %w", err)
+		}
+		depths[id] = depth
+		ids = append(ids, id)
+		placeholders = append(placeholders, "?")
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get descendants: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	issueRows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref,
+		       due_at, defer_until, recurrence
+		FROM issues
+		WHERE id IN (%s)
+	`, strings.Join(placeholders, ", ")), ids...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get descendants: %w", err)
+	}
+	defer issueRows.Close()
+
+	issues, err := scanIssues(issueRows)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*types.TreeNode, 0, len(issues))
+	for _, issue := range issues {
+		depth := depths[issue.ID]
+		nodes = append(nodes, &types.TreeNode{
+			Issue:     *issue,
+			Depth:     depth,
+			Truncated: depth == maxDepth,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Depth != nodes[j].Depth {
+			return nodes[i].Depth < nodes[j].Depth
+		}
+		if nodes[i].Priority != nodes[j].Priority {
+			return nodes[i].Priority < nodes[j].Priority
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	return nodes, nil
+}
+
 // DetectCycles finds circular dependencies and returns the actual cycle paths
 func (s *SQLiteStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
 	// Use recursive CTE to find cycles with full paths
@@ -648,12 +763,14 @@ func scanIssues(rows *sql.Rows) ([]*types.Issue, error) {
 		var estimatedMinutes sql.NullInt64
 		var assignee sql.NullString
 		var externalRef sql.NullString
+		var dueAt, deferUntil sql.NullTime
 
 		err := rows.Scan(
 			&issue.ID, &issue.Title, &issue.Description, &issue.Design,
 			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
+			&dueAt, &deferUntil, &issue.Recurrence,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue: %w", err)
@@ -672,6 +789,12 @@ func scanIssues(rows *sql.Rows) ([]*types.Issue, error) {
 		if externalRef.Valid {
 			issue.ExternalRef = &externalRef.String
 		}
+		if dueAt.Valid {
+			issue.DueAt = &dueAt.Time
+		}
+		if deferUntil.Valid {
+			issue.DeferUntil = &deferUntil.Time
+		}
 
 		issues = append(issues, &issue)
 	}
@@ -234,6 +234,53 @@ func TestGetDependencyTree(t *testing.T) {
 	}
 }
 
+func TestGetDescendants(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// epic ← feature ← task, plus an unrelated blocker of the epic
+	epic := &types.Issue{Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	feature := &types.Issue{Title: "Feature", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeFeature}
+	task := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask}
+
+	for _, issue := range []*types.Issue{epic, feature, task, blocker} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	store.AddDependency(ctx, &types.Dependency{IssueID: feature.ID, DependsOnID: epic.ID, Type: types.DepParentChild}, "test-user")
+	store.AddDependency(ctx, &types.Dependency{IssueID: task.ID, DependsOnID: feature.ID, Type: types.DepParentChild}, "test-user")
+	store.AddDependency(ctx, &types.Dependency{IssueID: epic.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test-user")
+
+	descendants, err := store.GetDescendants(ctx, epic.ID, 0)
+	if err != nil {
+		t.Fatalf("GetDescendants failed: %v", err)
+	}
+
+	if len(descendants) != 2 {
+		t.Fatalf("Expected 2 descendants, got %d", len(descendants))
+	}
+	if descendants[0].ID != feature.ID || descendants[0].Depth != 1 {
+		t.Errorf("Expected %s at depth 1, got %s at depth %d", feature.ID, descendants[0].ID, descendants[0].Depth)
+	}
+	if descendants[1].ID != task.ID || descendants[1].Depth != 2 {
+		t.Errorf("Expected %s at depth 2, got %s at depth %d", task.ID, descendants[1].ID, descendants[1].Depth)
+	}
+
+	// maxDepth limits how far the walk goes
+	descendants, err = store.GetDescendants(ctx, epic.ID, 1)
+	if err != nil {
+		t.Fatalf("GetDescendants failed: %v", err)
+	}
+	if len(descendants) != 1 {
+		t.Errorf("Expected 1 descendant with maxDepth 1, got %d", len(descendants))
+	}
+}
+
 func TestDetectCycles(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -0,0 +1,140 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetEpicStatus rolls up progress across all parent-child descendants of an epic:
+// counts by status, completion by count and by estimated minutes, which unclosed
+// children are ready (open, unblocked, not deferred) or blocked, and the newest
+// event on the epic or its descendants.
+func (s *SQLiteStorage) GetEpicStatus(ctx context.Context, epicID string) (*types.EpicStatus, error) {
+	descendants, err := s.GetDescendants(ctx, epicID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &types.EpicStatus{
+		EpicID:       epicID,
+		StatusCounts: make(map[types.Status]int),
+		Ready:        []string{},
+		Blocked:      []string{},
+	}
+	if len(descendants) == 0 {
+		return status, s.loadLastActivity(ctx, status, []string{epicID})
+	}
+
+	ids := make([]string, 0, len(descendants)+1)
+	ids = append(ids, epicID)
+	for _, node := range descendants {
+		ids = append(ids, node.ID)
+	}
+
+	blocked, err := s.blockedAmong(ctx, ids[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	closed := 0
+	now := time.Now()
+	for _, node := range descendants {
+		status.TotalChildren++
+		status.StatusCounts[node.Status]++
+
+		if node.Status == types.StatusClosed {
+			closed++
+		}
+		if node.EstimatedMinutes != nil {
+			status.EstimatedMinutes += *node.EstimatedMinutes
+			if node.Status == types.StatusClosed {
+				status.ClosedEstimatedMinutes += *node.EstimatedMinutes
+			}
+		} else {
+			status.UnestimatedChildren++
+		}
+
+		switch {
+		case node.Status == types.StatusClosed:
+		case blocked[node.ID] || node.Status == types.StatusBlocked:
+			status.Blocked = append(status.Blocked, node.ID)
+		case node.Status == types.StatusOpen && (node.DeferUntil == nil || !node.DeferUntil.After(now)):
+			status.Ready = append(status.Ready, node.ID)
+		}
+	}
+
+	status.PercentComplete = float64(closed) / float64(status.TotalChildren) * 100
+	if status.EstimatedMinutes > 0 {
+		status.PercentCompleteByEstimate = float64(status.ClosedEstimatedMinutes) / float64(status.EstimatedMinutes) * 100
+	}
+
+	return status, s.loadLastActivity(ctx, status, ids)
+}
+
+// blockedAmong returns which of the given issues are blocked, directly or through
+// a blocked ancestor, using the same rules as GetReadyWork.
+func (s *SQLiteStorage) blockedAmong(ctx context.Context, ids []string) (map[string]bool, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		WITH RECURSIVE %s
+		SELECT DISTINCT issue_id FROM blocked_transitively
+		WHERE issue_id IN (%s)
+	`, blockedTransitivelyCTE, strings.Join(placeholders, ","))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked issues: %w", err)
+	}
+	defer rows.Close()
+
+	blocked := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan blocked issue: %w", err)
+		}
+		blocked[id] = true
+	}
+	return blocked, rows.Err()
+}
+
+// loadLastActivity sets the newest event recorded on any of the given issues
+func (s *SQLiteStorage) loadLastActivity(ctx context.Context, status *types.EpicStatus, ids []string) error {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE issue_id IN (%s)
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return fmt.Errorf("failed to get last activity: %w", err)
+	}
+	defer rows.Close()
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return err
+	}
+	if len(events) > 0 {
+		status.LastActivity = events[0]
+	}
+	return nil
+}
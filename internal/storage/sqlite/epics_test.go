@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGetEpicStatus(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	estimate := func(m int) *int { return &m }
+	epic := &types.Issue{Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	design := &types.Issue{Title: "Design", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, EstimatedMinutes: estimate(60)}
+	build := &types.Issue{Title: "Build", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeFeature, EstimatedMinutes: estimate(180)}
+	subtask := &types.Issue{Title: "Subtask", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	docs := &types.Issue{Title: "Docs", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeTask}
+
+	for _, issue := range []*types.Issue{epic, design, build, subtask, docs} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, child := range []*types.Issue{design, build, docs} {
+		store.AddDependency(ctx, &types.Dependency{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild}, "test-user")
+	}
+	store.AddDependency(ctx, &types.Dependency{IssueID: subtask.ID, DependsOnID: build.ID, Type: types.DepParentChild}, "test-user")
+	store.AddDependency(ctx, &types.Dependency{IssueID: build.ID, DependsOnID: design.ID, Type: types.DepBlocks}, "test-user")
+
+	status, err := store.GetEpicStatus(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("GetEpicStatus failed: %v", err)
+	}
+
+	if status.TotalChildren != 4 {
+		t.Errorf("Expected 4 children, got %d", status.TotalChildren)
+	}
+	if status.PercentComplete != 0 {
+		t.Errorf("Expected 0%% complete, got %.1f", status.PercentComplete)
+	}
+	if len(status.Ready) != 1 || status.Ready[0] != design.ID {
+		t.Errorf("Expected only %s ready, got %v", design.ID, status.Ready)
+	}
+	// subtask inherits the block on build
+	if len(status.Blocked) != 2 {
+		t.Errorf("Expected build and subtask blocked, got %v", status.Blocked)
+	}
+
+	if err := store.CloseIssue(ctx, design.ID, "done", "closer"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	status, err = store.GetEpicStatus(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("GetEpicStatus failed: %v", err)
+	}
+	if status.StatusCounts[types.StatusClosed] != 1 || status.StatusCounts[types.StatusInProgress] != 1 {
+		t.Errorf("Unexpected status counts: %v", status.StatusCounts)
+	}
+	if status.PercentComplete != 25 {
+		t.Errorf("Expected 25%% complete by count, got %.1f", status.PercentComplete)
+	}
+	if status.EstimatedMinutes != 240 || status.ClosedEstimatedMinutes != 60 || status.PercentCompleteByEstimate != 25 {
+		t.Errorf("Unexpected estimate rollup: %d/%d (%.1f%%)", status.ClosedEstimatedMinutes, status.EstimatedMinutes, status.PercentCompleteByEstimate)
+	}
+	if status.UnestimatedChildren != 2 {
+		t.Errorf("Expected 2 unestimated children, got %d", status.UnestimatedChildren)
+	}
+	if len(status.Blocked) != 0 || len(status.Ready) != 2 {
+		t.Errorf("Expected build and subtask ready after closing blocker, got ready=%v blocked=%v", status.Ready, status.Blocked)
+	}
+	if status.LastActivity == nil || status.LastActivity.IssueID != design.ID || status.LastActivity.EventType != types.EventClosed {
+		t.Errorf("Expected last activity to be the close of %s, got %+v", design.ID, status.LastActivity)
+	}
+}
+
+func TestGetEpicStatusNoChildren(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	epic := &types.Issue{Title: "Empty", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	if err := store.CreateIssue(ctx, epic, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	status, err := store.GetEpicStatus(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("GetEpicStatus failed: %v", err)
+	}
+	if status.TotalChildren != 0 || status.PercentComplete != 0 {
+		t.Errorf("Expected empty rollup, got %+v", status)
+	}
+	if status.LastActivity == nil || status.LastActivity.EventType != types.EventCreated {
+		t.Errorf("Expected creation as last activity, got %+v", status.LastActivity)
+	}
+}
@@ -3,7 +3,9 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -72,6 +74,126 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 	}
 	defer rows.Close()
 
+	return scanEvents(rows)
+}
+
+// SearchEvents returns audit trail events matching the filter, newest first
+func (s *SQLiteStorage) SearchEvents(ctx context.Context, filter types.EventFilter) ([]*types.Event, error) {
+	whereClauses := []string{}
+	args := []interface{}{}
+
+	if len(filter.IDs) > 0 {
+		placeholders := make([]string, len(filter.IDs))
+		for i, id := range filter.IDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("id IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if filter.IssueID != "" {
+		whereClauses = append(whereClauses, "issue_id = ?")
+		args = append(args, filter.IssueID)
+	}
+	if filter.Actor != "" {
+		whereClauses = append(whereClauses, "actor = ?")
+		args = append(args, filter.Actor)
+	}
+	if filter.Since != nil {
+		// created_at defaults to CURRENT_TIMESTAMP, which is UTC text; compare in that layout
+		whereClauses = append(whereClauses, "created_at >= ?")
+		args = append(args, filter.Since.UTC().Format("2006-01-02 15:04:05"))
+	}
+	if len(filter.Types) > 0 {
+		placeholders := make([]string, len(filter.Types))
+		for i, t := range filter.Types {
+			placeholders[i] = "?"
+			args = append(args, t)
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("event_type IN (%s)", strings.Join(placeholders, ", ")))
+	}
+
+	whereSQL := ""
+	if len(whereClauses) > 0 {
+		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = limitClause
+		args = append(args, filter.Limit)
+	}
+
+	// Order by id rather than created_at: ids are strictly increasing, while several
+	// events written in the same transaction can share a timestamp
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		%s
+		ORDER BY id DESC
+		%s
+	`, whereSQL, limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+// GetStatusHistory returns the status transitions of every issue, oldest first:
+// the status each issue was created with, then every change after that
+func (s *SQLiteStorage) GetStatusHistory(ctx context.Context) (map[string][]*types.StatusChange, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE event_type IN (?, ?, ?, ?)
+		ORDER BY id
+	`, types.EventCreated, types.EventStatusChanged, types.EventClosed, types.EventReopened)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status events: %w", err)
+	}
+	defer rows.Close()
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[string][]*types.StatusChange)
+	for _, event := range events {
+		if status, ok := eventStatus(event); ok {
+			history[event.IssueID] = append(history[event.IssueID], &types.StatusChange{
+				IssueID: event.IssueID,
+				Status:  status,
+				At:      event.CreatedAt,
+			})
+		}
+	}
+	return history, nil
+}
+
+// eventStatus returns the status an event set its issue to. Created events carry
+// the new issue and status changes the update map, both as JSON; close events
+// have no new value.
+func eventStatus(event *types.Event) (types.Status, bool) {
+	if event.EventType == types.EventClosed {
+		return types.StatusClosed, true
+	}
+	if event.NewValue == nil {
+		return "", false
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(*event.NewValue), &fields); err != nil {
+		return "", false
+	}
+	status, ok := fields["status"].(string)
+	return types.Status(status), ok && status != ""
+}
+
+// scanEvents scans event rows into Event structs
+func scanEvents(rows *sql.Rows) ([]*types.Event, error) {
 	var events []*types.Event
 	for rows.Next() {
 		var event types.Event
@@ -98,7 +220,7 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 		events = append(events, &event)
 	}
 
-	return events, nil
+	return events, rows.Err()
 }
 
 // GetStatistics returns aggregate statistics
@@ -167,3 +289,77 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 
 	return &stats, nil
 }
+
+// statisticsGroupKeys maps the groupings GetStatisticsBy supports to their SQL
+var statisticsGroupKeys = map[string]string{
+	"label":    "COALESCE(l.label, '')",
+	"assignee": "COALESCE(i.assignee, '')",
+	"type":     "i.issue_type",
+	"priority": "'P' || i.priority",
+}
+
+// GetStatisticsBy returns statistics grouped by label, assignee, type or priority in
+// one query. Issues appear once per label, and unlabeled issues under "". Closed
+// counts and lead times only include issues closed since closedSince, when given.
+func (s *SQLiteStorage) GetStatisticsBy(ctx context.Context, groupBy string, closedSince *time.Time) ([]*types.StatisticsGroup, error) {
+	keySQL, ok := statisticsGroupKeys[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("invalid grouping %q (must be label, assignee, type or priority)", groupBy)
+	}
+	joinSQL := ""
+	if groupBy == "label" {
+		joinSQL = "LEFT JOIN labels l ON l.issue_id = i.id"
+	}
+
+	closedSQL := "i.status = 'closed'"
+	var args []interface{}
+	if closedSince != nil {
+		closedSQL += " AND i.closed_at >= ?"
+		args = append(args, *closedSince, *closedSince)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS group_key,
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN i.status = 'open' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN i.status = 'in_progress' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN i.status IN ('open', 'in_progress', 'blocked') AND %s THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN i.status = 'open' AND NOT %s THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0),
+			AVG(CASE WHEN %s AND i.closed_at IS NOT NULL
+				THEN (julianday(i.closed_at) - julianday(i.created_at)) * 24 END)
+		FROM issues i
+		%s
+		GROUP BY group_key
+		ORDER BY group_key
+	`, keySQL, hasOpenBlockerSQL, hasOpenBlockerSQL, closedSQL, closedSQL, joinSQL)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grouped statistics: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []*types.StatisticsGroup{}
+	for rows.Next() {
+		var g types.StatisticsGroup
+		var leadTime sql.NullFloat64
+		if err := rows.Scan(&g.Key, &g.TotalIssues, &g.OpenIssues, &g.InProgressIssues,
+			&g.BlockedIssues, &g.ReadyIssues, &g.ClosedIssues, &leadTime); err != nil {
+			return nil, fmt.Errorf("failed to scan grouped statistics: %w", err)
+		}
+		if leadTime.Valid {
+			g.AverageLeadTime = leadTime.Float64
+		}
+		groups = append(groups, &g)
+	}
+	return groups, rows.Err()
+}
+
+// hasOpenBlockerSQL is true when issue i has a blocks dependency on an unclosed issue
+const hasOpenBlockerSQL = `EXISTS (
+				SELECT 1 FROM dependencies d
+				JOIN issues blocker ON d.depends_on_id = blocker.id
+				WHERE d.issue_id = i.id
+				  AND d.type = 'blocks'
+				  AND blocker.status IN ('open', 'in_progress', 'blocked'))`
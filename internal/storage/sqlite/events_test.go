@@ -337,3 +337,53 @@ func TestEventTypesInHistory(t *testing.T) {
 		t.Error("Expected EventClosed in history")
 	}
 }
+
+func TestGetStatusHistory(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Tracked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	other := &types.Issue{Title: "Untouched", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, other, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	steps := []map[string]interface{}{
+		{"status": string(types.StatusInProgress)},
+		{"priority": 0}, // Not a status change
+		{"status": string(types.StatusClosed)},
+		{"status": string(types.StatusOpen)},
+	}
+	for _, updates := range steps {
+		if err := store.UpdateIssue(ctx, issue.ID, updates, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	history, err := store.GetStatusHistory(ctx)
+	if err != nil {
+		t.Fatalf("GetStatusHistory failed: %v", err)
+	}
+
+	want := []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusClosed, types.StatusOpen, types.StatusClosed}
+	changes := history[issue.ID]
+	if len(changes) != len(want) {
+		t.Fatalf("Expected %d status changes, got %d", len(want), len(changes))
+	}
+	for i, change := range changes {
+		if change.Status != want[i] || change.IssueID != issue.ID || change.At.IsZero() {
+			t.Errorf("Change %d = %+v, want status %s", i, change, want[i])
+		}
+	}
+	if len(history[other.ID]) != 1 || history[other.ID][0].Status != types.StatusOpen {
+		t.Errorf("Expected only the creation status for %s, got %+v", other.ID, history[other.ID])
+	}
+}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -74,6 +75,46 @@ func (s *SQLiteStorage) RemoveLabel(ctx context.Context, issueID, label, actor s
 	)
 }
 
+// addLabelTx adds a label within an existing transaction and records a label_added
+// event. Returns false without recording anything if the issue already has the label.
+func addLabelTx(ctx context.Context, tx execer, issueID, label, actor string) (bool, error) {
+	result, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`, issueID, label)
+	if err != nil {
+		return false, fmt.Errorf("failed to add label %s to %s: %w", label, issueID, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, types.EventLabelAdded, actor, fmt.Sprintf("Added label: %s", label))
+	if err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+	return true, nil
+}
+
+// removeLabelTx removes a label within an existing transaction and records a label_removed
+// event. Returns false without recording anything if the issue didn't have the label.
+func removeLabelTx(ctx context.Context, tx *sql.Tx, issueID, label, actor string) (bool, error) {
+	result, err := tx.ExecContext(ctx, `DELETE FROM labels WHERE issue_id = ? AND label = ?`, issueID, label)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove label %s from %s: %w", label, issueID, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, types.EventLabelRemoved, actor, fmt.Sprintf("Removed label: %s", label))
+	if err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+	return true, nil
+}
+
 // GetLabels returns all labels for an issue
 func (s *SQLiteStorage) GetLabels(ctx context.Context, issueID string) ([]string, error) {
 	rows, err := s.db.QueryContext(ctx, `
@@ -101,7 +142,8 @@ func (s *SQLiteStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref,
+		       i.due_at, i.defer_until, i.recurrence
 		FROM issues i
 		JOIN labels l ON i.id = l.issue_id
 		WHERE l.label = ?
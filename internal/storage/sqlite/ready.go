@@ -5,10 +5,42 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
 
+// blockedTransitivelyCTE defines blocked_transitively: every issue that cannot be
+// worked on because of an open blocker. Use it after WITH RECURSIVE.
+// Algorithm:
+// 1. Find issues directly blocked by 'blocks' dependencies
+// 2. Recursively propagate blockage to all descendants via 'parent-child' links
+const blockedTransitivelyCTE = `
+		  -- Step 1: Find issues blocked directly by dependencies
+		  blocked_directly AS (
+		    SELECT DISTINCT d.issue_id
+		    FROM dependencies d
+		    JOIN issues blocker ON d.depends_on_id = blocker.id
+		    WHERE d.type = 'blocks'
+		      AND blocker.status IN ('open', 'in_progress', 'blocked')
+		  ),
+
+		  -- Step 2: Propagate blockage to all descendants via parent-child
+		  blocked_transitively AS (
+		    -- Base case: directly blocked issues
+		    SELECT issue_id, 0 as depth
+		    FROM blocked_directly
+
+		    UNION ALL
+
+		    -- Recursive case: children of blocked issues inherit blockage
+		    SELECT d.issue_id, bt.depth + 1
+		    FROM blocked_transitively bt
+		    JOIN dependencies d ON d.depends_on_id = bt.issue_id
+		    WHERE d.type = 'parent-child'
+		      AND bt.depth < 50
+		  )`
+
 // GetReadyWork returns issues with no open blockers
 func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
 	whereClauses := []string{}
@@ -32,9 +64,25 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		args = append(args, *filter.Assignee)
 	}
 
+	// Deferred issues stay hidden until their defer_until date (stored in UTC, see utcTime)
+	if !filter.IncludeDeferred {
+		whereClauses = append(whereClauses, "(i.defer_until IS NULL OR i.defer_until <= ?)")
+		args = append(args, time.Now().UTC())
+	}
+
+	if filter.DueBefore != nil {
+		whereClauses = append(whereClauses, "i.due_at < ?")
+		args = append(args, filter.DueBefore.UTC())
+	}
+
 	// Build WHERE clause properly
 	whereSQL := strings.Join(whereClauses, " AND ")
 
+	orderSQL := "i.priority ASC, i.created_at ASC"
+	if filter.SortByDue {
+		orderSQL = "i.due_at IS NULL, i.due_at ASC, i.priority ASC, i.created_at ASC"
+	}
+
 	// Build LIMIT clause using parameter
 	limitSQL := ""
 	if filter.Limit > 0 {
@@ -42,50 +90,23 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		args = append(args, filter.Limit)
 	}
 
-	// Query with recursive CTE to propagate blocking through parent-child hierarchy
-	// Algorithm:
-	// 1. Find issues directly blocked by 'blocks' dependencies
-	// 2. Recursively propagate blockage to all descendants via 'parent-child' links
-	// 3. Exclude all blocked issues (both direct and transitive) from ready work
+	// Exclude issues that are blocked directly or through a blocked ancestor
 	query := fmt.Sprintf(`
-		WITH RECURSIVE
-		  -- Step 1: Find issues blocked directly by dependencies
-		  blocked_directly AS (
-		    SELECT DISTINCT d.issue_id
-		    FROM dependencies d
-		    JOIN issues blocker ON d.depends_on_id = blocker.id
-		    WHERE d.type = 'blocks'
-		      AND blocker.status IN ('open', 'in_progress', 'blocked')
-		  ),
-
-		  -- Step 2: Propagate blockage to all descendants via parent-child
-		  blocked_transitively AS (
-		    -- Base case: directly blocked issues
-		    SELECT issue_id, 0 as depth
-		    FROM blocked_directly
-
-		    UNION ALL
-
-		    -- Recursive case: children of blocked issues inherit blockage
-		    SELECT d.issue_id, bt.depth + 1
-		    FROM blocked_transitively bt
-		    JOIN dependencies d ON d.depends_on_id = bt.issue_id
-		    WHERE d.type = 'parent-child'
-		      AND bt.depth < 50
-		  )
+		WITH RECURSIVE %s
 
 		-- Step 3: Select ready issues (excluding all blocked)
 		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref,
+		       i.due_at, i.defer_until, i.recurrence
 		FROM issues i
 		WHERE %s
 		  AND NOT EXISTS (
 		    SELECT 1 FROM blocked_transitively WHERE issue_id = i.id
 		  )
-		ORDER BY i.priority ASC, i.created_at ASC
+		ORDER BY %s
 		%s
-	`, whereSQL, limitSQL)
+	`, blockedTransitivelyCTE, whereSQL, orderSQL, limitSQL)
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -104,6 +125,7 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 		    i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		    i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
 		    i.created_at, i.updated_at, i.closed_at, i.external_ref,
+		    i.due_at, i.defer_until, i.recurrence,
 		    COUNT(d.depends_on_id) as blocked_by_count,
 		    GROUP_CONCAT(d.depends_on_id, ',') as blocker_ids
 		FROM issues i
@@ -127,14 +149,15 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 		var estimatedMinutes sql.NullInt64
 		var assignee sql.NullString
 		var externalRef sql.NullString
+		var dueAt, deferUntil sql.NullTime
 		var blockerIDsStr string
 
 		err := rows.Scan(
 			&issue.ID, &issue.Title, &issue.Description, &issue.Design,
 			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &issue.BlockedByCount,
-			&blockerIDsStr,
+			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
+			&dueAt, &deferUntil, &issue.Recurrence, &issue.BlockedByCount, &blockerIDsStr,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan blocked issue: %w", err)
@@ -153,6 +176,12 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 		if externalRef.Valid {
 			issue.ExternalRef = &externalRef.String
 		}
+		if dueAt.Valid {
+			issue.DueAt = &dueAt.Time
+		}
+		if deferUntil.Valid {
+			issue.DeferUntil = &deferUntil.Time
+		}
 
 		// Parse comma-separated blocker IDs
 		if blockerIDsStr != "" {
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -751,3 +752,102 @@ func TestDeepHierarchyBlocking(t *testing.T) {
 		}
 	}
 }
+
+func TestGetReadyWorkHidesDeferred(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	future := time.Now().Add(48 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+	deferred := &types.Issue{Title: "Deferred", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DeferUntil: &future}
+	resumed := &types.Issue{Title: "Resumed", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DeferUntil: &past}
+	plain := &types.Issue{Title: "Plain", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+
+	for _, issue := range []*types.Issue{deferred, resumed, plain} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 2 {
+		t.Fatalf("Expected 2 ready issues, got %d", len(ready))
+	}
+	for _, issue := range ready {
+		if issue.ID == deferred.ID {
+			t.Errorf("Deferred issue %s should not be ready", deferred.ID)
+		}
+	}
+
+	ready, err = store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, IncludeDeferred: true})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 3 {
+		t.Errorf("Expected 3 issues with IncludeDeferred, got %d", len(ready))
+	}
+
+	// Clearing the defer date makes the issue ready again
+	if err := store.UpdateIssue(ctx, deferred.ID, map[string]interface{}{"defer_until": nil}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	ready, _ = store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen})
+	if len(ready) != 3 {
+		t.Errorf("Expected 3 ready issues after clearing defer_until, got %d", len(ready))
+	}
+}
+
+func TestGetReadyWorkDueDates(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Due dates in a non-UTC zone must still compare correctly
+	zone := time.FixedZone("UTC+10", 10*60*60)
+	soon := time.Now().Add(24 * time.Hour).In(zone)
+	later := time.Now().Add(10 * 24 * time.Hour).In(zone)
+
+	undated := &types.Issue{Title: "Undated", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask}
+	dueLater := &types.Issue{Title: "Later", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DueAt: &later}
+	dueSoon := &types.Issue{Title: "Soon", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask, DueAt: &soon}
+
+	for _, issue := range []*types.Issue{undated, dueLater, dueSoon} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, SortByDue: true})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 3 || ready[0].ID != dueSoon.ID || ready[1].ID != dueLater.ID || ready[2].ID != undated.ID {
+		t.Errorf("Expected due order [%s %s %s], got %v", dueSoon.ID, dueLater.ID, undated.ID, issueIDs(ready))
+	}
+	if ready[0].DueAt == nil || !ready[0].DueAt.Equal(soon) {
+		t.Errorf("Expected due_at %v to round-trip, got %v", soon, ready[0].DueAt)
+	}
+
+	within := time.Now().Add(3 * 24 * time.Hour)
+	ready, err = store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, DueBefore: &within})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != dueSoon.ID {
+		t.Errorf("Expected only %s due within 3 days, got %v", dueSoon.ID, issueIDs(ready))
+	}
+}
+
+func issueIDs(issues []*types.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
@@ -0,0 +1,176 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/recur"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// NextOccurrence returns when the occurrence after this instance of a recurring
+// issue is due: the first time the rule yields after the instance's due date (or
+// now, if it has none) that is still in the future.
+func NextOccurrence(issue *types.Issue, now time.Time) (time.Time, error) {
+	sched, err := recur.Parse(issue.Recurrence)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	base := now
+	if issue.DueAt != nil {
+		base = *issue.DueAt
+	}
+	next := sched.Next(base.Local())
+	for !next.IsZero() && !next.After(now) {
+		next = sched.Next(next)
+	}
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("recurrence %q of %s has no further occurrences", issue.Recurrence, issue.ID)
+	}
+	return next, nil
+}
+
+// SpawnNextOccurrence creates the next instance of a recurring issue. The new issue
+// copies the title, description, fields and labels, is due at the next occurrence,
+// and links back to the previous instance with a related dependency. The recurrence
+// rule moves to the new instance, so each series spawns from its newest issue only.
+func (s *SQLiteStorage) SpawnNextOccurrence(ctx context.Context, issueID, actor string) (*types.Issue, error) {
+	return s.spawnOccurrence(ctx, issueID, time.Now(), actor)
+}
+
+// SpawnDueOccurrences spawns the next instance of every recurring issue that is
+// closed or whose due date has passed. Called periodically by the daemon.
+func (s *SQLiteStorage) SpawnDueOccurrences(ctx context.Context, now time.Time, actor string) ([]*types.Issue, error) {
+	// Dates are stored as UTC text, see utcTime
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM issues
+		WHERE recurrence != ''
+		  AND (status = 'closed' OR (due_at IS NOT NULL AND due_at <= ?))
+		ORDER BY id
+	`, now.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due recurring issues: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan issue ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var spawned []*types.Issue
+	for _, id := range ids {
+		issue, err := s.spawnOccurrence(ctx, id, now, actor)
+		if err != nil {
+			return spawned, err
+		}
+		if issue != nil {
+			spawned = append(spawned, issue)
+		}
+	}
+	return spawned, nil
+}
+
+// spawnOccurrence creates the next instance of a recurring issue in one transaction.
+// Returns nil if the issue no longer has a recurrence rule (e.g. another process
+// spawned it first).
+func (s *SQLiteStorage) spawnOccurrence(ctx context.Context, issueID string, now time.Time, actor string) (*types.Issue, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return nil, fmt.Errorf("failed to begin immediate transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}()
+
+	prev, err := getIssue(ctx, conn, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if prev == nil {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+	if prev.Recurrence == "" {
+		return nil, nil
+	}
+
+	due, err := NextOccurrence(prev, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []string
+	rows, err := conn.QueryContext(ctx, `SELECT label FROM labels WHERE issue_id = ? ORDER BY label`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	rows.Close()
+
+	next := &types.Issue{
+		Title:              prev.Title,
+		Description:        prev.Description,
+		Design:             prev.Design,
+		AcceptanceCriteria: prev.AcceptanceCriteria,
+		Status:             types.StatusOpen,
+		Priority:           prev.Priority,
+		IssueType:          prev.IssueType,
+		Assignee:           prev.Assignee,
+		EstimatedMinutes:   prev.EstimatedMinutes,
+		DueAt:              &due,
+		Recurrence:         prev.Recurrence,
+		Labels:             labels,
+		Dependencies: []*types.Dependency{
+			{DependsOnID: prev.ID, Type: types.DepRelated},
+		},
+	}
+	// Keep the same lead time between deferral and due date
+	if prev.DueAt != nil && prev.DeferUntil != nil {
+		deferUntil := due.Add(-prev.DueAt.Sub(*prev.DeferUntil))
+		next.DeferUntil = &deferUntil
+	}
+
+	if err := validateBatchIssues([]*types.Issue{next}); err != nil {
+		return nil, err
+	}
+	if err := createIssuesConn(ctx, conn, []*types.Issue{next}, actor); err != nil {
+		return nil, err
+	}
+
+	if err := updateIssueTx(ctx, conn, prev, map[string]interface{}{"recurrence": ""}, actor); err != nil {
+		return nil, err
+	}
+	if err := bulkMarkDirty(ctx, conn, []*types.Issue{prev}); err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return next, nil
+}
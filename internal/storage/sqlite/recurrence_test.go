@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSpawnNextOccurrence(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	due := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	issue := &types.Issue{
+		Title:       "Weekly triage",
+		Description: "Go through the new issues",
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeChore,
+		DueAt:       &due,
+		Recurrence:  "weekly",
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, issue.ID, "maintenance", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	next, err := store.SpawnNextOccurrence(ctx, issue.ID, "test-user")
+	if err != nil {
+		t.Fatalf("SpawnNextOccurrence failed: %v", err)
+	}
+	if next == nil {
+		t.Fatal("Expected a spawned issue")
+	}
+
+	got, err := store.GetIssue(ctx, next.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Title != issue.Title || got.Description != issue.Description || got.Status != types.StatusOpen {
+		t.Errorf("Spawned issue doesn't copy the original: %+v", got)
+	}
+	if got.Recurrence != "weekly" {
+		t.Errorf("Expected rule to move to spawned issue, got %q", got.Recurrence)
+	}
+	if got.DueAt == nil || !got.DueAt.Equal(due.AddDate(0, 0, 7)) {
+		t.Errorf("Expected due %v, got %v", due.AddDate(0, 0, 7), got.DueAt)
+	}
+
+	labels, err := store.GetLabels(ctx, next.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 1 || labels[0] != "maintenance" {
+		t.Errorf("Expected labels to be copied, got %v", labels)
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, next.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != issue.ID || deps[0].Type != types.DepRelated {
+		t.Errorf("Expected related dependency on %s, got %+v", issue.ID, deps)
+	}
+
+	prev, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if prev.Recurrence != "" {
+		t.Errorf("Expected rule cleared on previous instance, got %q", prev.Recurrence)
+	}
+
+	// The previous instance no longer recurs, so spawning again is a no-op
+	again, err := store.SpawnNextOccurrence(ctx, issue.ID, "test-user")
+	if err != nil {
+		t.Fatalf("SpawnNextOccurrence failed: %v", err)
+	}
+	if again != nil {
+		t.Errorf("Expected no second spawn, got %s", again.ID)
+	}
+}
+
+func TestSpawnDueOccurrences(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	past := time.Now().Add(-50 * time.Hour).Truncate(time.Second)
+	future := time.Now().Add(50 * time.Hour).Truncate(time.Second)
+	overdue := &types.Issue{Title: "Overdue chore", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeChore,
+		DueAt: &past, Recurrence: "daily"}
+	pending := &types.Issue{Title: "Pending chore", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeChore,
+		DueAt: &future, Recurrence: "daily"}
+	plain := &types.Issue{Title: "Plain overdue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		DueAt: &past}
+	for _, issue := range []*types.Issue{overdue, pending, plain} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	now := time.Now()
+	spawned, err := store.SpawnDueOccurrences(ctx, now, "daemon")
+	if err != nil {
+		t.Fatalf("SpawnDueOccurrences failed: %v", err)
+	}
+	if len(spawned) != 1 || spawned[0].Title != "Overdue chore" {
+		t.Fatalf("Expected only the overdue chore to spawn, got %+v", spawned)
+	}
+	// Missed occurrences are skipped: the next one is in the future
+	if spawned[0].DueAt == nil || !spawned[0].DueAt.After(now) {
+		t.Errorf("Expected next due date after now, got %v", spawned[0].DueAt)
+	}
+
+	// Nothing else is due now
+	spawned, err = store.SpawnDueOccurrences(ctx, now, "daemon")
+	if err != nil {
+		t.Fatalf("SpawnDueOccurrences failed: %v", err)
+	}
+	if len(spawned) != 0 {
+		t.Errorf("Expected no spawns on second pass, got %d", len(spawned))
+	}
+}
+
+func TestInvalidRecurrence(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Bad rule", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		Recurrence: "fortnightly"}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err == nil {
+		t.Error("Expected CreateIssue to reject invalid recurrence")
+	}
+
+	issue.Recurrence = ""
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"recurrence": "every 0d"}, "test-user"); err == nil {
+		t.Error("Expected UpdateIssue to reject invalid recurrence")
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"recurrence": "0 9 * * 1"}, "test-user"); err != nil {
+		t.Errorf("UpdateIssue with cron rule failed: %v", err)
+	}
+}
@@ -18,6 +18,9 @@ CREATE TABLE IF NOT EXISTS issues (
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     closed_at DATETIME,
     external_ref TEXT,
+    due_at DATETIME,
+    defer_until DATETIME,
+    recurrence TEXT NOT NULL DEFAULT '',
     compaction_level INTEGER DEFAULT 0,
     compacted_at DATETIME,
     compacted_at_commit TEXT,
@@ -72,6 +75,21 @@ CREATE TABLE IF NOT EXISTS events (
 CREATE INDEX IF NOT EXISTS idx_events_issue ON events(issue_id);
 CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
 
+-- Work log table (actual time spent, see 'bd time')
+CREATE TABLE IF NOT EXISTS work_logs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_id TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME,
+    minutes INTEGER NOT NULL DEFAULT 0 CHECK(minutes >= 0),
+    note TEXT NOT NULL DEFAULT '',
+    UNIQUE (issue_id, actor, started_at),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_work_logs_issue ON work_logs(issue_id);
+
 -- Config table (for storing settings like issue prefix)
 CREATE TABLE IF NOT EXISTS config (
     key TEXT PRIMARY KEY,
@@ -87,9 +105,12 @@ INSERT OR IGNORE INTO config (key, value) VALUES
     ('compact_tier2_dep_levels', '5'),
     ('compact_tier2_commits', '100'),
     ('compact_model', 'claude-3-5-haiku-20241022'),
+    ('compact_provider', 'anthropic'),
     ('compact_batch_size', '50'),
     ('compact_parallel_workers', '5'),
-    ('auto_compact_enabled', 'false');
+    ('auto_compact_enabled', 'false'),
+    ('auto_compact_interval', '24h'),
+    ('auto_compact_daily_limit', '200');
 
 -- Metadata table (for storing internal state like import hashes)
 CREATE TABLE IF NOT EXISTS metadata (
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	// Import SQLite driver
+	"github.com/steveyegge/beads/internal/recur"
 	"github.com/steveyegge/beads/internal/types"
 	_ "modernc.org/sqlite"
 )
@@ -92,6 +93,16 @@ func New(path string) (*SQLiteStorage, error) {
 		return nil, fmt.Errorf("failed to migrate compacted_at_commit column: %w", err)
 	}
 
+	// Migrate existing databases to add due_at/defer_until columns
+	if err := migrateScheduleColumns(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate schedule columns: %w", err)
+	}
+
+	// Migrate existing databases to add recurrence column
+	if err := migrateRecurrenceColumn(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate recurrence column: %w", err)
+	}
+
 	return &SQLiteStorage{
 		db: db,
 	}, nil
@@ -396,9 +407,12 @@ func migrateCompactionConfig(db *sql.DB) error {
 			('compact_tier2_dep_levels', '5'),
 			('compact_tier2_commits', '100'),
 			('compact_model', 'claude-3-5-haiku-20241022'),
+			('compact_provider', 'anthropic'),
 			('compact_batch_size', '50'),
 			('compact_parallel_workers', '5'),
-			('auto_compact_enabled', 'false')
+			('auto_compact_enabled', 'false'),
+			('auto_compact_interval', '24h'),
+			('auto_compact_daily_limit', '200')
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to add compaction config defaults: %w", err)
@@ -431,6 +445,60 @@ func migrateCompactedAtCommitColumn(db *sql.DB) error {
 	return nil
 }
 
+// migrateScheduleColumns adds the due_at and defer_until columns to databases created
+// before issues had dates. The due date index is created here rather than in the schema
+// so that it runs after the column exists.
+func migrateScheduleColumns(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'due_at'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check due_at column: %w", err)
+	}
+
+	if !columnExists {
+		_, err = db.Exec(`
+			ALTER TABLE issues ADD COLUMN due_at DATETIME;
+			ALTER TABLE issues ADD COLUMN defer_until DATETIME;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to add schedule columns: %w", err)
+		}
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_due_at ON issues(due_at)`); err != nil {
+		return fmt.Errorf("failed to create due_at index: %w", err)
+	}
+
+	return nil
+}
+
+// migrateRecurrenceColumn adds the recurrence column to databases created before
+// issues could repeat
+func migrateRecurrenceColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'recurrence'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check recurrence column: %w", err)
+	}
+
+	if !columnExists {
+		_, err = db.Exec(`ALTER TABLE issues ADD COLUMN recurrence TEXT NOT NULL DEFAULT ''`)
+		if err != nil {
+			return fmt.Errorf("failed to add recurrence column: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // getNextIDForPrefix atomically generates the next ID for a given prefix
 // Uses the issue_counters table for atomic, cross-process ID generation
 func (s *SQLiteStorage) getNextIDForPrefix(ctx context.Context, prefix string) (int, error) {
@@ -475,6 +543,9 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	if err := issue.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
+	if err := validateRecurrence(issue.Recurrence); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
 
 	// Set timestamps
 	now := time.Now()
@@ -563,14 +634,16 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		INSERT INTO issues (
 			id, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
-			created_at, updated_at, closed_at, external_ref
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			created_at, updated_at, closed_at, external_ref,
+			due_at, defer_until, recurrence
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		issue.ID, issue.Title, issue.Description, issue.Design,
 		issue.AcceptanceCriteria, issue.Notes, issue.Status,
 		issue.Priority, issue.IssueType, issue.Assignee,
 		issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
 		issue.ClosedAt, issue.ExternalRef,
+		utcTime(issue.DueAt), utcTime(issue.DeferUntil), issue.Recurrence,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert issue: %w", err)
@@ -623,6 +696,9 @@ func validateBatchIssues(issues []*types.Issue) error {
 		if err := issue.Validate(); err != nil {
 			return fmt.Errorf("validation failed for issue %d: %w", i, err)
 		}
+		if err := validateRecurrence(issue.Recurrence); err != nil {
+			return fmt.Errorf("validation failed for issue %d: %w", i, err)
+		}
 	}
 	return nil
 }
@@ -689,8 +765,9 @@ func bulkInsertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue
 		INSERT INTO issues (
 			id, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
-			created_at, updated_at, closed_at, external_ref
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			created_at, updated_at, closed_at, external_ref,
+			due_at, defer_until, recurrence
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
@@ -704,6 +781,7 @@ func bulkInsertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue
 			issue.Priority, issue.IssueType, issue.Assignee,
 			issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
 			issue.ClosedAt, issue.ExternalRef,
+			utcTime(issue.DueAt), utcTime(issue.DeferUntil), issue.Recurrence,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
@@ -760,6 +838,133 @@ func bulkMarkDirty(ctx context.Context, conn *sql.Conn, issues []*types.Issue) e
 	return nil
 }
 
+// bulkInsertLabels adds the labels carried on each issue, recording a label_added
+// event for each as AddLabel does
+func bulkInsertLabels(ctx context.Context, conn *sql.Conn, issues []*types.Issue, actor string) error {
+	for _, issue := range issues {
+		for _, label := range issue.Labels {
+			if _, err := addLabelTx(ctx, conn, issue.ID, label, actor); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolveBatchDependencies returns the dependencies that belong to the batch: those
+// with an empty IssueID, which is filled in with the carrying issue's ID. A DependsOnID
+// of types.BatchRef(i) is replaced with the ID of issues[i].
+func resolveBatchDependencies(issues []*types.Issue) []*types.Dependency {
+	var deps []*types.Dependency
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep.IssueID != "" {
+				continue
+			}
+			dep.IssueID = issue.ID
+			for i, target := range issues {
+				if dep.DependsOnID == types.BatchRef(i) {
+					dep.DependsOnID = target.ID
+					break
+				}
+			}
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// bulkInsertDependencies adds resolved batch dependencies, with the same checks as
+// AddDependency. Returns the IDs of issues outside the batch that gained dependents
+// (they need re-exporting).
+func bulkInsertDependencies(ctx context.Context, conn *sql.Conn, issues []*types.Issue, deps []*types.Dependency, actor string) ([]string, error) {
+	batchIDs := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		batchIDs[issue.ID] = true
+	}
+
+	var touched []string
+	for _, dep := range deps {
+		if !dep.Type.IsValid() {
+			return nil, fmt.Errorf("invalid dependency type: %s", dep.Type)
+		}
+		if dep.IssueID == dep.DependsOnID {
+			return nil, fmt.Errorf("issue cannot depend on itself")
+		}
+		if !batchIDs[dep.DependsOnID] {
+			target, err := getIssue(ctx, conn, dep.DependsOnID)
+			if err != nil {
+				return nil, err
+			}
+			if target == nil {
+				return nil, fmt.Errorf("dependency target %s not found", dep.DependsOnID)
+			}
+			touched = append(touched, dep.DependsOnID)
+		}
+		if err := checkDependencyCycleTx(ctx, conn, dep); err != nil {
+			return nil, err
+		}
+
+		dep.CreatedAt = time.Now()
+		dep.CreatedBy = actor
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+			VALUES (?, ?, ?, ?, ?)
+		`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add dependency %s -> %s: %w", dep.IssueID, dep.DependsOnID, err)
+		}
+		if err := recordDependencyEventTx(ctx, conn, types.EventDependencyAdded, dep, actor); err != nil {
+			return nil, err
+		}
+	}
+	return touched, nil
+}
+
+// createIssuesConn inserts validated issues with their labels and batch dependencies
+// inside a transaction already open on conn
+func createIssuesConn(ctx context.Context, conn *sql.Conn, issues []*types.Issue, actor string) error {
+	// Phase 3: Generate IDs for issues that need them
+	if err := generateBatchIDs(ctx, conn, issues); err != nil {
+		return err
+	}
+	batchDeps := resolveBatchDependencies(issues)
+
+	// Phase 4: Bulk insert issues
+	if err := bulkInsertIssues(ctx, conn, issues); err != nil {
+		return err
+	}
+
+	// Phase 5: Record creation events
+	if err := bulkRecordEvents(ctx, conn, issues, actor); err != nil {
+		return err
+	}
+
+	// Phase 6: Add labels and batch dependencies
+	if err := bulkInsertLabels(ctx, conn, issues, actor); err != nil {
+		return err
+	}
+	touched, err := bulkInsertDependencies(ctx, conn, issues, batchDeps, actor)
+	if err != nil {
+		return err
+	}
+
+	// Phase 7: Mark issues dirty for incremental export
+	if err := bulkMarkDirty(ctx, conn, issues); err != nil {
+		return err
+	}
+	for _, id := range touched {
+		if _, err := conn.ExecContext(ctx, `
+			INSERT INTO dirty_issues (issue_id, marked_at)
+			VALUES (?, ?)
+			ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+		`, id, time.Now()); err != nil {
+			return fmt.Errorf("failed to mark dirty %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
 // CreateIssues creates multiple issues atomically in a single transaction.
 // This provides significant performance improvements over calling CreateIssue in a loop:
 // - Single connection acquisition
@@ -789,6 +994,14 @@ func bulkMarkDirty(ctx context.Context, conn *sql.Conn, issues []*types.Issue) e
 //   - All issues in the batch receive identical created_at/updated_at timestamps
 //   - This reflects that they were created as a single atomic operation
 //
+// Labels and dependencies:
+//   - Labels carried on the issues are added in the same transaction
+//   - Dependencies with an empty IssueID are added in the same transaction, so a
+//     whole tree of issues can be created atomically. They may point at other
+//     issues of the batch with types.BatchRef(index)
+//   - Dependencies with IssueID set are left to the caller (import adds them once
+//     all issues exist, tolerating missing targets)
+//
 // Usage:
 //   // Bulk import from external source
 //   issues := []*types.Issue{...}
@@ -838,27 +1051,13 @@ func (s *SQLiteStorage) CreateIssues(ctx context.Context, issues []*types.Issue,
 		}
 	}()
 
-	// Phase 3: Generate IDs for issues that need them
-	if err := generateBatchIDs(ctx, conn, issues); err != nil {
-		return err
-	}
-
-	// Phase 4: Bulk insert issues
-	if err := bulkInsertIssues(ctx, conn, issues); err != nil {
+	// Phases 3-7: Generate IDs, insert issues, record events, add labels and
+	// dependencies, mark dirty
+	if err := createIssuesConn(ctx, conn, issues, actor); err != nil {
 		return err
 	}
 
-	// Phase 5: Record creation events
-	if err := bulkRecordEvents(ctx, conn, issues, actor); err != nil {
-		return err
-	}
-
-	// Phase 6: Mark issues dirty for incremental export
-	if err := bulkMarkDirty(ctx, conn, issues); err != nil {
-		return err
-	}
-
-	// Phase 7: Commit transaction
+	// Phase 8: Commit transaction
 	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -866,21 +1065,39 @@ func (s *SQLiteStorage) CreateIssues(ctx context.Context, issues []*types.Issue,
 	return nil
 }
 
+// queryRower is satisfied by both *sql.DB and *sql.Tx, so single-row lookups
+// can run either standalone or inside a transaction
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// execer is satisfied by *sql.DB, *sql.Tx and *sql.Conn
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
 // GetIssue retrieves an issue by ID
 func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	return getIssue(ctx, s.db, id)
+}
+
+// getIssue retrieves an issue by ID using the given database handle or transaction
+func getIssue(ctx context.Context, q queryRower, id string) (*types.Issue, error) {
 	var issue types.Issue
 	var closedAt sql.NullTime
 	var estimatedMinutes sql.NullInt64
 	var assignee sql.NullString
 	var externalRef sql.NullString
+	var dueAt, deferUntil sql.NullTime
 	var compactedAt sql.NullTime
 	var originalSize sql.NullInt64
 
 	var compactedAtCommit sql.NullString
-	err := s.db.QueryRowContext(ctx, `
+	err := q.QueryRowContext(ctx, `
 		SELECT id, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, updated_at, closed_at, external_ref,
+		       due_at, defer_until, recurrence,
 		       compaction_level, compacted_at, compacted_at_commit, original_size
 		FROM issues
 		WHERE id = ?
@@ -889,6 +1106,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
+		&dueAt, &deferUntil, &issue.Recurrence,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize,
 	)
 
@@ -912,6 +1130,12 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	if externalRef.Valid {
 		issue.ExternalRef = &externalRef.String
 	}
+	if dueAt.Valid {
+		issue.DueAt = &dueAt.Time
+	}
+	if deferUntil.Valid {
+		issue.DeferUntil = &deferUntil.Time
+	}
 	if compactedAt.Valid {
 		issue.CompactedAt = &compactedAt.Time
 	}
@@ -938,6 +1162,9 @@ var allowedUpdateFields = map[string]bool{
 	"issue_type":          true,
 	"estimated_minutes":   true,
 	"external_ref":        true,
+	"due_at":              true,
+	"defer_until":         true,
+	"recurrence":          true,
 }
 
 // validatePriority validates a priority value
@@ -980,6 +1207,16 @@ func validateTitle(value interface{}) error {
 	return nil
 }
 
+// validateRecurrence validates a recurrence rule ("" clears it)
+func validateRecurrence(value interface{}) error {
+	if rule, ok := value.(string); ok && rule != "" {
+		if _, err := recur.Parse(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // validateEstimatedMinutes validates an estimated_minutes value
 func validateEstimatedMinutes(value interface{}) error {
 	if mins, ok := value.(int); ok {
@@ -990,6 +1227,38 @@ func validateEstimatedMinutes(value interface{}) error {
 	return nil
 }
 
+// utcTime converts an optional timestamp to UTC for storage. Dates are compared in SQL
+// as stored text, so every due_at/defer_until value must be written in the same zone.
+func utcTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// normalizeDateValue converts a due_at/defer_until update value to a UTC time or nil.
+// Accepts time.Time, *time.Time, nil, or an RFC3339 string ("" clears the date).
+func normalizeDateValue(key string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		return v.UTC(), nil
+	case *time.Time:
+		return utcTime(v), nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		return t.UTC(), nil
+	}
+	return nil, fmt.Errorf("invalid %s: unsupported type %T", key, value)
+}
+
 // fieldValidators maps field names to their validation functions
 var fieldValidators = map[string]func(interface{}) error{
 	"priority":           validatePriority,
@@ -997,6 +1266,7 @@ var fieldValidators = map[string]func(interface{}) error{
 	"issue_type":         validateIssueType,
 	"title":              validateTitle,
 	"estimated_minutes":  validateEstimatedMinutes,
+	"recurrence":         validateRecurrence,
 }
 
 // validateFieldUpdate validates a field update value
@@ -1069,6 +1339,37 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return fmt.Errorf("issue %s not found", id)
 	}
 
+	// Start transaction
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateIssueTx(ctx, tx, oldIssue, updates, actor); err != nil {
+		return err
+	}
+
+	// Mark issue as dirty for incremental export
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, id, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// updateIssueTx validates and applies field updates to a single issue and records
+// the corresponding event within an existing transaction. The updates map may be
+// modified (closed_at is added when the status changes), so callers applying the
+// same updates to several issues must pass a fresh copy each time.
+func updateIssueTx(ctx context.Context, tx execer, oldIssue *types.Issue, updates map[string]interface{}, actor string) error {
+	id := oldIssue.ID
+
 	// Build update query with validated field names
 	setClauses := []string{"updated_at = ?"}
 	args := []interface{}{time.Now()}
@@ -1084,6 +1385,14 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 			return err
 		}
 
+		if key == "due_at" || key == "defer_until" {
+			normalized, err := normalizeDateValue(key, value)
+			if err != nil {
+				return err
+			}
+			value = normalized
+		}
+
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
 		args = append(args, value)
 	}
@@ -1093,16 +1402,9 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 
 	args = append(args, id)
 
-	// Start transaction
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
 	// Update issue
 	query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", "))
-	_, err = tx.ExecContext(ctx, query, args...)
+	_, err := tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update issue: %w", err)
 	}
@@ -1131,17 +1433,7 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return fmt.Errorf("failed to record event: %w", err)
 	}
 
-	// Mark issue as dirty for incremental export
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO dirty_issues (issue_id, marked_at)
-		VALUES (?, ?)
-		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-	`, id, time.Now())
-	if err != nil {
-		return fmt.Errorf("failed to mark issue dirty: %w", err)
-	}
-
-	return tx.Commit()
+	return nil
 }
 
 // UpdateIssueID updates an issue ID and all its text fields in a single transaction
@@ -1181,6 +1473,11 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update labels: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `UPDATE work_logs SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update work_logs: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		UPDATE dirty_issues SET issue_id = ? WHERE issue_id = ?
 	`, newID, oldID)
@@ -1265,6 +1562,15 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 	}
 	defer tx.Rollback()
 
+	// Capture the previous state so the close can be undone later
+	var oldValue *string
+	if oldIssue, err := getIssue(ctx, tx, id); err == nil && oldIssue != nil {
+		if oldData, err := json.Marshal(oldIssue); err == nil {
+			oldDataStr := string(oldData)
+			oldValue = &oldDataStr
+		}
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?
 		WHERE id = ?
@@ -1274,9 +1580,9 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, id, types.EventClosed, actor, reason)
+		INSERT INTO events (issue_id, event_type, actor, old_value, comment)
+		VALUES (?, ?, ?, ?, ?)
+	`, id, types.EventClosed, actor, oldValue, reason)
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
@@ -1339,6 +1645,23 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		}
 	}
 
+	// Due date filtering (dates are stored in UTC, see utcTime)
+	if filter.DueBefore != nil {
+		whereClauses = append(whereClauses, "due_at < ?")
+		args = append(args, filter.DueBefore.UTC())
+	}
+	if filter.DueAfter != nil {
+		whereClauses = append(whereClauses, "due_at >= ?")
+		args = append(args, filter.DueAfter.UTC())
+	}
+	if filter.Overdue {
+		whereClauses = append(whereClauses, "status != 'closed' AND due_at < ?")
+		args = append(args, time.Now().UTC())
+	}
+	if filter.Recurring {
+		whereClauses = append(whereClauses, "recurrence != ''")
+	}
+
 	whereSQL := ""
 	if len(whereClauses) > 0 {
 		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
@@ -1353,7 +1676,8 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 	querySQL := fmt.Sprintf(`
 		SELECT id, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, updated_at, closed_at, external_ref
+		       created_at, updated_at, closed_at, external_ref,
+		       due_at, defer_until, recurrence
 		FROM issues
 		%s
 		ORDER BY priority ASC, created_at DESC
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -618,6 +619,102 @@ func TestCreateIssuesRollback(t *testing.T) {
 	})
 }
 
+func TestCreateIssuesWithBatchDependencies(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	existing := &types.Issue{Title: "Existing", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, existing, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	issues := []*types.Issue{
+		{Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic, Labels: []string{"release"}},
+		{Title: "First", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Dependencies: []*types.Dependency{
+			{DependsOnID: types.BatchRef(0), Type: types.DepParentChild},
+		}},
+		{Title: "Second", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Dependencies: []*types.Dependency{
+			{DependsOnID: types.BatchRef(0), Type: types.DepParentChild},
+			{DependsOnID: types.BatchRef(1), Type: types.DepBlocks},
+			{DependsOnID: existing.ID, Type: types.DepRelated},
+		}},
+	}
+	if err := store.CreateIssues(ctx, issues, "test-user"); err != nil {
+		t.Fatalf("CreateIssues failed: %v", err)
+	}
+
+	labels, err := store.GetLabels(ctx, issues[0].ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 1 || labels[0] != "release" {
+		t.Errorf("Expected release label, got %v", labels)
+	}
+	events, err := store.SearchEvents(ctx, types.EventFilter{IssueID: issues[0].ID, Types: []types.EventType{types.EventLabelAdded}})
+	if err != nil {
+		t.Fatalf("SearchEvents failed: %v", err)
+	}
+	if len(events) != 1 || events[0].Actor != "test-user" {
+		t.Errorf("Expected one label_added event by test-user, got %+v", events)
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, issues[2].ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	want := map[string]types.DependencyType{issues[0].ID: types.DepParentChild, issues[1].ID: types.DepBlocks, existing.ID: types.DepRelated}
+	if len(deps) != len(want) {
+		t.Fatalf("Expected %d dependencies, got %d", len(want), len(deps))
+	}
+	for _, dep := range deps {
+		if want[dep.DependsOnID] != dep.Type {
+			t.Errorf("Unexpected dependency %s -> %s (%s)", dep.IssueID, dep.DependsOnID, dep.Type)
+		}
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	for _, issue := range ready {
+		if issue.ID == issues[2].ID {
+			t.Errorf("Expected %s to be blocked by %s", issues[2].ID, issues[1].ID)
+		}
+	}
+
+	t.Run("rollback on missing target", func(t *testing.T) {
+		before, _ := store.SearchIssues(ctx, "", types.IssueFilter{})
+		batch := []*types.Issue{
+			{Title: "Orphan", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Dependencies: []*types.Dependency{
+				{DependsOnID: "test-999", Type: types.DepBlocks},
+			}},
+		}
+		if err := store.CreateIssues(ctx, batch, "test-user"); err == nil {
+			t.Fatal("Expected error for missing dependency target")
+		}
+		after, _ := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if len(after) != len(before) {
+			t.Errorf("Expected batch to roll back, issue count went from %d to %d", len(before), len(after))
+		}
+	})
+
+	t.Run("rollback on cycle", func(t *testing.T) {
+		batch := []*types.Issue{
+			{Title: "A", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Dependencies: []*types.Dependency{
+				{DependsOnID: types.BatchRef(1), Type: types.DepBlocks},
+			}},
+			{Title: "B", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Dependencies: []*types.Dependency{
+				{DependsOnID: types.BatchRef(0), Type: types.DepBlocks},
+			}},
+		}
+		if err := store.CreateIssues(ctx, batch, "test-user"); err == nil || !strings.Contains(err.Error(), "cycle") {
+			t.Fatalf("Expected cycle error, got %v", err)
+		}
+	})
+}
+
 func TestUpdateIssue(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -968,6 +1065,103 @@ func TestGetStatistics(t *testing.T) {
 	}
 }
 
+func TestGetStatisticsBy(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	groups, err := store.GetStatisticsBy(ctx, "assignee", nil)
+	if err != nil {
+		t.Fatalf("GetStatisticsBy failed on empty database: %v", err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("Expected no groups, got %d", len(groups))
+	}
+
+	issues := []*types.Issue{
+		{Title: "Blocker", Status: types.StatusInProgress, Priority: 0, IssueType: types.TypeBug, Assignee: "agent-3"},
+		{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Assignee: "agent-3"},
+		{Title: "Done", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Assignee: "agent-3"},
+		{Title: "Unassigned", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	for _, issue := range issues {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	dep := &types.Dependency{IssueID: issues[1].ID, DependsOnID: issues[0].ID, Type: types.DepBlocks}
+	if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issues[2].ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	for _, label := range []string{"backend", "urgent"} {
+		if err := store.AddLabel(ctx, issues[0].ID, label, "test-user"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+	}
+
+	groups, err = store.GetStatisticsBy(ctx, "assignee", nil)
+	if err != nil {
+		t.Fatalf("GetStatisticsBy failed: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Key != "" || groups[1].Key != "agent-3" {
+		t.Fatalf("Expected unassigned and agent-3 groups, got %+v", groups)
+	}
+	agent := groups[1]
+	if agent.TotalIssues != 3 || agent.OpenIssues != 1 || agent.InProgressIssues != 1 || agent.BlockedIssues != 1 ||
+		agent.ReadyIssues != 0 || agent.ClosedIssues != 1 {
+		t.Errorf("Unexpected agent-3 statistics: %+v", agent)
+	}
+	if groups[0].ReadyIssues != 1 || groups[0].TotalIssues != 1 {
+		t.Errorf("Unexpected unassigned statistics: %+v", groups[0])
+	}
+
+	// Issues count once per label; unlabeled issues are grouped under ""
+	groups, err = store.GetStatisticsBy(ctx, "label", nil)
+	if err != nil {
+		t.Fatalf("GetStatisticsBy failed: %v", err)
+	}
+	if len(groups) != 3 || groups[0].Key != "" || groups[0].TotalIssues != 3 ||
+		groups[1].Key != "backend" || groups[2].Key != "urgent" || groups[2].InProgressIssues != 1 {
+		t.Errorf("Unexpected label groups: %+v", groups)
+	}
+
+	groups, err = store.GetStatisticsBy(ctx, "priority", nil)
+	if err != nil {
+		t.Fatalf("GetStatisticsBy failed: %v", err)
+	}
+	if len(groups) != 3 || groups[0].Key != "P0" || groups[1].Key != "P1" || groups[1].ClosedIssues != 1 {
+		t.Errorf("Unexpected priority groups: %+v", groups)
+	}
+
+	// The window limits closed counts and lead times
+	future := time.Now().Add(time.Hour)
+	groups, err = store.GetStatisticsBy(ctx, "type", &future)
+	if err != nil {
+		t.Fatalf("GetStatisticsBy failed: %v", err)
+	}
+	for _, g := range groups {
+		if g.ClosedIssues != 0 || g.AverageLeadTime != 0 {
+			t.Errorf("Expected no closed issues in the window, got %+v", g)
+		}
+	}
+	past := time.Now().Add(-time.Hour)
+	groups, err = store.GetStatisticsBy(ctx, "type", &past)
+	if err != nil {
+		t.Fatalf("GetStatisticsBy failed: %v", err)
+	}
+	if len(groups) != 2 || groups[1].Key != "task" || groups[1].ClosedIssues != 1 {
+		t.Errorf("Unexpected type groups: %+v", groups)
+	}
+
+	if _, err := store.GetStatisticsBy(ctx, "color", nil); err == nil {
+		t.Error("Expected error for unknown grouping")
+	}
+}
+
 // Note: High-concurrency stress tests were removed as the pure Go SQLite driver
 // (modernc.org/sqlite) can experience "database is locked" errors under extreme
 // parallel load (100+ simultaneous operations). This is a known limitation and
@@ -1152,3 +1346,51 @@ func TestMetadataMultipleKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestSearchIssuesDueFilters(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	yesterday := time.Now().Add(-24 * time.Hour)
+	nextWeek := time.Now().Add(7 * 24 * time.Hour)
+	overdue := &types.Issue{Title: "Overdue", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DueAt: &yesterday}
+	upcoming := &types.Issue{Title: "Upcoming", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DueAt: &nextWeek}
+	doneLate := &types.Issue{Title: "Done late", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DueAt: &yesterday}
+	undated := &types.Issue{Title: "Undated", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+
+	for _, issue := range []*types.Issue{overdue, upcoming, doneLate, undated} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, doneLate.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{Overdue: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != overdue.ID {
+		t.Errorf("Expected only %s overdue, got %d results", overdue.ID, len(results))
+	}
+
+	now := time.Now()
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{DueAfter: &now})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != upcoming.ID {
+		t.Errorf("Expected only %s due after now, got %d results", upcoming.ID, len(results))
+	}
+
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{DueBefore: &now})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected 2 issues due before now (open and closed), got %d", len(results))
+	}
+}
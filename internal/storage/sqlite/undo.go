@@ -0,0 +1,489 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// UndoOperation is the inverse of a single audit trail event
+type UndoOperation struct {
+	EventID     int64                  `json:"event_id"`
+	IssueID     string                 `json:"issue_id"`
+	EventType   types.EventType        `json:"event_type"`
+	EventActor  string                 `json:"event_actor"`
+	Description string                 `json:"description"`
+	Updates     map[string]interface{} `json:"updates,omitempty"`      // Field values to restore
+	AddLabel    string                 `json:"add_label,omitempty"`    // Label to re-add
+	RemoveLabel string                 `json:"remove_label,omitempty"` // Label to remove
+	AddDep      *types.Dependency      `json:"add_dependency,omitempty"`
+	RemoveDep   *types.Dependency      `json:"remove_dependency,omitempty"`
+}
+
+// UndoSkip records an event that cannot be reversed
+type UndoSkip struct {
+	EventID   int64           `json:"event_id"`
+	IssueID   string          `json:"issue_id"`
+	EventType types.EventType `json:"event_type"`
+	Reason    string          `json:"reason"`
+}
+
+// UndoConflict records a later event that prevents reverting an earlier one
+type UndoConflict struct {
+	EventID            int64  `json:"event_id"`
+	IssueID            string `json:"issue_id"`
+	ConflictingEventID int64  `json:"conflicting_event_id"`
+	Reason             string `json:"reason"`
+}
+
+// UndoPlan is the set of inverse operations computed for a group of events.
+// Operations are ordered newest event first, which is the order they must be applied in.
+type UndoPlan struct {
+	Operations []*UndoOperation `json:"operations"`
+	Skipped    []*UndoSkip      `json:"skipped,omitempty"`
+	Conflicts  []*UndoConflict  `json:"conflicts,omitempty"`
+}
+
+// IsUndoableEvent reports whether events of this type can be reversed by PlanUndo
+func IsUndoableEvent(eventType types.EventType) bool {
+	for _, t := range UndoableEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// UndoableEventTypes lists the event types PlanUndo can revert
+var UndoableEventTypes = []types.EventType{
+	types.EventUpdated, types.EventStatusChanged, types.EventClosed, types.EventReopened,
+	types.EventLabelAdded, types.EventLabelRemoved,
+	types.EventDependencyAdded, types.EventDependencyRemoved,
+}
+
+// PlanUndo computes the inverse operations for the given events without modifying anything.
+//
+// An event conflicts with the revert if a later event on the same issue, which is not itself
+// being undone, touched the same field, label or dependency. Events whose previous state was
+// not recorded, or which are inherently irreversible (creation, comments, compaction), are
+// reported as skipped.
+func (s *SQLiteStorage) PlanUndo(ctx context.Context, events []*types.Event) (*UndoPlan, error) {
+	plan := &UndoPlan{}
+
+	// Work newest first so that applying the operations in order unwinds history correctly
+	sorted := make([]*types.Event, len(events))
+	copy(sorted, events)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID > sorted[j].ID })
+
+	undoing := make(map[int64]bool, len(sorted))
+	for _, e := range sorted {
+		undoing[e.ID] = true
+	}
+
+	// Later history per issue, fetched once
+	history := make(map[string][]*types.Event)
+
+	for _, event := range sorted {
+		op, skipReason := inverseOfEvent(event)
+		if op == nil {
+			plan.Skipped = append(plan.Skipped, &UndoSkip{
+				EventID:   event.ID,
+				IssueID:   event.IssueID,
+				EventType: event.EventType,
+				Reason:    skipReason,
+			})
+			continue
+		}
+
+		if _, ok := history[event.IssueID]; !ok {
+			issueEvents, err := s.SearchEvents(ctx, types.EventFilter{IssueID: event.IssueID})
+			if err != nil {
+				return nil, err
+			}
+			history[event.IssueID] = issueEvents
+		}
+
+		if undoneBy := findUndoOf(history[event.IssueID], event.ID); undoneBy != nil {
+			plan.Conflicts = append(plan.Conflicts, &UndoConflict{
+				EventID:            event.ID,
+				IssueID:            event.IssueID,
+				ConflictingEventID: undoneBy.ID,
+				Reason:             "event was already undone",
+			})
+			plan.Operations = append(plan.Operations, op)
+			continue
+		}
+
+		touched := eventTouches(event)
+		for _, later := range history[event.IssueID] {
+			if later.ID <= event.ID || undoing[later.ID] {
+				continue
+			}
+			for _, key := range eventTouches(later) {
+				if containsString(touched, key) {
+					plan.Conflicts = append(plan.Conflicts, &UndoConflict{
+						EventID:            event.ID,
+						IssueID:            event.IssueID,
+						ConflictingEventID: later.ID,
+						Reason:             fmt.Sprintf("%s changed later by %s (%s)", strings.Replace(key, ":", " ", 1), later.Actor, later.EventType),
+					})
+					break
+				}
+			}
+		}
+
+		plan.Operations = append(plan.Operations, op)
+	}
+
+	return plan, nil
+}
+
+// ApplyUndo applies the operations of a plan in a single transaction. Each inverse operation
+// records its own audit events under actor, plus an "undone" event referencing the original.
+// Plans with conflicts are rejected.
+func (s *SQLiteStorage) ApplyUndo(ctx context.Context, plan *UndoPlan, actor string) error {
+	if len(plan.Conflicts) > 0 {
+		return fmt.Errorf("cannot undo: %d conflicting later change(s)", len(plan.Conflicts))
+	}
+	if len(plan.Operations) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	dirty := []string{}
+	for _, op := range plan.Operations {
+		dirty = append(dirty, op.IssueID)
+
+		switch {
+		case len(op.Updates) > 0:
+			// Read current state inside the transaction so chained operations see earlier ones
+			current, err := getIssue(ctx, tx, op.IssueID)
+			if err != nil {
+				return err
+			}
+			if current == nil {
+				return fmt.Errorf("issue %s not found", op.IssueID)
+			}
+			updates := make(map[string]interface{}, len(op.Updates))
+			for k, v := range op.Updates {
+				updates[k] = v
+			}
+			if err := updateIssueTx(ctx, tx, current, updates, actor); err != nil {
+				return fmt.Errorf("failed to undo event %d: %w", op.EventID, err)
+			}
+
+		case op.AddLabel != "":
+			if _, err := addLabelTx(ctx, tx, op.IssueID, op.AddLabel, actor); err != nil {
+				return err
+			}
+
+		case op.RemoveLabel != "":
+			if _, err := removeLabelTx(ctx, tx, op.IssueID, op.RemoveLabel, actor); err != nil {
+				return err
+			}
+
+		case op.AddDep != nil:
+			for _, id := range []string{op.AddDep.IssueID, op.AddDep.DependsOnID} {
+				issue, err := getIssue(ctx, tx, id)
+				if err != nil {
+					return err
+				}
+				if issue == nil {
+					return fmt.Errorf("cannot restore dependency: issue %s not found", id)
+				}
+			}
+			if err := checkDependencyCycleTx(ctx, tx, op.AddDep); err != nil {
+				return err
+			}
+			dep := &types.Dependency{
+				IssueID:     op.AddDep.IssueID,
+				DependsOnID: op.AddDep.DependsOnID,
+				Type:        op.AddDep.Type,
+				CreatedAt:   time.Now(),
+				CreatedBy:   actor,
+			}
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+				VALUES (?, ?, ?, ?, ?)
+			`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+			if err != nil {
+				return fmt.Errorf("failed to restore dependency: %w", err)
+			}
+			if err := recordDependencyEventTx(ctx, tx, types.EventDependencyAdded, dep, actor); err != nil {
+				return err
+			}
+			dirty = append(dirty, dep.DependsOnID)
+
+		case op.RemoveDep != nil:
+			var depType types.DependencyType
+			err := tx.QueryRowContext(ctx, `
+				SELECT type FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
+			`, op.RemoveDep.IssueID, op.RemoveDep.DependsOnID).Scan(&depType)
+			if err != nil {
+				return fmt.Errorf("cannot remove dependency %s → %s: %w", op.RemoveDep.IssueID, op.RemoveDep.DependsOnID, err)
+			}
+			_, err = tx.ExecContext(ctx, `
+				DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
+			`, op.RemoveDep.IssueID, op.RemoveDep.DependsOnID)
+			if err != nil {
+				return fmt.Errorf("failed to remove dependency: %w", err)
+			}
+			removed := &types.Dependency{IssueID: op.RemoveDep.IssueID, DependsOnID: op.RemoveDep.DependsOnID, Type: depType}
+			if err := recordDependencyEventTx(ctx, tx, types.EventDependencyRemoved, removed, actor); err != nil {
+				return err
+			}
+			dirty = append(dirty, op.RemoveDep.DependsOnID)
+		}
+
+		// Record the undo itself so it shows up in the audit trail and can't be applied twice
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, comment)
+			VALUES (?, ?, ?, ?, ?)
+		`, op.IssueID, types.EventUndone, actor, strconv.FormatInt(op.EventID, 10),
+			fmt.Sprintf("Undo of event #%d (%s by %s): %s", op.EventID, op.EventType, op.EventActor, op.Description))
+		if err != nil {
+			return fmt.Errorf("failed to record undo event: %w", err)
+		}
+	}
+
+	if err := markIssuesDirtyTx(ctx, tx, dirty); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// inverseOfEvent computes the operation that reverts an event, or a reason why it can't
+func inverseOfEvent(event *types.Event) (*UndoOperation, string) {
+	op := &UndoOperation{
+		EventID:    event.ID,
+		IssueID:    event.IssueID,
+		EventType:  event.EventType,
+		EventActor: event.Actor,
+	}
+
+	switch event.EventType {
+	case types.EventUpdated, types.EventStatusChanged, types.EventClosed, types.EventReopened:
+		oldFields, _ := decodeEventMap(event.OldValue)
+		if event.EventType == types.EventClosed && event.NewValue == nil {
+			// Closed via CloseIssue: only the status changed
+			status := string(types.StatusOpen)
+			if s, ok := oldFields["status"].(string); ok && s != string(types.StatusClosed) {
+				status = s
+			}
+			op.Updates = map[string]interface{}{"status": status}
+			op.Description = fmt.Sprintf("restore status to %s", status)
+			return op, ""
+		}
+
+		if oldFields == nil {
+			return nil, "previous values were not recorded"
+		}
+		newFields, err := decodeEventMap(event.NewValue)
+		if err != nil || newFields == nil {
+			return nil, "changed values were not recorded"
+		}
+
+		op.Updates = make(map[string]interface{})
+		var restored []string
+		for field := range newFields {
+			if !allowedUpdateFields[field] {
+				continue // closed_at and friends are derived from status
+			}
+			op.Updates[field] = restoredFieldValue(field, oldFields[field])
+			restored = append(restored, field)
+		}
+		if len(op.Updates) == 0 {
+			return nil, "no reversible field changes"
+		}
+		sort.Strings(restored)
+		op.Description = fmt.Sprintf("restore %s", strings.Join(restored, ", "))
+		return op, ""
+
+	case types.EventLabelAdded:
+		label := labelFromComment(event.Comment)
+		if label == "" {
+			return nil, "label was not recorded"
+		}
+		op.RemoveLabel = label
+		op.Description = fmt.Sprintf("remove label %s", label)
+		return op, ""
+
+	case types.EventLabelRemoved:
+		label := labelFromComment(event.Comment)
+		if label == "" {
+			return nil, "label was not recorded"
+		}
+		op.AddLabel = label
+		op.Description = fmt.Sprintf("re-add label %s", label)
+		return op, ""
+
+	case types.EventDependencyAdded:
+		dep := dependencyFromEvent(event)
+		if dep == nil {
+			return nil, "dependency was not recorded"
+		}
+		op.RemoveDep = dep
+		op.Description = fmt.Sprintf("remove dependency %s → %s", dep.IssueID, dep.DependsOnID)
+		return op, ""
+
+	case types.EventDependencyRemoved:
+		dep := dependencyFromEvent(event)
+		if dep == nil || dep.Type == "" {
+			return nil, "dependency type was not recorded"
+		}
+		op.AddDep = dep
+		op.Description = fmt.Sprintf("re-add %s dependency %s → %s", dep.Type, dep.IssueID, dep.DependsOnID)
+		return op, ""
+
+	case types.EventCreated:
+		return nil, "issue creation cannot be undone"
+	case types.EventCommented:
+		return nil, "comments cannot be undone"
+	case types.EventCompacted:
+		return nil, "use 'bd restore' for compacted issues"
+	}
+
+	return nil, fmt.Sprintf("%s events cannot be undone", event.EventType)
+}
+
+// findUndoOf returns the "undone" event that already reverted eventID, if any
+func findUndoOf(events []*types.Event, eventID int64) *types.Event {
+	target := strconv.FormatInt(eventID, 10)
+	for _, e := range events {
+		if e.EventType == types.EventUndone && e.OldValue != nil && *e.OldValue == target {
+			return e
+		}
+	}
+	return nil
+}
+
+// eventTouches returns the keys (field:x, label:x, dep:a->b) that an event modified
+func eventTouches(event *types.Event) []string {
+	switch event.EventType {
+	case types.EventUpdated, types.EventStatusChanged, types.EventClosed, types.EventReopened:
+		newFields, _ := decodeEventMap(event.NewValue)
+		if newFields == nil {
+			return []string{"field:status"}
+		}
+		var keys []string
+		for field := range newFields {
+			if field == "closed_at" {
+				field = "status"
+			}
+			keys = append(keys, "field:"+field)
+		}
+		return keys
+	case types.EventLabelAdded, types.EventLabelRemoved:
+		if label := labelFromComment(event.Comment); label != "" {
+			return []string{"label:" + label}
+		}
+	case types.EventDependencyAdded, types.EventDependencyRemoved:
+		if dep := dependencyFromEvent(event); dep != nil {
+			return []string{"dep:" + dep.IssueID + "->" + dep.DependsOnID}
+		}
+	}
+	return nil
+}
+
+// decodeEventMap parses a JSON object stored in an event's old_value or new_value
+func decodeEventMap(value *string) (map[string]interface{}, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(*value), &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// restoredFieldValue converts a JSON-decoded previous value back into the type UpdateIssue expects.
+// Missing values correspond to omitempty fields that were unset.
+func restoredFieldValue(field string, value interface{}) interface{} {
+	switch field {
+	case "priority":
+		if n, ok := value.(float64); ok {
+			return int(n)
+		}
+		return 0
+	case "estimated_minutes":
+		if n, ok := value.(float64); ok {
+			return int(n)
+		}
+		return nil
+	case "external_ref", "due_at", "defer_until":
+		if s, ok := value.(string); ok {
+			return s
+		}
+		return nil
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// labelFromComment extracts the label from "Added label: x" / "Removed label: x" comments
+func labelFromComment(comment *string) string {
+	if comment == nil {
+		return ""
+	}
+	if idx := strings.Index(*comment, ": "); idx >= 0 {
+		return strings.TrimSpace((*comment)[idx+2:])
+	}
+	return ""
+}
+
+// dependencyFromEvent recovers the dependency record from a dependency event. Older events
+// only carry a comment; "Added dependency: a type b" can still be parsed, but removals
+// recorded before dependency JSON was stored lack the type.
+func dependencyFromEvent(event *types.Event) *types.Dependency {
+	value := event.NewValue
+	if event.EventType == types.EventDependencyRemoved {
+		value = event.OldValue
+	}
+	if fields, err := decodeEventMap(value); err == nil && fields != nil {
+		issueID, _ := fields["issue_id"].(string)
+		dependsOnID, _ := fields["depends_on_id"].(string)
+		depType, _ := fields["type"].(string)
+		if issueID != "" && dependsOnID != "" {
+			return &types.Dependency{IssueID: issueID, DependsOnID: dependsOnID, Type: types.DependencyType(depType)}
+		}
+	}
+
+	if event.Comment == nil {
+		return nil
+	}
+	if rest, ok := strings.CutPrefix(*event.Comment, "Added dependency: "); ok {
+		parts := strings.Fields(rest)
+		if len(parts) == 3 {
+			return &types.Dependency{IssueID: parts[0], DependsOnID: parts[2], Type: types.DependencyType(parts[1])}
+		}
+	}
+	if rest, ok := strings.CutPrefix(*event.Comment, "Removed dependency on "); ok {
+		return &types.Dependency{IssueID: event.IssueID, DependsOnID: strings.TrimSpace(rest)}
+	}
+	return nil
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
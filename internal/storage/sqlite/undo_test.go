@@ -0,0 +1,224 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func createUndoTestIssue(t *testing.T, store *SQLiteStorage, title string) *types.Issue {
+	t.Helper()
+	issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(context.Background(), issue, "setup"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	return issue
+}
+
+func eventsByActor(t *testing.T, store *SQLiteStorage, actor string) []*types.Event {
+	t.Helper()
+	events, err := store.SearchEvents(context.Background(), types.EventFilter{Actor: actor})
+	if err != nil {
+		t.Fatalf("SearchEvents failed: %v", err)
+	}
+	return events
+}
+
+func TestUndoRevertsMassEdit(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	a := createUndoTestIssue(t, store, "A")
+	b := createUndoTestIssue(t, store, "B")
+	if err := store.AddLabel(ctx, a.ID, "keep", "setup"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepParentChild}, "setup"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	// The destructive edit
+	if err := store.UpdateIssues(ctx, []string{a.ID, b.ID}, map[string]interface{}{"priority": 0, "assignee": "agent-7"}, []string{"oops"}, []string{"keep"}, "agent-7"); err != nil {
+		t.Fatalf("UpdateIssues failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, a.ID, "done", "agent-7"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.RemoveDependency(ctx, b.ID, a.ID, "agent-7"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+
+	plan, err := store.PlanUndo(ctx, eventsByActor(t, store, "agent-7"))
+	if err != nil {
+		t.Fatalf("PlanUndo failed: %v", err)
+	}
+	if len(plan.Conflicts) != 0 {
+		t.Fatalf("unexpected conflicts: %+v", plan.Conflicts[0])
+	}
+	if len(plan.Operations) != 7 {
+		t.Fatalf("expected 7 operations, got %d", len(plan.Operations))
+	}
+	if err := store.ApplyUndo(ctx, plan, "supervisor"); err != nil {
+		t.Fatalf("ApplyUndo failed: %v", err)
+	}
+
+	for _, id := range []string{a.ID, b.ID} {
+		issue, _ := store.GetIssue(ctx, id)
+		if issue.Priority != 2 || issue.Assignee != "" || issue.Status != types.StatusOpen || issue.ClosedAt != nil {
+			t.Errorf("%s not restored: priority=%d assignee=%q status=%s", id, issue.Priority, issue.Assignee, issue.Status)
+		}
+	}
+	labels, _ := store.GetLabels(ctx, a.ID)
+	if len(labels) != 1 || labels[0] != "keep" {
+		t.Errorf("expected labels [keep], got %v", labels)
+	}
+	deps, _ := store.GetDependencyRecords(ctx, b.ID)
+	if len(deps) != 1 || deps[0].Type != types.DepParentChild {
+		t.Errorf("expected parent-child dependency restored, got %+v", deps)
+	}
+
+	// The undo is itself recorded and cannot be applied twice
+	undone := 0
+	for _, e := range eventsByActor(t, store, "supervisor") {
+		if e.EventType == types.EventUndone {
+			undone++
+		}
+	}
+	if undone != 7 {
+		t.Errorf("expected 7 undone events, got %d", undone)
+	}
+	again, err := store.PlanUndo(ctx, eventsByActor(t, store, "agent-7"))
+	if err != nil {
+		t.Fatalf("PlanUndo failed: %v", err)
+	}
+	if len(again.Conflicts) == 0 {
+		t.Error("expected conflicts when undoing the same events twice")
+	}
+}
+
+func TestUndoRefusesOnLaterConflict(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := createUndoTestIssue(t, store, "Contested")
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0}, "agent-7"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1}, "human"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	plan, err := store.PlanUndo(ctx, eventsByActor(t, store, "agent-7"))
+	if err != nil {
+		t.Fatalf("PlanUndo failed: %v", err)
+	}
+	if len(plan.Conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %d", len(plan.Conflicts))
+	}
+	if err := store.ApplyUndo(ctx, plan, "supervisor"); err == nil {
+		t.Fatal("expected ApplyUndo to refuse a conflicting plan")
+	}
+
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Priority != 1 {
+		t.Errorf("expected priority to stay 1, got %d", got.Priority)
+	}
+
+	// An unrelated later change does not conflict
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"notes": "x"}, "agent-7"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Renamed"}, "human"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	events := eventsByActor(t, store, "agent-7")
+	plan, err = store.PlanUndo(ctx, events[:1])
+	if err != nil {
+		t.Fatalf("PlanUndo failed: %v", err)
+	}
+	if len(plan.Conflicts) != 0 || len(plan.Operations) != 1 {
+		t.Fatalf("expected 1 clean operation, got %d ops / %d conflicts", len(plan.Operations), len(plan.Conflicts))
+	}
+}
+
+func TestUndoSkipsIrreversibleEvents(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := createUndoTestIssue(t, store, "Commented")
+	if err := store.AddComment(ctx, issue.ID, "setup", "hello"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	plan, err := store.PlanUndo(ctx, eventsByActor(t, store, "setup"))
+	if err != nil {
+		t.Fatalf("PlanUndo failed: %v", err)
+	}
+	if len(plan.Operations) != 0 || len(plan.Skipped) != 2 {
+		t.Errorf("expected creation and comment to be skipped, got %d ops / %d skipped", len(plan.Operations), len(plan.Skipped))
+	}
+}
+
+func TestSearchEventsUndoableLimit(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := createUndoTestIssue(t, store, "A")
+	if err := store.AddLabel(ctx, issue.ID, "first", "agent-7"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, issue.ID, "second", "agent-7"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "agent-7", "newest, but not reversible"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	events, err := store.SearchEvents(ctx, types.EventFilter{Types: UndoableEventTypes, Limit: 1})
+	if err != nil {
+		t.Fatalf("SearchEvents failed: %v", err)
+	}
+	if len(events) != 1 || events[0].EventType != types.EventLabelAdded || events[0].Comment == nil || *events[0].Comment != "Added label: second" {
+		t.Fatalf("expected only the newest reversible event, got %+v", events)
+	}
+}
+
+func TestSearchEventsSinceInLocalTime(t *testing.T) {
+	// Events are stamped in UTC; a zone ahead of UTC used to push Since past them
+	local := time.Local
+	time.Local = time.FixedZone("CEST", 2*60*60)
+	defer func() { time.Local = local }()
+
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := createUndoTestIssue(t, store, "A")
+	if err := store.AddLabel(ctx, issue.ID, "recent", "agent-7"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	since := time.Now().Add(-time.Hour)
+	events, err := store.SearchEvents(ctx, types.EventFilter{Actor: "agent-7", Since: &since})
+	if err != nil {
+		t.Fatalf("SearchEvents failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected the event from the last hour, got %d", len(events))
+	}
+
+	later := time.Now().Add(time.Hour)
+	events, err = store.SearchEvents(ctx, types.EventFilter{Actor: "agent-7", Since: &later})
+	if err != nil {
+		t.Fatalf("SearchEvents failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events after now, got %d", len(events))
+	}
+}
@@ -0,0 +1,343 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddWorkLog records a work-log entry. Entries are identified by issue, actor and
+// start time, so adding the same entry again (e.g. on re-import) is a no-op, except
+// that a finished entry completes a matching running timer.
+func (s *SQLiteStorage) AddWorkLog(ctx context.Context, entry *types.WorkLog, actor string) error {
+	if entry.Minutes < 0 {
+		return fmt.Errorf("minutes cannot be negative")
+	}
+	if entry.StartedAt.IsZero() {
+		entry.StartedAt = time.Now().Add(-time.Duration(entry.Minutes) * time.Minute)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO work_logs (issue_id, actor, started_at, ended_at, minutes, note)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (issue_id, actor, started_at) DO UPDATE
+			SET ended_at = excluded.ended_at, minutes = excluded.minutes, note = excluded.note
+			WHERE work_logs.ended_at IS NULL AND excluded.ended_at IS NOT NULL
+	`, entry.IssueID, entry.Actor, entry.StartedAt.UTC(), utcTime(entry.EndedAt), entry.Minutes, entry.Note)
+	if err != nil {
+		return fmt.Errorf("failed to add work log: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return nil // Already recorded
+	}
+	entry.ID, _ = result.LastInsertId()
+
+	if entry.EndedAt != nil {
+		if err := recordWorkLogEventTx(ctx, tx, entry, actor); err != nil {
+			return err
+		}
+	}
+	if err := markIssuesDirtyTx(ctx, tx, []string{entry.IssueID}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// StartWorkLog starts a timer for actor on an issue. Each actor can have one
+// running timer per issue; the check is part of the insert so concurrent starts
+// can't both succeed.
+func (s *SQLiteStorage) StartWorkLog(ctx context.Context, issueID, actor, note string) (*types.WorkLog, error) {
+	issue, err := s.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	entry := &types.WorkLog{IssueID: issueID, Actor: actor, StartedAt: time.Now(), Note: note}
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO work_logs (issue_id, actor, started_at, note)
+		SELECT ?, ?, ?, ?
+		WHERE NOT EXISTS (
+			SELECT 1 FROM work_logs WHERE issue_id = ? AND actor = ? AND ended_at IS NULL
+		)
+	`, issueID, actor, entry.StartedAt.UTC(), note, issueID, actor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start work log: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		running, err := s.runningWorkLog(ctx, tx, issueID, actor)
+		if err != nil {
+			return nil, err
+		}
+		if running == nil {
+			return nil, fmt.Errorf("%s already has a timer running on %s", actor, issueID)
+		}
+		return nil, fmt.Errorf("%s already has a timer running on %s since %s", actor, issueID, running.StartedAt.Local().Format("15:04"))
+	}
+	entry.ID, _ = result.LastInsertId()
+
+	if err := markIssuesDirtyTx(ctx, tx, []string{issueID}); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return entry, nil
+}
+
+// StopWorkLog stops the running timer for actor on an issue and records the elapsed minutes
+func (s *SQLiteStorage) StopWorkLog(ctx context.Context, issueID, actor string) (*types.WorkLog, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	entry, err := s.runningWorkLog(ctx, tx, issueID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("no timer running on %s for %s", issueID, actor)
+	}
+
+	now := time.Now()
+	entry.EndedAt = &now
+	entry.Minutes = int(math.Round(now.Sub(entry.StartedAt).Minutes()))
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE work_logs SET ended_at = ?, minutes = ? WHERE id = ?
+	`, now.UTC(), entry.Minutes, entry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop work log: %w", err)
+	}
+	if err := recordWorkLogEventTx(ctx, tx, entry, actor); err != nil {
+		return nil, err
+	}
+	if err := markIssuesDirtyTx(ctx, tx, []string{issueID}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return entry, nil
+}
+
+// GetWorkLogs returns the work-log entries of an issue, oldest first
+func (s *SQLiteStorage) GetWorkLogs(ctx context.Context, issueID string) ([]*types.WorkLog, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, actor, started_at, ended_at, minutes, note
+		FROM work_logs
+		WHERE issue_id = ?
+		ORDER BY started_at, id
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work logs: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWorkLogs(rows)
+}
+
+// GetAllWorkLogs returns all work-log entries grouped by issue ID (for export)
+func (s *SQLiteStorage) GetAllWorkLogs(ctx context.Context) (map[string][]*types.WorkLog, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, actor, started_at, ended_at, minutes, note
+		FROM work_logs
+		ORDER BY issue_id, started_at, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work logs: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanWorkLogs(rows)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]*types.WorkLog)
+	for _, entry := range entries {
+		result[entry.IssueID] = append(result[entry.IssueID], entry)
+	}
+	return result, nil
+}
+
+// GetEstimateActuals compares estimated and actual effort for every closed issue.
+// Actual effort is the logged time when any was logged, otherwise the time the
+// issue spent in_progress according to its status change events.
+func (s *SQLiteStorage) GetEstimateActuals(ctx context.Context) ([]*types.EstimateActual, error) {
+	closed := types.StatusClosed
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{Status: &closed})
+	if err != nil {
+		return nil, err
+	}
+
+	allLogs, err := s.GetAllWorkLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*types.EstimateActual, 0, len(issues))
+	for _, issue := range issues {
+		labels, err := s.GetLabels(ctx, issue.ID)
+		if err != nil {
+			return nil, err
+		}
+		elapsed, err := s.elapsedInProgress(ctx, issue.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &types.EstimateActual{
+			IssueID:          issue.ID,
+			Title:            issue.Title,
+			IssueType:        issue.IssueType,
+			Assignee:         issue.Assignee,
+			Labels:           labels,
+			EstimatedMinutes: issue.EstimatedMinutes,
+			ElapsedMinutes:   int(math.Round(elapsed.Minutes())),
+		}
+		for _, entry := range allLogs[issue.ID] {
+			if entry.EndedAt != nil {
+				result.LoggedMinutes += entry.Minutes
+			}
+		}
+		result.ActualMinutes = result.ElapsedMinutes
+		if result.LoggedMinutes > 0 {
+			result.ActualMinutes = result.LoggedMinutes
+		}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].IssueID < results[j].IssueID
+	})
+	return results, nil
+}
+
+// elapsedInProgress sums the time an issue spent in_progress: each interval starts
+// with a change to in_progress and ends with the next change to another status.
+func (s *SQLiteStorage) elapsedInProgress(ctx context.Context, issueID string) (time.Duration, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE issue_id = ? AND event_type IN (?, ?, ?)
+		ORDER BY created_at, id
+	`, issueID, types.EventStatusChanged, types.EventClosed, types.EventReopened)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get status events: %w", err)
+	}
+	defer rows.Close()
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	var total time.Duration
+	var since *time.Time
+	for _, event := range events {
+		status, _ := eventStatus(event)
+		inProgress := status == types.StatusInProgress
+
+		switch {
+		case inProgress && since == nil:
+			t := event.CreatedAt
+			since = &t
+		case !inProgress && since != nil:
+			total += event.CreatedAt.Sub(*since)
+			since = nil
+		}
+	}
+	return total, nil
+}
+
+// runningWorkLog returns the running timer for actor on an issue, or nil
+func (s *SQLiteStorage) runningWorkLog(ctx context.Context, q interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}, issueID, actor string) (*types.WorkLog, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, issue_id, actor, started_at, ended_at, minutes, note
+		FROM work_logs
+		WHERE issue_id = ? AND actor = ? AND ended_at IS NULL
+		ORDER BY started_at DESC
+		LIMIT 1
+	`, issueID, actor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running timer: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanWorkLogs(rows)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+// recordWorkLogEventTx records a time_logged event for a finished entry
+func recordWorkLogEventTx(ctx context.Context, tx *sql.Tx, entry *types.WorkLog, actor string) error {
+	comment := fmt.Sprintf("Logged %dm", entry.Minutes)
+	if entry.Note != "" {
+		comment += ": " + entry.Note
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, entry.IssueID, types.EventTimeLogged, actor, comment)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	return nil
+}
+
+// scanWorkLogs scans work-log rows into WorkLog structs
+func scanWorkLogs(rows *sql.Rows) ([]*types.WorkLog, error) {
+	var entries []*types.WorkLog
+	for rows.Next() {
+		var entry types.WorkLog
+		var endedAt sql.NullTime
+
+		err := rows.Scan(
+			&entry.ID, &entry.IssueID, &entry.Actor, &entry.StartedAt,
+			&endedAt, &entry.Minutes, &entry.Note,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan work log: %w", err)
+		}
+		if endedAt.Valid {
+			entry.EndedAt = &endedAt.Time
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,232 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWorkLogTimer(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Timed", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if _, err := store.StopWorkLog(ctx, issue.ID, "alice"); err == nil {
+		t.Error("Expected error stopping a timer that isn't running")
+	}
+
+	if _, err := store.StartWorkLog(ctx, issue.ID, "alice", "digging in"); err != nil {
+		t.Fatalf("StartWorkLog failed: %v", err)
+	}
+	if _, err := store.StartWorkLog(ctx, issue.ID, "alice", ""); err == nil {
+		t.Error("Expected error starting a second timer for the same actor")
+	}
+	// Other actors can run their own timers
+	if _, err := store.StartWorkLog(ctx, issue.ID, "bob", ""); err != nil {
+		t.Fatalf("StartWorkLog for second actor failed: %v", err)
+	}
+
+	entry, err := store.StopWorkLog(ctx, issue.ID, "alice")
+	if err != nil {
+		t.Fatalf("StopWorkLog failed: %v", err)
+	}
+	if entry.EndedAt == nil || entry.Note != "digging in" {
+		t.Errorf("Expected finished entry with note, got %+v", entry)
+	}
+
+	logs, err := store.GetWorkLogs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWorkLogs failed: %v", err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(logs))
+	}
+	running := 0
+	for _, l := range logs {
+		if l.EndedAt == nil {
+			running++
+			if l.Actor != "bob" {
+				t.Errorf("Expected bob's timer to still run, got %s", l.Actor)
+			}
+		}
+	}
+	if running != 1 {
+		t.Errorf("Expected 1 running timer, got %d", running)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	found := false
+	for _, e := range events {
+		if e.EventType == types.EventTimeLogged && e.Comment != nil && strings.Contains(*e.Comment, "digging in") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected time_logged event")
+	}
+}
+
+func TestAddWorkLogIdempotent(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Logged", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	started := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	running := &types.WorkLog{IssueID: issue.ID, Actor: "alice", StartedAt: started}
+	if err := store.AddWorkLog(ctx, running, "import"); err != nil {
+		t.Fatalf("AddWorkLog failed: %v", err)
+	}
+
+	// A finished copy of the same entry (e.g. from another clone) completes it
+	ended := started.Add(45 * time.Minute)
+	finished := &types.WorkLog{IssueID: issue.ID, Actor: "alice", StartedAt: started.Local(), EndedAt: &ended, Minutes: 45, Note: "review"}
+	for i := 0; i < 2; i++ {
+		if err := store.AddWorkLog(ctx, finished, "import"); err != nil {
+			t.Fatalf("AddWorkLog failed: %v", err)
+		}
+	}
+
+	logs, err := store.GetWorkLogs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWorkLogs failed: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("Expected 1 entry after re-adding, got %d", len(logs))
+	}
+	if logs[0].EndedAt == nil || logs[0].Minutes != 45 || logs[0].Note != "review" {
+		t.Errorf("Expected running entry to be completed, got %+v", logs[0])
+	}
+
+	if err := store.AddWorkLog(ctx, &types.WorkLog{IssueID: issue.ID, Actor: "alice", Minutes: -1}, "test"); err == nil {
+		t.Error("Expected error for negative minutes")
+	}
+}
+
+func TestGetEstimateActuals(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	estimate := func(m int) *int { return &m }
+	logged := &types.Issue{Title: "Logged", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, EstimatedMinutes: estimate(60)}
+	elapsed := &types.Issue{Title: "Elapsed", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug, EstimatedMinutes: estimate(30), Assignee: "bob"}
+	open := &types.Issue{Title: "Open", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{logged, elapsed, open} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	store.AddLabel(ctx, logged.ID, "backend", "test-user")
+
+	ended := time.Now()
+	for _, minutes := range []int{50, 40} {
+		entry := &types.WorkLog{IssueID: logged.ID, Actor: "alice", StartedAt: ended.Add(-time.Duration(minutes+1) * time.Hour), EndedAt: &ended, Minutes: minutes}
+		if err := store.AddWorkLog(ctx, entry, "alice"); err != nil {
+			t.Fatalf("AddWorkLog failed: %v", err)
+		}
+	}
+	// A running timer doesn't count
+	if _, err := store.StartWorkLog(ctx, logged.ID, "bob", ""); err != nil {
+		t.Fatalf("StartWorkLog failed: %v", err)
+	}
+
+	// Two in_progress intervals: 20m and 25m
+	for _, e := range []struct{ eventType, newValue, at string }{
+		{"status_changed", `{"status":"in_progress"}`, "2025-03-01 09:00:00"},
+		{"status_changed", `{"status":"open"}`, "2025-03-01 09:20:00"},
+		{"status_changed", `{"status":"in_progress"}`, "2025-03-02 10:00:00"},
+		{"closed", "", "2025-03-02 10:25:00"},
+	} {
+		_, err := store.db.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, new_value, created_at)
+			VALUES (?, ?, 'bob', NULLIF(?, ''), ?)
+		`, elapsed.ID, e.eventType, e.newValue, e.at)
+		if err != nil {
+			t.Fatalf("Failed to insert event: %v", err)
+		}
+	}
+
+	for _, issue := range []*types.Issue{logged, elapsed} {
+		if err := store.CloseIssue(ctx, issue.ID, "done", "test-user"); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+	}
+
+	actuals, err := store.GetEstimateActuals(ctx)
+	if err != nil {
+		t.Fatalf("GetEstimateActuals failed: %v", err)
+	}
+	if len(actuals) != 2 {
+		t.Fatalf("Expected 2 closed issues, got %d", len(actuals))
+	}
+
+	byID := make(map[string]*types.EstimateActual)
+	for _, a := range actuals {
+		byID[a.IssueID] = a
+	}
+
+	if a := byID[logged.ID]; a.LoggedMinutes != 90 || a.ActualMinutes != 90 {
+		t.Errorf("Expected 90 logged/actual minutes, got %+v", a)
+	} else if len(a.Labels) != 1 || a.Labels[0] != "backend" {
+		t.Errorf("Expected backend label, got %v", a.Labels)
+	}
+	if a := byID[elapsed.ID]; a.LoggedMinutes != 0 || a.ElapsedMinutes != 45 || a.ActualMinutes != 45 {
+		t.Errorf("Expected 45 elapsed/actual minutes, got %+v", a)
+	}
+}
+
+func TestStartWorkLogConcurrent(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Race", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var started atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.StartWorkLog(ctx, issue.ID, "alice", ""); err == nil {
+				started.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := started.Load(); n != 1 {
+		t.Errorf("Expected exactly one timer to start, got %d", n)
+	}
+	logs, err := store.GetWorkLogs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWorkLogs failed: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Errorf("Expected one running work log, got %d", len(logs))
+	}
+}
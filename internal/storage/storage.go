@@ -3,6 +3,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -14,9 +15,14 @@ type Storage interface {
 	CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error
 	GetIssue(ctx context.Context, id string) (*types.Issue, error)
 	UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error
+	UpdateIssues(ctx context.Context, ids []string, updates map[string]interface{}, addLabels, removeLabels []string, actor string) error
 	CloseIssue(ctx context.Context, id string, reason string, actor string) error
 	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
 
+	// Recurring issues
+	SpawnNextOccurrence(ctx context.Context, issueID, actor string) (*types.Issue, error)
+	SpawnDueOccurrences(ctx context.Context, now time.Time, actor string) ([]*types.Issue, error)
+
 	// Dependencies
 	AddDependency(ctx context.Context, dep *types.Dependency, actor string) error
 	RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error
@@ -25,6 +31,7 @@ type Storage interface {
 	GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error)
 	GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error)
 	GetDependencyTree(ctx context.Context, issueID string, maxDepth int) ([]*types.TreeNode, error)
+	GetDescendants(ctx context.Context, issueID string, maxDepth int) ([]*types.TreeNode, error)
 	DetectCycles(ctx context.Context) ([][]*types.Issue, error)
 
 	// Labels
@@ -40,9 +47,21 @@ type Storage interface {
 	// Events
 	AddComment(ctx context.Context, issueID, actor, comment string) error
 	GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error)
+	SearchEvents(ctx context.Context, filter types.EventFilter) ([]*types.Event, error)
+	GetStatusHistory(ctx context.Context) (map[string][]*types.StatusChange, error)
+
+	// Time tracking
+	AddWorkLog(ctx context.Context, entry *types.WorkLog, actor string) error
+	StartWorkLog(ctx context.Context, issueID, actor, note string) (*types.WorkLog, error)
+	StopWorkLog(ctx context.Context, issueID, actor string) (*types.WorkLog, error)
+	GetWorkLogs(ctx context.Context, issueID string) ([]*types.WorkLog, error)
+	GetAllWorkLogs(ctx context.Context) (map[string][]*types.WorkLog, error)
+	GetEstimateActuals(ctx context.Context) ([]*types.EstimateActual, error)
 
 	// Statistics
 	GetStatistics(ctx context.Context) (*types.Statistics, error)
+	GetStatisticsBy(ctx context.Context, groupBy string, closedSince *time.Time) ([]*types.StatisticsGroup, error)
+	GetEpicStatus(ctx context.Context, epicID string) (*types.EpicStatus, error)
 
 	// Dirty tracking (for incremental JSONL export)
 	GetDirtyIssues(ctx context.Context) ([]string, error)
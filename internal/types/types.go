@@ -23,12 +23,16 @@ type Issue struct {
 	UpdatedAt          time.Time      `json:"updated_at"`
 	ClosedAt           *time.Time     `json:"closed_at,omitempty"`
 	ExternalRef        *string        `json:"external_ref,omitempty"` // e.g., "gh-9", "jira-ABC"
+	DueAt              *time.Time     `json:"due_at,omitempty"`
+	DeferUntil         *time.Time     `json:"defer_until,omitempty"` // Hidden from ready work until this time
+	Recurrence         string         `json:"recurrence,omitempty"`  // Rule for spawning the next occurrence, e.g. "weekly"
 	CompactionLevel    int            `json:"compaction_level,omitempty"`
 	CompactedAt        *time.Time     `json:"compacted_at,omitempty"`
 	CompactedAtCommit  *string        `json:"compacted_at_commit,omitempty"` // Git commit hash when compacted
 	OriginalSize       int            `json:"original_size,omitempty"`
 	Labels             []string       `json:"labels,omitempty"`       // Populated only for export/import
 	Dependencies       []*Dependency  `json:"dependencies,omitempty"` // Populated only for export/import
+	WorkLogs           []*WorkLog     `json:"work_logs,omitempty"`    // Populated only for export/import
 }
 
 // Validate checks if the issue has valid field values
@@ -111,6 +115,12 @@ type Dependency struct {
 	CreatedBy   string         `json:"created_by"`
 }
 
+// BatchRef returns a placeholder DependsOnID that refers to the issue at the given
+// index of a CreateIssues batch, for linking issues that don't have IDs yet
+func BatchRef(index int) string {
+	return fmt.Sprintf("#%d", index)
+}
+
 // DependencyType categorizes the relationship
 type DependencyType string
 
@@ -165,8 +175,42 @@ const (
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
 	EventCompacted         EventType = "compacted"
+	EventUndone            EventType = "undone"
+	EventTimeLogged        EventType = "time_logged"
 )
 
+// WorkLog records actual time spent on an issue. An entry with no EndedAt is a
+// running timer started with 'bd time start'.
+type WorkLog struct {
+	ID        int64      `json:"-"` // Local row ID; entries are identified across clones by issue, actor and start time
+	IssueID   string     `json:"issue_id"`
+	Actor     string     `json:"actor"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Minutes   int        `json:"minutes"`
+	Note      string     `json:"note,omitempty"`
+}
+
+// EstimateActual compares the estimate of a closed issue with the effort it took
+type EstimateActual struct {
+	IssueID          string    `json:"issue_id"`
+	Title            string    `json:"title"`
+	IssueType        IssueType `json:"issue_type"`
+	Assignee         string    `json:"assignee,omitempty"`
+	Labels           []string  `json:"labels,omitempty"`
+	EstimatedMinutes *int      `json:"estimated_minutes,omitempty"`
+	LoggedMinutes    int       `json:"logged_minutes"`  // Sum of finished work-log entries
+	ElapsedMinutes   int       `json:"elapsed_minutes"` // Time spent in_progress according to the audit trail
+	ActualMinutes    int       `json:"actual_minutes"`  // Logged time if any was logged, otherwise elapsed time
+}
+
+// StatusChange is one status transition recorded in the audit trail
+type StatusChange struct {
+	IssueID string    `json:"issue_id"`
+	Status  Status    `json:"status"`
+	At      time.Time `json:"at"`
+}
+
 // BlockedIssue extends Issue with blocking information
 type BlockedIssue struct {
 	Issue
@@ -192,6 +236,34 @@ type Statistics struct {
 	AverageLeadTime  float64 `json:"average_lead_time_hours"`
 }
 
+// StatisticsGroup is one row of a statistics breakdown by label, assignee, type or
+// priority. Blocked and ready use the same rules as Statistics.
+type StatisticsGroup struct {
+	Key              string  `json:"key"` // Label, assignee, issue type or "P0"-"P4"; "" for none
+	TotalIssues      int     `json:"total_issues"`
+	OpenIssues       int     `json:"open_issues"`
+	InProgressIssues int     `json:"in_progress_issues"`
+	BlockedIssues    int     `json:"blocked_issues"`
+	ReadyIssues      int     `json:"ready_issues"`
+	ClosedIssues     int     `json:"closed_issues"`           // Closed within the window, if one was given
+	AverageLeadTime  float64 `json:"average_lead_time_hours"` // Over the same closed issues
+}
+
+// EpicStatus is a progress rollup over all parent-child descendants of an epic
+type EpicStatus struct {
+	EpicID                    string         `json:"epic_id"`
+	TotalChildren             int            `json:"total_children"`
+	StatusCounts              map[Status]int `json:"status_counts"`
+	PercentComplete           float64        `json:"percent_complete"`             // Closed children / all children
+	EstimatedMinutes          int            `json:"estimated_minutes"`            // Sum over estimated children
+	ClosedEstimatedMinutes    int            `json:"closed_estimated_minutes"`     // Sum over closed estimated children
+	PercentCompleteByEstimate float64        `json:"percent_complete_by_estimate"` // Closed estimate / total estimate
+	UnestimatedChildren       int            `json:"unestimated_children"`
+	Ready                     []string       `json:"ready"`   // Open children with no open blockers that are not deferred
+	Blocked                   []string       `json:"blocked"` // Unclosed children blocked directly or via an ancestor
+	LastActivity              *Event         `json:"last_activity,omitempty"`
+}
+
 // IssueFilter is used to filter issue queries
 type IssueFilter struct {
 	Status      *Status
@@ -200,13 +272,30 @@ type IssueFilter struct {
 	Assignee    *string
 	Labels      []string
 	TitleSearch string
+	DueBefore   *time.Time // Only issues due before this time
+	DueAfter    *time.Time // Only issues due at or after this time
+	Overdue     bool       // Only unclosed issues whose due date has passed
+	Recurring   bool       // Only issues with a recurrence rule
 	Limit       int
 }
 
+// EventFilter is used to filter audit trail queries
+type EventFilter struct {
+	IDs     []int64     // Specific event IDs
+	IssueID string      // Only events for this issue
+	Actor   string      // Only events by this actor
+	Since   *time.Time  // Only events created at or after this time
+	Types   []EventType // Only events of these types
+	Limit   int
+}
+
 // WorkFilter is used to filter ready work queries
 type WorkFilter struct {
-	Status   Status
-	Priority *int
-	Assignee *string
-	Limit    int
+	Status          Status
+	Priority        *int
+	Assignee        *string
+	DueBefore       *time.Time // Only issues due before this time
+	IncludeDeferred bool       // Include issues whose defer_until is still in the future
+	SortByDue       bool       // Order by due date (undated last) instead of priority
+	Limit           int
 }